@@ -32,6 +32,9 @@ const (
 	InstanceFamilyLabelKey = Group + "/instance-family"
 	InstanceMemoryLabelKey = Group + "/instance-memory"
 	InstanceCPULabelKey    = Group + "/instance-cpu"
+
+	// DeviceDriver is the name of the DRA driver that allocates the synthetic devices of kwok instance types
+	DeviceDriver = "gpu." + Group
 )
 
 // Hard coded Kwok values
@@ -36,6 +36,7 @@ type InstanceTypeOptions struct {
 	Architecture       string
 	OperatingSystems   sets.Set[string]
 	Resources          v1.ResourceList
+	Devices            cloudprovider.Devices
 	InstanceTypeLabels map[string]string
 }
 
@@ -81,6 +82,8 @@ func ConstructInstanceTypes() []*cloudprovider.InstanceType {
 							v1.ResourcePods:             resource.MustParse(fmt.Sprintf("%d", pods)),
 							v1.ResourceEphemeralStorage: resource.MustParse("20G"),
 						},
+						// Larger instance types expose synthetic devices so that DRA ResourceClaims can be tested
+						Devices:            lo.Ternary(cpu >= 8, cloudprovider.Devices{{Driver: DeviceDriver, Count: cpu / 8}}, nil),
 						InstanceTypeLabels: labels,
 					}
 					price := PriceFromResources(opts.Resources)
@@ -128,6 +131,7 @@ func newInstanceType(options InstanceTypeOptions) *cloudprovider.InstanceType {
 				v1.ResourceMemory: resource.MustParse("10Mi"),
 			},
 		},
+		Devices: options.Devices,
 	}
 }
 
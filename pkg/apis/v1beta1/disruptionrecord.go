@@ -0,0 +1,118 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DisruptionRecordSpec describes a disruption command that Karpenter executed
+type DisruptionRecordSpec struct {
+	// CommandID is the ID of the command, which is included in each log message about the command
+	// +required
+	CommandID string `json:"commandID"`
+	// Method is the disruption method that chose the command
+	// +required
+	Method string `json:"method"`
+	// ConsolidationType is the type of consolidation that chose the command, if the command is a consolidation
+	// +optional
+	ConsolidationType string `json:"consolidationType,omitempty"`
+	// Candidates are the nodes that the command disrupted
+	// +required
+	Candidates []DisruptionRecordCandidate `json:"candidates"`
+	// Replacements are the names of the NodeClaims that the command launched to replace its candidates
+	// +optional
+	Replacements []string `json:"replacements,omitempty"`
+	// PriceBefore is the hourly price of the candidates
+	// +optional
+	PriceBefore string `json:"priceBefore,omitempty"`
+	// PriceAfter is the estimated hourly price of the replacements, assuming that each launches with its cheapest
+	// compatible offering
+	// +optional
+	PriceAfter string `json:"priceAfter,omitempty"`
+}
+
+// DisruptionRecordCandidate describes a node that a disruption command disrupted
+type DisruptionRecordCandidate struct {
+	// NodeClaim is the name of the candidate's NodeClaim
+	// +required
+	NodeClaim string `json:"nodeClaim"`
+	// Node is the name of the candidate's node
+	// +optional
+	Node string `json:"node,omitempty"`
+	// NodePool is the name of the NodePool that the candidate belongs to
+	// +optional
+	NodePool string `json:"nodePool,omitempty"`
+	// InstanceType is the candidate's instance type
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+	// CapacityType is the candidate's capacity type
+	// +optional
+	CapacityType string `json:"capacityType,omitempty"`
+	// Zone is the candidate's zone
+	// +optional
+	Zone string `json:"zone,omitempty"`
+}
+
+// DisruptionOutcome is how a disruption command ended
+type DisruptionOutcome string
+
+const (
+	DisruptionOutcomeSucceeded         DisruptionOutcome = "Succeeded"
+	DisruptionOutcomeTimedOut          DisruptionOutcome = "TimedOut"
+	DisruptionOutcomeReplacementFailed DisruptionOutcome = "ReplacementFailed"
+)
+
+// DisruptionRecordStatus defines how the disruption command ended
+type DisruptionRecordStatus struct {
+	// Outcome is how the command ended. It's empty while the command is in flight.
+	// +kubebuilder:validation:Enum:={Succeeded,TimedOut,ReplacementFailed}
+	// +optional
+	Outcome DisruptionOutcome `json:"outcome,omitempty"`
+	// Message explains why the command didn't succeed
+	// +optional
+	Message string `json:"message,omitempty"`
+	// CompletionTime is when the command ended
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// DisruptionRecord is the Schema for the DisruptionRecords API. Karpenter creates a DisruptionRecord for each
+// disruption command that it executes and deletes it once it's older than the configured TTL.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=disruptionrecords,scope=Cluster,categories=karpenter
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Method",type="string",JSONPath=".spec.method",description=""
+// +kubebuilder:printcolumn:name="Outcome",type="string",JSONPath=".status.outcome",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+// +kubebuilder:printcolumn:name="ConsolidationType",type="string",JSONPath=".spec.consolidationType",priority=1,description=""
+type DisruptionRecord struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DisruptionRecordSpec   `json:"spec,omitempty"`
+	Status DisruptionRecordStatus `json:"status,omitempty"`
+}
+
+// DisruptionRecordList contains a list of DisruptionRecords
+// +kubebuilder:object:root=true
+type DisruptionRecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DisruptionRecord `json:"items"`
+}
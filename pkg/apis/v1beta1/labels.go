@@ -30,6 +30,7 @@ const (
 	ArchitectureArm64    = "arm64"
 	CapacityTypeSpot     = "spot"
 	CapacityTypeOnDemand = "on-demand"
+	CapacityTypeReserved = "reserved"
 )
 
 // Karpenter specific domains and labels
@@ -42,10 +43,16 @@ const (
 
 // Karpenter specific annotations
 const (
-	DoNotDisruptAnnotationKey          = Group + "/do-not-disrupt"
-	ProviderCompatabilityAnnotationKey = CompatabilityGroup + "/provider"
-	ManagedByAnnotationKey             = Group + "/managed-by"
-	NodePoolHashAnnotationKey          = Group + "/nodepool-hash"
+	DoNotDisruptAnnotationKey            = Group + "/do-not-disrupt"
+	ProviderCompatabilityAnnotationKey   = CompatabilityGroup + "/provider"
+	ManagedByAnnotationKey               = Group + "/managed-by"
+	NodePoolHashAnnotationKey            = Group + "/nodepool-hash"
+	PreProvisionAnnotationKey            = Group + "/pre-provision"
+	ProvisioningExplanationAnnotationKey = Group + "/provisioning-explanation"
+	ReservationIDAnnotationKey           = Group + "/reservation-id"
+	BatchIdleDurationAnnotationKey       = Group + "/batch-idle-duration"
+	BatchMaxDurationAnnotationKey        = Group + "/batch-max-duration"
+	DisruptionCommandAnnotationKey       = Group + "/disruption-command"
 )
 
 // Karpenter specific finalizers
@@ -36,9 +36,10 @@ type NodeClaimSpec struct {
 	// Requirements are layered with GetLabels and applied to every node.
 	// +kubebuilder:validation:XValidation:message="requirements with operator 'In' must have a value defined",rule="self.all(x, x.operator == 'In' ? x.values.size() != 0 : true)"
 	// +kubebuilder:validation:XValidation:message="requirements operator 'Gt' or 'Lt' must have a single positive integer value",rule="self.all(x, (x.operator == 'Gt' || x.operator == 'Lt') ? (x.values.size() == 1 && int(x.values[0]) >= 0) : true)"
+	// +kubebuilder:validation:XValidation:message="requirements with 'minValues' must have at least that many values specified in the 'values' field",rule="self.all(x, (x.operator == 'In' && has(x.minValues)) ? x.values.size() >= x.minValues : true)"
 	// +kubebuilder:validation:MaxItems:=30
 	// +required
-	Requirements []v1.NodeSelectorRequirement `json:"requirements" hash:"ignore"`
+	Requirements []NodeSelectorRequirementWithMinValues `json:"requirements" hash:"ignore"`
 	// Resources models the resource requirements for the NodeClaim to launch
 	// +optional
 	Resources ResourceRequirements `json:"resources,omitempty" hash:"ignore"`
@@ -52,6 +53,28 @@ type NodeClaimSpec struct {
 	// NodeClassRef is a reference to an object that defines provider specific configuration
 	// +required
 	NodeClassRef *NodeClassReference `json:"nodeClassRef"`
+	// TerminationGracePeriod is the maximum duration the controller will wait before forcefully deleting the pods on a node,
+	// measured from when deletion is first initiated. Once the grace period begins, eviction continues to respect
+	// PodDisruptionBudgets and the karpenter.sh/do-not-disrupt annotation. Pods that would not be granted their full
+	// terminationGracePeriodSeconds before the node's grace period expires are deleted directly at
+	// T = node grace period expiration - pod terminationGracePeriodSeconds, bypassing eviction. When the node's grace
+	// period expires, the instance is terminated regardless of any pods remaining on it.
+	// If left undefined, the controller will wait indefinitely for pods to be drained.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(s|m|h))+$`
+	// +kubebuilder:validation:Type="string"
+	// +optional
+	TerminationGracePeriod *metav1.Duration `json:"terminationGracePeriod,omitempty"`
+}
+
+// NodeSelectorRequirementWithMinValues is a NodeSelectorRequirement that can additionally express the minimum
+// number of distinct values that the instance types launched for a NodeClaim must span for its key
+type NodeSelectorRequirementWithMinValues struct {
+	v1.NodeSelectorRequirement `json:",inline"`
+	// MinValues is the minimum number of unique values required to define the flexibility of the specific requirement.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=50
+	// +optional
+	MinValues *int `json:"minValues,omitempty"`
 }
 
 // ResourceRequirements models the required resources for the NodeClaim to launch
@@ -134,13 +134,27 @@ func (in *NodeClaimSpec) validateTaintsField(taints []v1.Taint, existing map[tai
 // NodeClaim requirements only support well known labels.
 func (in *NodeClaimSpec) validateRequirements() (errs *apis.FieldError) {
 	for i, requirement := range in.Requirements {
-		if err := ValidateRequirement(requirement); err != nil {
+		if err := multierr.Append(ValidateRequirement(requirement.NodeSelectorRequirement), validateMinValues(requirement)); err != nil {
 			errs = errs.Also(apis.ErrInvalidArrayValue(err, "requirements", i))
 		}
 	}
 	return errs
 }
 
+func validateMinValues(requirement NodeSelectorRequirementWithMinValues) error {
+	if requirement.MinValues == nil {
+		return nil
+	}
+	var errs error
+	if lo.FromPtr(requirement.MinValues) < 1 || lo.FromPtr(requirement.MinValues) > 50 {
+		errs = multierr.Append(errs, fmt.Errorf("key %s with minValues %d must be between 1 and 50", requirement.Key, lo.FromPtr(requirement.MinValues)))
+	}
+	if requirement.Operator == v1.NodeSelectorOpIn && len(requirement.Values) < lo.FromPtr(requirement.MinValues) {
+		errs = multierr.Append(errs, fmt.Errorf("key %s with operator %s must have at least minValues %d values defined", requirement.Key, requirement.Operator, lo.FromPtr(requirement.MinValues)))
+	}
+	return errs
+}
+
 func ValidateRequirement(requirement v1.NodeSelectorRequirement) error { //nolint:gocyclo
 	var errs error
 	if normalized, ok := NormalizedLabels[requirement.Key]; ok {
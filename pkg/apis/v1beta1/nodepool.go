@@ -22,6 +22,7 @@ import (
 	"math"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/mitchellh/hashstructure/v2"
 	"github.com/robfig/cron/v3"
@@ -38,6 +39,8 @@ import (
 // launch nodes in response to pods that are unschedulable. A single nodepool
 // is capable of managing a diverse set of nodes. Node properties are determined
 // from a combination of nodepool and pod scheduling constraints.
+// Only integer node counts are compared here; minimums of other resources are checked against limits by the webhook.
+// +kubebuilder:validation:XValidation:message="the nodes minimum must not exceed the nodes limit",rule="!has(self.limits) || !has(self.minimums) || !('nodes' in self.limits) || !('nodes' in self.minimums) || !string(self.limits['nodes']).matches('^[0-9]+$') || !string(self.minimums['nodes']).matches('^[0-9]+$') || int(string(self.minimums['nodes'])) <= int(string(self.limits['nodes']))"
 type NodePoolSpec struct {
 	// Template contains the template of possibilities for the provisioning logic to launch a NodeClaim with.
 	// NodeClaims launched from this NodePool will often be further constrained than the template specifies.
@@ -45,13 +48,25 @@ type NodePoolSpec struct {
 	Template NodeClaimTemplate `json:"template"`
 	// Disruption contains the parameters that relate to Karpenter's disruption logic
 	// +kubebuilder:default={"consolidationPolicy": "WhenUnderutilized", "expireAfter": "720h"}
-	// +kubebuilder:validation:XValidation:message="consolidateAfter cannot be combined with consolidationPolicy=WhenUnderutilized",rule="has(self.consolidateAfter) ? self.consolidationPolicy != 'WhenUnderutilized' || self.consolidateAfter == 'Never' : true"
 	// +kubebuilder:validation:XValidation:message="consolidateAfter must be specified with consolidationPolicy=WhenEmpty",rule="self.consolidationPolicy == 'WhenEmpty' ? has(self.consolidateAfter) : true"
 	// +optional
 	Disruption Disruption `json:"disruption"`
 	// Limits define a set of bounds for provisioning capacity.
+	// In addition to resource names, a "nodes" limit bounds the number of nodes in the NodePool.
 	// +optional
 	Limits Limits `json:"limits,omitempty"`
+	// Minimums define a floor of capacity that Karpenter keeps provisioned for the NodePool, even when no pods
+	// are scheduled against it. In addition to resource names, a "nodes" minimum keeps that many nodes launched.
+	// Consolidation will not disrupt nodes if doing so would take the NodePool below its minimums.
+	// +optional
+	Minimums Minimums `json:"minimums,omitempty"`
+	// Headroom is spare capacity that Karpenter keeps available in the NodePool, expressed as virtual pods.
+	// Karpenter provisions nodes so that the virtual pods would schedule alongside the pods that are running,
+	// and consolidation won't disrupt nodes if doing so would leave the virtual pods unschedulable.
+	// Virtual pods are only simulated and are never created in the cluster.
+	// +kubebuilder:validation:MaxItems:=20
+	// +optional
+	Headroom []Headroom `json:"headroom,omitempty"`
 	// Weight is the priority given to the nodepool during scheduling. A higher
 	// numerical weight indicates that this nodepool will be ordered
 	// ahead of other nodepools with lower weights. A nodepool with no weight
@@ -60,12 +75,68 @@ type NodePoolSpec struct {
 	// +kubebuilder:validation:Maximum:=100
 	// +optional
 	Weight *int32 `json:"weight,omitempty"`
+	// SchedulingStrategy is how Karpenter bin-packs pods onto the NodeClaims that it launches for the NodePool and
+	// picks their instance types. LowestPrice launches the cheapest instance types that fit the pods, BestFit launches
+	// the instance types that the pods utilize the most, FewestNodes launches the largest instance types, and
+	// PricePerVCPU launches the instance types with the lowest price per vCPU. Defaults to LowestPrice.
+	// +optional
+	SchedulingStrategy SchedulingStrategy `json:"schedulingStrategy,omitempty"`
+	// Batching overrides how long Karpenter batches pending pods that would schedule to the NodePool before it
+	// provisions capacity for them. The batch durations that Karpenter is configured with are used for anything
+	// that isn't set.
+	// +optional
+	Batching *Batching `json:"batching,omitempty"`
+	// Paused is a list of operations that Karpenter won't perform for the NodePool. Pausing Provisioning stops
+	// Karpenter from launching NodeClaims for the NodePool, and pausing Disruption stops Karpenter from disrupting
+	// the NodePool's nodes. NodeClaims that are already launching or being disrupted aren't affected.
+	// +kubebuilder:validation:MaxItems=2
+	// +listType=set
+	// +optional
+	Paused []PausedOperation `json:"paused,omitempty"`
 }
 
+// Headroom is a group of identical virtual pods that reserve capacity in a NodePool
+type Headroom struct {
+	// Count is the number of virtual pods
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=1000
+	// +required
+	Count int32 `json:"count"`
+	// Requests are the resources requested by each virtual pod
+	// +required
+	Requests v1.ResourceList `json:"requests"`
+	// NodeSelector constrains the nodes that the virtual pods can schedule to. Virtual pods are always
+	// constrained to the NodePool and tolerate its taints.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// PausedOperation is an operation that can be paused for a NodePool
+// +kubebuilder:validation:Enum:={Provisioning,Disruption}
+type PausedOperation string
+
+const (
+	PausedOperationProvisioning PausedOperation = "Provisioning"
+	PausedOperationDisruption   PausedOperation = "Disruption"
+)
+
+// SchedulingStrategy is how Karpenter bin-packs pods and picks instance types for a NodePool
+// +kubebuilder:validation:Enum:={LowestPrice,BestFit,FewestNodes,PricePerVCPU}
+type SchedulingStrategy string
+
+const (
+	SchedulingStrategyLowestPrice  SchedulingStrategy = "LowestPrice"
+	SchedulingStrategyBestFit      SchedulingStrategy = "BestFit"
+	SchedulingStrategyFewestNodes  SchedulingStrategy = "FewestNodes"
+	SchedulingStrategyPricePerVCPU SchedulingStrategy = "PricePerVCPU"
+)
+
 type Disruption struct {
 	// ConsolidateAfter is the duration the controller will wait
 	// before attempting to terminate nodes that are underutilized.
 	// Refer to ConsolidationPolicy for how underutilization is considered.
+	// With consolidationPolicy=WhenUnderutilized, a node is only considered for consolidation
+	// once no pods have been scheduled to or removed from it for this duration.
 	// +kubebuilder:validation:Pattern=`^(([0-9]+(s|m|h))+)|(Never)$`
 	// +kubebuilder:validation:Type="string"
 	// +kubebuilder:validation:Schemaless
@@ -77,6 +148,14 @@ type Disruption struct {
 	// +kubebuilder:validation:Enum:={WhenEmpty,WhenUnderutilized}
 	// +optional
 	ConsolidationPolicy ConsolidationPolicy `json:"consolidationPolicy,omitempty"`
+	// ConsolidationThreshold is the minimum hourly savings that consolidation must achieve to delete or replace nodes
+	// that have pods, either as a price (e.g. "0.05") or as a percentage of the nodes' price (e.g. "10%"). Each node
+	// with pods adds the threshold to what the consolidation must save. A price is multiplied by
+	// 1 + log2(1 + the disruption cost of the node's pods), so consolidations that disrupt more pods need to save more.
+	// A percentage is taken of the node's price as is. If omitted, consolidation acts on any savings.
+	// +kubebuilder:validation:Pattern=`^(((100|[0-9]{1,2}(\.[0-9]+)?)%)|([0-9]+(\.[0-9]+)?))$`
+	// +optional
+	ConsolidationThreshold string `json:"consolidationThreshold,omitempty"`
 	// ExpireAfter is the duration the controller will wait
 	// before terminating a node, measured from when the node is created. This
 	// is useful to implement features like eventually consistent node upgrade,
@@ -96,10 +175,16 @@ type Disruption struct {
 	// +kubebuilder:validation:MaxItems=50
 	// +optional
 	Budgets []Budget `json:"budgets,omitempty" hash:"ignore"`
+	// DryRun makes Karpenter report the disruptions that it would perform on the NodePool's nodes through events and
+	// metrics, without performing them. Nodes aren't tainted and no replacement NodeClaims are launched.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // Budget defines when Karpenter will restrict the
 // number of Node Claims that can be terminating simultaneously.
+// +kubebuilder:validation:XValidation:message="'timeZone' must be set with 'schedule'",rule="has(self.timeZone) ? has(self.schedule) : true"
+// +kubebuilder:validation:XValidation:message="'timeZone' cannot be combined with a CRON_TZ or TZ prefix in 'schedule'",rule="has(self.timeZone) && has(self.schedule) ? !self.schedule.contains('TZ=') : true"
 type Budget struct {
 	// Nodes dictates the maximum number of NodeClaims owned by this NodePool
 	// that can be terminating at once. This is calculated by counting nodes that
@@ -113,7 +198,7 @@ type Budget struct {
 	Nodes string `json:"nodes" hash:"ignore"`
 	// Schedule specifies when a budget begins being active, following
 	// the upstream cronjob syntax. If omitted, the budget is always active.
-	// Timezones are not supported.
+	// The schedule is evaluated in UTC unless TimeZone is set.
 	// This field is required if Duration is set.
 	// +kubebuilder:validation:Pattern:=`^(@(annually|yearly|monthly|weekly|daily|midnight|hourly))|((.+)\s(.+)\s(.+)\s(.+)\s(.+))$`
 	// +optional
@@ -128,8 +213,44 @@ type Budget struct {
 	// +kubebuilder:validation:Type="string"
 	// +optional
 	Duration *metav1.Duration `json:"duration,omitempty" hash:"ignore"`
+	// TimeZone is the name of the IANA time zone that Schedule is evaluated in, such as "America/New_York".
+	// Schedule hits follow the local wall clock, so a budget stays aligned with local
+	// maintenance windows across daylight saving time transitions.
+	// This field can only be set if Schedule is set.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_+\-]+(/[A-Za-z0-9_+\-]+)*$`
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty" hash:"ignore"`
+	// Reasons is a list of disruption reasons that this budget applies to.
+	// If Reasons is not set, this budget applies to all disruption reasons.
+	// +optional
+	Reasons []DisruptionReason `json:"reasons,omitempty" hash:"ignore"`
+}
+
+// Batching is the window that pending pods are batched in before capacity is provisioned for them
+type Batching struct {
+	// IdleDuration is how long a batch waits without any new pending pods before it ends.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m))+$`
+	// +kubebuilder:validation:Type="string"
+	// +optional
+	IdleDuration *metav1.Duration `json:"idleDuration,omitempty"`
+	// MaxDuration is the longest that a batch lasts while new pending pods keep arriving.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m))+$`
+	// +kubebuilder:validation:Type="string"
+	// +optional
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
 }
 
+// DisruptionReason is the reason that Karpenter is disrupting a node
+// +kubebuilder:validation:Enum:={Underutilized,Empty,Drifted,Expired}
+type DisruptionReason string
+
+const (
+	DisruptionReasonUnderutilized DisruptionReason = "Underutilized"
+	DisruptionReasonEmpty         DisruptionReason = "Empty"
+	DisruptionReasonDrifted       DisruptionReason = "Drifted"
+	DisruptionReasonExpired       DisruptionReason = "Expired"
+)
+
 type ConsolidationPolicy string
 
 const (
@@ -137,6 +258,9 @@ const (
 	ConsolidationPolicyWhenUnderutilized ConsolidationPolicy = "WhenUnderutilized"
 )
 
+// ResourceNodes is the limit key that bounds the number of nodes that can be launched for a NodePool
+const ResourceNodes v1.ResourceName = "nodes"
+
 type Limits v1.ResourceList
 
 func (l Limits) ExceededBy(resources v1.ResourceList) error {
@@ -153,6 +277,22 @@ func (l Limits) ExceededBy(resources v1.ResourceList) error {
 	return nil
 }
 
+type Minimums v1.ResourceList
+
+// UnsatisfiedBy returns an error if any of the minimums is not met by the given resources. A minimum for a
+// resource that is absent from the given resources is treated as unmet.
+func (m Minimums) UnsatisfiedBy(resources v1.ResourceList) error {
+	if m == nil {
+		return nil
+	}
+	for resourceName, minimum := range m {
+		if usage := resources[resourceName]; usage.Cmp(minimum) < 0 {
+			return fmt.Errorf("%s resource usage of %v is below minimum of %v", resourceName, usage.AsDec(), minimum.AsDec())
+		}
+	}
+	return nil
+}
+
 type NodeClaimTemplate struct {
 	ObjectMeta `json:"metadata,omitempty"`
 	// +required
@@ -179,6 +319,8 @@ type ObjectMeta struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=nodepools,scope=Cluster,categories=karpenter
 // +kubebuilder:printcolumn:name="NodeClass",type="string",JSONPath=".spec.template.spec.nodeClassRef.name",description=""
+// +kubebuilder:printcolumn:name="Nodes",type="integer",JSONPath=".status.nodes",description=""
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
 // +kubebuilder:printcolumn:name="Weight",type="string",JSONPath=".spec.weight",priority=1,description=""
 // +kubebuilder:subresource:status
 type NodePool struct {
@@ -197,6 +339,11 @@ func (in *NodePool) Hash() string {
 	})))
 }
 
+// IsPaused returns true if the operation is paused for the NodePool
+func (in *NodePool) IsPaused(operation PausedOperation) bool {
+	return lo.Contains(in.Spec.Paused, operation)
+}
+
 // NodePoolList contains a list of NodePool
 // +kubebuilder:object:root=true
 type NodePoolList struct {
@@ -248,6 +395,58 @@ func (in *NodePool) GetAllowedDisruptions(ctx context.Context, c clock.Clock, nu
 	return minVal, multiErr
 }
 
+// MustGetAllowedDisruptionsByReason calls GetAllowedDisruptionsByReason and returns 0 if the error is not nil.
+func (in *NodePool) MustGetAllowedDisruptionsByReason(ctx context.Context, c clock.Clock, numNodes int, reason DisruptionReason) int {
+	val, err := in.GetAllowedDisruptionsByReason(ctx, c, numNodes, reason)
+	if err != nil {
+		return 0
+	}
+	return val
+}
+
+// GetAllowedDisruptionsByReason returns the minimum allowed disruptions across the disruption budgets that apply
+// to the given reason. Budgets without any reasons apply to every reason.
+func (in *NodePool) GetAllowedDisruptionsByReason(ctx context.Context, c clock.Clock, numNodes int, reason DisruptionReason) (int, error) {
+	minVal := math.MaxInt32
+	var multiErr error
+	for i := range in.Spec.Disruption.Budgets {
+		if !in.Spec.Disruption.Budgets[i].AppliesTo(reason) {
+			continue
+		}
+		val, err := in.Spec.Disruption.Budgets[i].GetAllowedDisruptions(c, numNodes)
+		if err != nil {
+			multiErr = multierr.Append(multiErr, err)
+		}
+		minVal = lo.Ternary(val < minVal, val, minVal)
+	}
+	return minVal, multiErr
+}
+
+// GetConsolidationThreshold returns the hourly savings that consolidating a node with the given hourly price must
+// beat, before it's scaled by the disruption cost of the node's pods. It returns 0 if the threshold isn't set.
+func (in *Disruption) GetConsolidationThreshold(price float64) (float64, error) {
+	if in.ConsolidationThreshold == "" {
+		return 0, nil
+	}
+	value, isPercent := strings.CutSuffix(in.ConsolidationThreshold, "%")
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing consolidation threshold, %w", err)
+	}
+	if threshold < 0 {
+		return 0, fmt.Errorf("consolidation threshold %s is negative", in.ConsolidationThreshold)
+	}
+	if isPercent {
+		return price * threshold / 100, nil
+	}
+	return threshold, nil
+}
+
+// AppliesTo returns if the budget restricts disruptions for the given reason
+func (in *Budget) AppliesTo(reason DisruptionReason) bool {
+	return len(in.Reasons) == 0 || lo.Contains(in.Reasons, reason)
+}
+
 // GetAllowedDisruptions returns an intstr.IntOrString that can be used a comparison
 // for calculating if a disruption action is allowed. It returns an error if the
 // schedule is invalid. This returns MAXINT if the value is unbounded.
@@ -285,7 +484,7 @@ func (in *Budget) IsActive(c clock.Clock) (bool, error) {
 	if in.Schedule == nil && in.Duration == nil {
 		return true, nil
 	}
-	schedule, err := cron.ParseStandard(lo.FromPtr(in.Schedule))
+	schedule, err := in.cronSchedule()
 	if err != nil {
 		// Should only occur if there's a discrepancy
 		// with the validation regex and the cron package.
@@ -297,6 +496,15 @@ func (in *Budget) IsActive(c clock.Clock) (bool, error) {
 	return !nextHit.After(c.Now()), nil
 }
 
+// cronSchedule parses the budget's schedule. If the budget has a time zone, the schedule is evaluated in that time zone
+// so that schedule hits are computed against the local wall clock.
+func (in *Budget) cronSchedule() (cron.Schedule, error) {
+	if in.TimeZone == nil {
+		return cron.ParseStandard(lo.FromPtr(in.Schedule))
+	}
+	return cron.ParseStandard(fmt.Sprintf("CRON_TZ=%s %s", lo.FromPtr(in.TimeZone), lo.FromPtr(in.Schedule)))
+}
+
 func GetIntStrFromValue(str string) intstr.IntOrString {
 	// If err is nil, we treat it as an int.
 	if intVal, err := strconv.Atoi(str); err == nil {
@@ -96,6 +96,25 @@ var _ = Describe("Budgets", func() {
 			Expect(min).To(BeNumerically("==", 0))
 		})
 	})
+	Context("MustGetAllowedDisruptionsByReason", func() {
+		It("should only consider budgets that apply to the reason", func() {
+			budgets[0].Reasons = []DisruptionReason{DisruptionReasonUnderutilized}
+			budgets[2].Reasons = []DisruptionReason{DisruptionReasonUnderutilized, DisruptionReasonEmpty}
+			Expect(nodePool.MustGetAllowedDisruptionsByReason(ctx, fakeClock, 100, DisruptionReasonUnderutilized)).To(BeNumerically("==", 10))
+			Expect(nodePool.MustGetAllowedDisruptionsByReason(ctx, fakeClock, 100, DisruptionReasonDrifted)).To(BeNumerically("==", 100))
+		})
+		It("should apply budgets without reasons to every reason", func() {
+			for _, reason := range []DisruptionReason{DisruptionReasonUnderutilized, DisruptionReasonEmpty, DisruptionReasonDrifted, DisruptionReasonExpired} {
+				Expect(nodePool.MustGetAllowedDisruptionsByReason(ctx, fakeClock, 100, reason)).To(BeNumerically("==", 10))
+			}
+		})
+		It("should return MaxInt32 if no budgets apply to the reason", func() {
+			for i := range budgets {
+				budgets[i].Reasons = []DisruptionReason{DisruptionReasonDrifted}
+			}
+			Expect(nodePool.MustGetAllowedDisruptionsByReason(ctx, fakeClock, 100, DisruptionReasonExpired)).To(BeNumerically("==", math.MaxInt32))
+		})
+	})
 	Context("AllowedDisruptions", func() {
 		It("should return zero values if a schedule is invalid", func() {
 			budgets[0].Schedule = lo.ToPtr("@wrongly")
@@ -173,5 +192,49 @@ var _ = Describe("Budgets", func() {
 			Expect(err).To(Succeed())
 			Expect(active).ToNot(BeTrue())
 		})
+		It("should evaluate the schedule in the budget's time zone", func() {
+			budgets[0].Schedule = lo.ToPtr("0 9 * * *")
+			budgets[0].Duration = lo.ToPtr(metav1.Duration{Duration: lo.Must(time.ParseDuration("1h"))})
+			budgets[0].TimeZone = lo.ToPtr("America/New_York")
+			// 09:30 in New York during standard time (UTC-5)
+			fakeClock = clock.NewFakeClock(time.Date(2024, time.January, 8, 14, 30, 0, 0, time.UTC))
+			active, err := budgets[0].IsActive(fakeClock)
+			Expect(err).To(Succeed())
+			Expect(active).To(BeTrue())
+			// 08:30 in New York during standard time (UTC-5)
+			fakeClock = clock.NewFakeClock(time.Date(2024, time.January, 8, 13, 30, 0, 0, time.UTC))
+			active, err = budgets[0].IsActive(fakeClock)
+			Expect(err).To(Succeed())
+			Expect(active).To(BeFalse())
+		})
+		It("should keep the schedule aligned to the local wall clock across daylight saving time transitions", func() {
+			budgets[0].Schedule = lo.ToPtr("0 9 * * *")
+			budgets[0].Duration = lo.ToPtr(metav1.Duration{Duration: lo.Must(time.ParseDuration("1h"))})
+			budgets[0].TimeZone = lo.ToPtr("America/New_York")
+			// Daylight saving time started in New York on March 10th 2024, so 09:30 local is now 13:30 UTC
+			fakeClock = clock.NewFakeClock(time.Date(2024, time.March, 11, 13, 30, 0, 0, time.UTC))
+			active, err := budgets[0].IsActive(fakeClock)
+			Expect(err).To(Succeed())
+			Expect(active).To(BeTrue())
+			// 10:30 local is outside of the window
+			fakeClock = clock.NewFakeClock(time.Date(2024, time.March, 11, 14, 30, 0, 0, time.UTC))
+			active, err = budgets[0].IsActive(fakeClock)
+			Expect(err).To(Succeed())
+			Expect(active).To(BeFalse())
+			// Daylight saving time ended in New York on November 3rd 2024, so 09:30 local is back to 14:30 UTC
+			fakeClock = clock.NewFakeClock(time.Date(2024, time.November, 4, 14, 30, 0, 0, time.UTC))
+			active, err = budgets[0].IsActive(fakeClock)
+			Expect(err).To(Succeed())
+			Expect(active).To(BeTrue())
+			fakeClock = clock.NewFakeClock(time.Date(2024, time.November, 4, 13, 30, 0, 0, time.UTC))
+			active, err = budgets[0].IsActive(fakeClock)
+			Expect(err).To(Succeed())
+			Expect(active).To(BeFalse())
+		})
+		It("should return an error when the time zone is invalid", func() {
+			budgets[0].TimeZone = lo.ToPtr("Mars/Olympus_Mons")
+			_, err := budgets[0].IsActive(fakeClock)
+			Expect(err).ToNot(Succeed())
+		})
 	})
 })
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
 )
 
 // NodePoolStatus defines the observed state of NodePool
@@ -25,4 +26,60 @@ type NodePoolStatus struct {
 	// Resources is the list of resources that have been provisioned.
 	// +optional
 	Resources v1.ResourceList `json:"resources,omitempty"`
+	// Nodes is the number of nodes that have been provisioned for this NodePool and are not marked for deletion
+	// +optional
+	Nodes int64 `json:"nodes,omitempty"`
+	// NodeClaims is the number of NodeClaims that belong to this NodePool and are not being deleted
+	// +optional
+	NodeClaims int64 `json:"nodeClaims,omitempty"`
+	// Conditions contains signals for whether the NodePool is able to launch capacity
+	// +optional
+	Conditions apis.Conditions `json:"conditions,omitempty"`
+	// PodRejections summarizes the pods that the NodePool couldn't launch capacity for in the last scheduling loop,
+	// grouped by the reason that they were rejected
+	// +optional
+	PodRejections []PodRejection `json:"podRejections,omitempty"`
+}
+
+// PodRejection summarizes the pods that a NodePool rejected for the same reason
+type PodRejection struct {
+	// Reason is why the NodePool couldn't launch capacity for the pods
+	// +required
+	Reason string `json:"reason"`
+	// Count is the number of pods that were rejected
+	// +required
+	Count int32 `json:"count"`
+	// Pods is a sample of the rejected pods, formatted as namespace/name
+	// +optional
+	Pods []string `json:"pods,omitempty"`
+}
+
+func (in *NodePool) StatusConditions() apis.ConditionManager {
+	return apis.NewLivingConditionSet(
+		NodePoolValidationSucceeded,
+		NodeClassReady,
+		InstanceTypesResolved,
+	).Manage(in)
+}
+
+var (
+	// NodePoolValidationSucceeded is true when the NodePool passes the runtime validation performed before scheduling
+	NodePoolValidationSucceeded apis.ConditionType = "ValidationSucceeded"
+	// NodeClassReady is false when the cloudprovider reports that the referenced NodeClass is not ready
+	NodeClassReady apis.ConditionType = "NodeClassReady"
+	// InstanceTypesResolved is false when the cloudprovider can't return any instance types for the NodePool
+	InstanceTypesResolved apis.ConditionType = "InstanceTypesResolved"
+	// LimitsExceeded is informational and doesn't affect readiness. The NodePool can still launch capacity
+	// for pods once existing nodes are removed and usage falls back under the limits.
+	LimitsExceeded apis.ConditionType = "LimitsExceeded"
+	// Paused is informational and doesn't affect readiness. It is true when any operation is paused for the NodePool.
+	Paused apis.ConditionType = "Paused"
+)
+
+func (in *NodePool) GetConditions() apis.Conditions {
+	return in.Status.Conditions
+}
+
+func (in *NodePool) SetConditions(conditions apis.Conditions) {
+	in.Status.Conditions = conditions
 }
@@ -19,8 +19,9 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
-	"github.com/robfig/cron/v3"
 	"github.com/samber/lo"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -55,9 +56,19 @@ func (in *NodePoolSpec) validate() (errs *apis.FieldError) {
 	return errs.Also(
 		in.Template.validate().ViaField("template"),
 		in.Disruption.validate().ViaField("deprovisioning"),
+		in.validateMinimums().ViaField("minimums"),
 	)
 }
 
+func (in *NodePoolSpec) validateMinimums() (errs *apis.FieldError) {
+	for resourceName, minimum := range in.Minimums {
+		if limit, ok := in.Limits[resourceName]; ok && minimum.Cmp(limit) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s exceeds limit of %s", minimum.String(), limit.String()), string(resourceName)))
+		}
+	}
+	return errs
+}
+
 func (in *NodeClaimTemplate) validate() (errs *apis.FieldError) {
 	if len(in.Spec.Resources.Requests) > 0 {
 		errs = errs.Also(apis.ErrDisallowedFields("resources.requests"))
@@ -98,12 +109,12 @@ func (in *NodeClaimTemplate) validateRequirementsNodePoolKeyDoesNotExist() (errs
 
 //nolint:gocyclo
 func (in *Disruption) validate() (errs *apis.FieldError) {
-	if in.ConsolidateAfter != nil && in.ConsolidateAfter.Duration != nil && in.ConsolidationPolicy == ConsolidationPolicyWhenUnderutilized {
-		return errs.Also(apis.ErrGeneric("consolidateAfter cannot be combined with consolidationPolicy=WhenUnderutilized"))
-	}
 	if in.ConsolidateAfter == nil && in.ConsolidationPolicy == ConsolidationPolicyWhenEmpty {
 		return errs.Also(apis.ErrGeneric("consolidateAfter must be specified with consolidationPolicy=WhenEmpty"))
 	}
+	if _, err := in.GetConsolidationThreshold(0); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(in.ConsolidationThreshold, "consolidationThreshold", err.Error()))
+	}
 	for i := range in.Budgets {
 		budget := in.Budgets[i]
 		if err := budget.validate(); err != nil {
@@ -117,8 +128,19 @@ func (in *Budget) validate() (errs *apis.FieldError) {
 	if (in.Schedule != nil && in.Duration == nil) || (in.Schedule == nil && in.Duration != nil) {
 		return apis.ErrGeneric("schedule and duration must be specified together")
 	}
+	if in.TimeZone != nil {
+		if in.Schedule == nil {
+			return apis.ErrGeneric("timeZone must be specified with schedule")
+		}
+		if strings.Contains(lo.FromPtr(in.Schedule), "TZ=") {
+			return apis.ErrGeneric("timeZone cannot be combined with a CRON_TZ or TZ prefix in schedule")
+		}
+		if _, err := time.LoadLocation(lo.FromPtr(in.TimeZone)); err != nil {
+			return apis.ErrInvalidValue(lo.FromPtr(in.TimeZone), "timeZone", fmt.Sprintf("invalid time zone %s", err))
+		}
+	}
 	if in.Schedule != nil {
-		if _, err := cron.ParseStandard(lo.FromPtr(in.Schedule)); err != nil {
+		if _, err := in.cronSchedule(); err != nil {
 			return apis.ErrInvalidValue(in.Schedule, "schedule", fmt.Sprintf("invalid schedule %s", err))
 		}
 	}
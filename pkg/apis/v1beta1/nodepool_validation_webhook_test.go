@@ -74,10 +74,10 @@ var _ = Describe("Webhook/Validation", func() {
 			nodePool.Spec.Disruption.ConsolidationPolicy = ConsolidationPolicyWhenEmpty
 			Expect(nodePool.Validate(ctx)).To(Succeed())
 		})
-		It("should fail when setting consolidateAfter with consolidationPolicy=WhenUnderutilized", func() {
+		It("should succeed when setting consolidateAfter with consolidationPolicy=WhenUnderutilized", func() {
 			nodePool.Spec.Disruption.ConsolidateAfter = &NillableDuration{Duration: lo.ToPtr(lo.Must(time.ParseDuration("30s")))}
 			nodePool.Spec.Disruption.ConsolidationPolicy = ConsolidationPolicyWhenUnderutilized
-			Expect(nodePool.Validate(ctx)).ToNot(Succeed())
+			Expect(nodePool.Validate(ctx)).To(Succeed())
 		})
 		It("should fail to validate a budget with an invalid cron", func() {
 			nodePool.Spec.Disruption.Budgets = []Budget{{
@@ -131,6 +131,40 @@ var _ = Describe("Webhook/Validation", func() {
 			}}
 			Expect(nodePool.Validate(ctx)).To(Succeed())
 		})
+		It("should succeed to validate a budget with a time zone", func() {
+			nodePool.Spec.Disruption.Budgets = []Budget{{
+				Nodes:    "10",
+				Schedule: ptr.String("0 9 * * 1-5"),
+				Duration: &metav1.Duration{Duration: lo.Must(time.ParseDuration("8h"))},
+				TimeZone: ptr.String("Europe/Berlin"),
+			}}
+			Expect(nodePool.Validate(ctx)).To(Succeed())
+		})
+		It("should fail to validate a budget with an unknown time zone", func() {
+			nodePool.Spec.Disruption.Budgets = []Budget{{
+				Nodes:    "10",
+				Schedule: ptr.String("0 9 * * 1-5"),
+				Duration: &metav1.Duration{Duration: lo.Must(time.ParseDuration("8h"))},
+				TimeZone: ptr.String("Mars/Olympus_Mons"),
+			}}
+			Expect(nodePool.Validate(ctx)).ToNot(Succeed())
+		})
+		It("should fail to validate a budget with a time zone but no schedule", func() {
+			nodePool.Spec.Disruption.Budgets = []Budget{{
+				Nodes:    "10",
+				TimeZone: ptr.String("Europe/Berlin"),
+			}}
+			Expect(nodePool.Validate(ctx)).ToNot(Succeed())
+		})
+		It("should fail to validate a budget with a time zone and a CRON_TZ prefixed schedule", func() {
+			nodePool.Spec.Disruption.Budgets = []Budget{{
+				Nodes:    "10",
+				Schedule: ptr.String("CRON_TZ=Asia/Tokyo 0 9 * * 1-5"),
+				Duration: &metav1.Duration{Duration: lo.Must(time.ParseDuration("8h"))},
+				TimeZone: ptr.String("Europe/Berlin"),
+			}}
+			Expect(nodePool.Validate(ctx)).ToNot(Succeed())
+		})
 		It("should fail when creating two budgets where one has an invalid crontab", func() {
 			nodePool.Spec.Disruption.Budgets = []Budget{
 				{
@@ -162,6 +196,14 @@ var _ = Describe("Webhook/Validation", func() {
 			}
 			Expect(nodePool.Validate(ctx)).ToNot(Succeed())
 		})
+		It("should succeed to validate a consolidation threshold", func() {
+			nodePool.Spec.Disruption.ConsolidationThreshold = "10%"
+			Expect(nodePool.Validate(ctx)).To(Succeed())
+		})
+		It("should fail to validate a malformed consolidation threshold", func() {
+			nodePool.Spec.Disruption.ConsolidationThreshold = "ten cents"
+			Expect(nodePool.Validate(ctx)).ToNot(Succeed())
+		})
 	})
 	Context("Limits", func() {
 		It("should allow undefined limits", func() {
@@ -173,6 +215,22 @@ var _ = Describe("Webhook/Validation", func() {
 			Expect(nodePool.Validate(ctx)).To(Succeed())
 		})
 	})
+	Context("Minimums", func() {
+		It("should allow minimums without limits", func() {
+			nodePool.Spec.Minimums = Minimums(v1.ResourceList{ResourceNodes: resource.MustParse("3")})
+			Expect(nodePool.Validate(ctx)).To(Succeed())
+		})
+		It("should allow minimums that are equal to limits", func() {
+			nodePool.Spec.Limits = Limits(v1.ResourceList{ResourceNodes: resource.MustParse("3")})
+			nodePool.Spec.Minimums = Minimums(v1.ResourceList{ResourceNodes: resource.MustParse("3")})
+			Expect(nodePool.Validate(ctx)).To(Succeed())
+		})
+		It("should fail when minimums exceed limits", func() {
+			nodePool.Spec.Limits = Limits(v1.ResourceList{v1.ResourceCPU: resource.MustParse("16")})
+			nodePool.Spec.Minimums = Minimums(v1.ResourceList{v1.ResourceCPU: resource.MustParse("32")})
+			Expect(nodePool.Validate(ctx)).ToNot(Succeed())
+		})
+	})
 	Context("Template", func() {
 		It("should fail if resource requests are set", func() {
 			nodePool.Spec.Template.Spec.Resources.Requests = v1.ResourceList{
@@ -293,70 +351,70 @@ var _ = Describe("Webhook/Validation", func() {
 		})
 		Context("Requirements", func() {
 			It("should fail for the karpenter.sh/nodepool label", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: NodePoolLabelKey, Operator: v1.NodeSelectorOpIn, Values: []string{randomdata.SillyName()}},
+				nodePool.Spec.Template.Spec.Requirements = []NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: NodePoolLabelKey, Operator: v1.NodeSelectorOpIn, Values: []string{randomdata.SillyName()}}},
 				}
 				Expect(nodePool.Validate(ctx)).ToNot(Succeed())
 			})
 			It("should allow supported ops", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test"}},
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpGt, Values: []string{"1"}},
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpLt, Values: []string{"1"}},
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpNotIn},
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpExists},
+				nodePool.Spec.Template.Spec.Requirements = []NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test"}}},
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpGt, Values: []string{"1"}}},
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpLt, Values: []string{"1"}}},
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpNotIn}},
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpExists}},
 				}
 				Expect(nodePool.Validate(ctx)).To(Succeed())
 			})
 			It("should fail for unsupported ops", func() {
 				for _, op := range []v1.NodeSelectorOperator{"unknown"} {
-					nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-						{Key: v1.LabelTopologyZone, Operator: op, Values: []string{"test"}},
+					nodePool.Spec.Template.Spec.Requirements = []NodeSelectorRequirementWithMinValues{
+						{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: op, Values: []string{"test"}}},
 					}
 					Expect(nodePool.Validate(ctx)).ToNot(Succeed())
 				}
 			})
 			It("should fail for restricted domains", func() {
 				for label := range RestrictedLabelDomains {
-					nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-						{Key: label + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test"}},
+					nodePool.Spec.Template.Spec.Requirements = []NodeSelectorRequirementWithMinValues{
+						{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: label + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test"}}},
 					}
 					Expect(nodePool.Validate(ctx)).ToNot(Succeed())
 				}
 			})
 			It("should allow restricted domains exceptions", func() {
 				for label := range LabelDomainExceptions {
-					nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-						{Key: label + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test"}},
+					nodePool.Spec.Template.Spec.Requirements = []NodeSelectorRequirementWithMinValues{
+						{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: label + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test"}}},
 					}
 					Expect(nodePool.Validate(ctx)).To(Succeed())
 				}
 			})
 			It("should allow restricted subdomains exceptions", func() {
 				for label := range LabelDomainExceptions {
-					nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-						{Key: "subdomain." + label + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test"}},
+					nodePool.Spec.Template.Spec.Requirements = []NodeSelectorRequirementWithMinValues{
+						{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "subdomain." + label + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test"}}},
 					}
 					Expect(nodePool.Validate(ctx)).To(Succeed())
 				}
 			})
 			It("should allow well known label exceptions", func() {
 				for label := range WellKnownLabels.Difference(sets.New(NodePoolLabelKey)) {
-					nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-						{Key: label, Operator: v1.NodeSelectorOpIn, Values: []string{"test"}},
+					nodePool.Spec.Template.Spec.Requirements = []NodeSelectorRequirementWithMinValues{
+						{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: label, Operator: v1.NodeSelectorOpIn, Values: []string{"test"}}},
 					}
 					Expect(nodePool.Validate(ctx)).To(Succeed())
 				}
 			})
 			It("should allow non-empty set after removing overlapped value", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test", "foo"}},
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpNotIn, Values: []string{"test", "bar"}},
+				nodePool.Spec.Template.Spec.Requirements = []NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test", "foo"}}},
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpNotIn, Values: []string{"test", "bar"}}},
 				}
 				Expect(nodePool.Validate(ctx)).To(Succeed())
 			})
 			It("should allow empty requirements", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{}
+				nodePool.Spec.Template.Spec.Requirements = []NodeSelectorRequirementWithMinValues{}
 				Expect(nodePool.Validate(ctx)).To(Succeed())
 			})
 			It("should fail with invalid GT or LT values", func() {
@@ -370,7 +428,28 @@ var _ = Describe("Webhook/Validation", func() {
 					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpLt, Values: []string{"a"}},
 					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpLt, Values: []string{"-1"}},
 				} {
-					nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{requirement}
+					nodePool.Spec.Template.Spec.Requirements = []NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: requirement}}
+					Expect(nodePool.Validate(ctx)).ToNot(Succeed())
+				}
+			})
+			It("should allow minValues on requirements", func() {
+				nodePool.Spec.Template.Spec.Requirements = []NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelInstanceTypeStable, Operator: v1.NodeSelectorOpExists}, MinValues: lo.ToPtr(10)},
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1", "test-zone-2"}}, MinValues: lo.ToPtr(2)},
+				}
+				Expect(nodePool.Validate(ctx)).To(Succeed())
+			})
+			It("should fail when minValues is greater than the number of values with operator In", func() {
+				nodePool.Spec.Template.Spec.Requirements = []NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}, MinValues: lo.ToPtr(2)},
+				}
+				Expect(nodePool.Validate(ctx)).ToNot(Succeed())
+			})
+			It("should fail when minValues is out of range", func() {
+				for _, minValues := range []int{0, 51} {
+					nodePool.Spec.Template.Spec.Requirements = []NodeSelectorRequirementWithMinValues{
+						{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelInstanceTypeStable, Operator: v1.NodeSelectorOpExists}, MinValues: lo.ToPtr(minValues)},
+					}
 					Expect(nodePool.Validate(ctx)).ToNot(Succeed())
 				}
 			})
@@ -618,3 +697,31 @@ var _ = Describe("Limits", func() {
 		Expect(nodepool.Spec.Limits.ExceededBy(nodepool.Status.Resources)).To(MatchError("cpu resource usage of 17 exceeds limit of 16"))
 	})
 })
+
+var _ = Describe("Minimums", func() {
+	var nodepool *NodePool
+
+	BeforeEach(func() {
+		nodepool = &NodePool{
+			ObjectMeta: metav1.ObjectMeta{Name: strings.ToLower(randomdata.SillyName())},
+			Spec: NodePoolSpec{
+				Minimums: Minimums(v1.ResourceList{
+					"cpu": resource.MustParse("16"),
+				}),
+			},
+		}
+	})
+
+	It("should work when usage is higher than minimum", func() {
+		Expect(nodepool.Spec.Minimums.UnsatisfiedBy(v1.ResourceList{"cpu": resource.MustParse("17")})).To(Succeed())
+	})
+	It("should work when usage is equal to minimum", func() {
+		Expect(nodepool.Spec.Minimums.UnsatisfiedBy(v1.ResourceList{"cpu": resource.MustParse("16")})).To(Succeed())
+	})
+	It("should fail when usage is lower than minimum", func() {
+		Expect(nodepool.Spec.Minimums.UnsatisfiedBy(v1.ResourceList{"cpu": resource.MustParse("15")})).To(MatchError("cpu resource usage of 15 is below minimum of 16"))
+	})
+	It("should fail when there is no usage for the minimum", func() {
+		Expect(nodepool.Spec.Minimums.UnsatisfiedBy(v1.ResourceList{})).To(MatchError("cpu resource usage of 0 is below minimum of 16"))
+	})
+})
@@ -35,6 +35,8 @@ var (
 			&NodePoolList{},
 			&NodeClaim{},
 			&NodeClaimList{},
+			&DisruptionRecord{},
+			&DisruptionRecordList{},
 		)
 		metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 		return nil
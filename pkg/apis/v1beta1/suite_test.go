@@ -20,6 +20,8 @@ import (
 	"context"
 	"math/rand"
 	"testing"
+	// The operator embeds the time zone database for the time zones of disruption budgets, so the tests need it too
+	_ "time/tzdata"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -100,3 +102,19 @@ var _ = Describe("OrderByWeight", func() {
 		}
 	})
 })
+
+var _ = Describe("ConsolidationThreshold", func() {
+	It("should return zero when the threshold isn't set", func() {
+		Expect((&v1beta1.Disruption{}).GetConsolidationThreshold(2)).To(BeNumerically("==", 0))
+	})
+	It("should return the threshold when it's a price", func() {
+		Expect((&v1beta1.Disruption{ConsolidationThreshold: "0.05"}).GetConsolidationThreshold(2)).To(BeNumerically("~", 0.05))
+	})
+	It("should scale the threshold by the price when it's a percentage", func() {
+		Expect((&v1beta1.Disruption{ConsolidationThreshold: "12.5%"}).GetConsolidationThreshold(2)).To(BeNumerically("~", 0.25))
+	})
+	It("should fail when the threshold is malformed", func() {
+		_, err := (&v1beta1.Disruption{ConsolidationThreshold: "ten cents"}).GetConsolidationThreshold(2)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -37,6 +37,8 @@ const (
 	IntegerInstanceLabelKey                 = "integer"
 	ResourceGPUVendorA      v1.ResourceName = "fake.com/vendor-a"
 	ResourceGPUVendorB      v1.ResourceName = "fake.com/vendor-b"
+	// DeviceDriver is the name of the DRA driver that allocates the synthetic devices of fake instance types
+	DeviceDriver = "gpu.fake.com"
 )
 
 func init() {
@@ -104,6 +106,7 @@ func NewInstanceType(options InstanceTypeOptions) *cloudprovider.InstanceType {
 				v1.ResourceMemory: resource.MustParse("10Mi"),
 			},
 		},
+		Devices: options.Devices,
 	}
 }
 
@@ -171,6 +174,7 @@ type InstanceTypeOptions struct {
 	Architecture     string
 	OperatingSystems sets.Set[string]
 	Resources        v1.ResourceList
+	Devices          cloudprovider.Devices
 }
 
 func PriceFromResources(resources v1.ResourceList) float64 {
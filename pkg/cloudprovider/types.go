@@ -26,6 +26,7 @@ import (
 
 	"github.com/samber/lo"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
 	"sigs.k8s.io/karpenter/pkg/scheduling"
@@ -72,6 +73,8 @@ type InstanceType struct {
 	// Overhead is the amount of resource overhead expected to be used by kubelet and any other system daemons outside
 	// of Kubernetes.
 	Overhead *InstanceTypeOverhead
+	// Devices are the devices that an instance of this type exposes to DRA drivers for allocation to ResourceClaims
+	Devices Devices
 
 	once        sync.Once
 	allocatable v1.ResourceList
@@ -79,6 +82,25 @@ type InstanceType struct {
 
 type InstanceTypes []*InstanceType
 
+// Device describes identical devices on an instance that are allocated by a DRA driver
+type Device struct {
+	// Driver is the name of the DRA driver that allocates the device and must match the ResourceClass's driverName
+	Driver string
+	// Count is the number of devices that are exposed by an instance
+	Count int
+}
+
+type Devices []Device
+
+// Capacity returns the number of devices that can be allocated by each driver
+func (d Devices) Capacity() map[string]int {
+	capacity := map[string]int{}
+	for _, device := range d {
+		capacity[device.Driver] += device.Count
+	}
+	return capacity
+}
+
 // precompute is used to ensure we only compute the allocatable resources onces as its called many times
 // and the operation is fairly expensive.
 func (i *InstanceType) precompute() {
@@ -120,6 +142,55 @@ func (its InstanceTypes) Compatible(requirements scheduling.Requirements) Instan
 	return filteredInstanceTypes
 }
 
+// SatisfiesMinValues validates whether the instance types satisfy the minValues of the passed requirements. It returns
+// the number of instance types, taken in order, that are needed to satisfy every minValues requirement.
+func (its InstanceTypes) SatisfiesMinValues(requirements scheduling.Requirements) (minNeededInstanceTypes int, err error) {
+	minValuesRequirements := lo.Filter(requirements.Values(), func(r *scheduling.Requirement, _ int) bool { return r.MinValues != nil })
+	if len(minValuesRequirements) == 0 {
+		return 0, nil
+	}
+	sort.Slice(minValuesRequirements, func(i, j int) bool { return minValuesRequirements[i].Key < minValuesRequirements[j].Key })
+	valuesForKey := map[string]sets.Set[string]{}
+	unsatisfiedKey := func() (string, bool) {
+		for _, r := range minValuesRequirements {
+			if len(valuesForKey[r.Key]) < lo.FromPtr(r.MinValues) {
+				return r.Key, true
+			}
+		}
+		return "", false
+	}
+	for i, it := range its {
+		for _, r := range minValuesRequirements {
+			// Only values that the instance type explicitly offers and the requirements allow count towards flexibility
+			if itRequirement := it.Requirements.Get(r.Key); itRequirement.Operator() == v1.NodeSelectorOpIn {
+				if _, ok := valuesForKey[r.Key]; !ok {
+					valuesForKey[r.Key] = sets.New[string]()
+				}
+				for _, value := range itRequirement.Values() {
+					if r.Has(value) {
+						valuesForKey[r.Key].Insert(value)
+					}
+				}
+			}
+		}
+		if _, ok := unsatisfiedKey(); !ok {
+			return i + 1, nil
+		}
+	}
+	key, _ := unsatisfiedKey()
+	return len(its), fmt.Errorf("minValues requirement is not met for %q", key)
+}
+
+// Truncate orders the instance types by price and keeps the cheapest maxItems of them. It returns an error if the
+// truncated instance types no longer satisfy the minValues of the passed requirements.
+func (its InstanceTypes) Truncate(requirements scheduling.Requirements, maxItems int) (InstanceTypes, error) {
+	truncated := InstanceTypes(lo.Slice(its.OrderByPrice(requirements), 0, maxItems))
+	if _, err := truncated.SatisfiesMinValues(requirements); err != nil {
+		return its, fmt.Errorf("validating minValues, %w", err)
+	}
+	return truncated, nil
+}
+
 type InstanceTypeOverhead struct {
 	// KubeReserved returns the default resources allocated to kubernetes system daemons by default
 	KubeReserved v1.ResourceList
@@ -142,6 +213,16 @@ type Offering struct {
 	// Available is added so that Offerings can return all offerings that have ever existed for an instance type,
 	// so we can get historical pricing data for calculating savings in consolidation
 	Available bool
+	// ReservationID identifies the capacity reservation that a reserved offering launches into
+	ReservationID string
+	// ReservationCapacity is the number of instances that can still be launched into the reservation. It shouldn't
+	// count the instances that have already been launched into it.
+	ReservationCapacity int
+}
+
+// IsReserved returns true if the offering launches into a capacity reservation
+func (o Offering) IsReserved() bool {
+	return o.CapacityType == v1beta1.CapacityTypeReserved
 }
 
 type Offerings []Offering
@@ -169,6 +250,13 @@ func (ofs Offerings) Compatible(reqs scheduling.Requirements) Offerings {
 	})
 }
 
+// Reserved filters the offerings that launch into capacity reservations
+func (ofs Offerings) Reserved() Offerings {
+	return lo.Filter(ofs, func(o Offering, _ int) bool {
+		return o.IsReserved()
+	})
+}
+
 // Cheapest returns the cheapest offering from the returned offerings
 func (ofs Offerings) Cheapest() Offering {
 	return lo.MinBy(ofs, func(a, b Offering) bool {
@@ -101,6 +101,12 @@ func (c *consolidation) ShouldDisrupt(_ context.Context, cn *Candidate) bool {
 		c.recorder.Publish(disruptionevents.Unconsolidatable(cn.Node, cn.NodeClaim, fmt.Sprintf("NodePool %q has consolidation disabled", cn.nodePool.Name))...)
 		return false
 	}
+	// If consolidateAfter is set, we wait for pod scheduling on the node to stabilize before considering it so that
+	// we don't churn nodes that are still having pods scheduled to and removed from them
+	if cn.nodePool.Spec.Disruption.ConsolidateAfter != nil &&
+		c.clock.Since(cn.LastPodEventTime()) < *cn.nodePool.Spec.Disruption.ConsolidateAfter.Duration {
+		return false
+	}
 	return true
 }
 
@@ -136,8 +142,31 @@ func (c *consolidation) computeConsolidation(ctx context.Context, candidates ...
 		return Command{}, pscheduling.Results{}, nil
 	}
 
+	// reservations are paid for whether or not they're used, so replacing reserved capacity with capacity that we pay
+	// for as we go would cost more even if the new capacity is cheaper
+	if lo.ContainsBy(candidates, func(cn *Candidate) bool { return cn.capacityType == v1beta1.CapacityTypeReserved }) && !onlyReservedCapacity(results) {
+		if len(candidates) == 1 {
+			c.recorder.Publish(disruptionevents.Unconsolidatable(candidates[0].Node, candidates[0].NodeClaim, "Can't replace reserved capacity with capacity that isn't reserved")...)
+		}
+		return Command{}, pscheduling.Results{}, nil
+	}
+
+	threshold, err := consolidationThreshold(candidates)
+	if err != nil {
+		// This should never happen since the threshold is validated when the NodePool is applied. If it's malformed,
+		// fail closed since we don't know how much the NodePool needs consolidation to save.
+		if len(candidates) == 1 {
+			c.recorder.Publish(disruptionevents.Unconsolidatable(candidates[0].Node, candidates[0].NodeClaim, fmt.Sprintf("Can't determine the consolidation threshold, %s", err))...)
+		}
+		return Command{}, pscheduling.Results{}, nil
+	}
+
 	// were we able to schedule all the pods on the inflight candidates?
 	if len(results.NewNodeClaims) == 0 {
+		if candidatePrice, err := getCandidatePrices(candidates); err == nil && threshold > 0 && candidatePrice <= threshold {
+			c.reportBelowThreshold(ctx, candidates, DeleteAction, candidatePrice, threshold)
+			return Command{}, pscheduling.Results{}, nil
+		}
 		return Command{
 			candidates: candidates,
 		}, results, nil
@@ -151,6 +180,12 @@ func (c *consolidation) computeConsolidation(ctx context.Context, candidates ...
 		return Command{}, pscheduling.Results{}, nil
 	}
 
+	// Only the instance types that the NodePool's strategy selects would be launched, so only they're compared against
+	// the price of the candidates. Otherwise, we'd replace nodes with instance types that the strategy wouldn't launch,
+	// like smaller instance types for a NodePool that launches the largest of them.
+	replacement := results.NewNodeClaims[0]
+	replacement.InstanceTypeOptions = replacement.Strategy.Select(replacement.InstanceTypeOptions, replacement.Requirements, replacement.Spec.Resources.Requests)
+
 	// get the current node price based on the offering
 	// fallback if we can't find the specific zonal pricing data
 	candidatePrice, err := getCandidatePrices(candidates)
@@ -167,20 +202,33 @@ func (c *consolidation) computeConsolidation(ctx context.Context, candidates ...
 
 	if allExistingAreSpot &&
 		results.NewNodeClaims[0].Requirements.Get(v1beta1.CapacityTypeLabelKey).Has(v1beta1.CapacityTypeSpot) {
-		return c.computeSpotToSpotConsolidation(ctx, candidates, results, candidatePrice)
+		return c.computeSpotToSpotConsolidation(ctx, candidates, results, candidatePrice, threshold)
 	}
 
 	// filterByPrice returns the instanceTypes that are lower priced than the current candidate. If we use this directly for spot-to-spot consolidation
 	// we are bound to get repeated consolidations because the strategy that chooses to launch the spot instance from the list does it based on availability and price which could
 	// result in selection/launch of non-lowest priced instance in the list. So, we would keep repeating this loop till we get to lowest priced instance
 	// causing churns and landing onto lower available spot instance ultimately resulting in higher interruptions.
-	results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions = filterByPrice(results.NewNodeClaims[0].InstanceTypeOptions, results.NewNodeClaims[0].Requirements, candidatePrice)
-	if len(results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions) == 0 {
+	cheaper := filterByPrice(results.NewNodeClaims[0].InstanceTypeOptions, results.NewNodeClaims[0].Requirements, candidatePrice)
+	if len(cheaper) == 0 {
 		if len(candidates) == 1 {
 			c.recorder.Publish(disruptionevents.Unconsolidatable(candidates[0].Node, candidates[0].NodeClaim, "Can't replace with a cheaper node")...)
 		}
 		return Command{}, pscheduling.Results{}, nil
 	}
+	// Only keep the instance types that save more than the threshold even at their worst launch price
+	results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions = filterByPrice(cheaper, results.NewNodeClaims[0].Requirements, candidatePrice-threshold)
+	if len(results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions) == 0 {
+		c.reportBelowThreshold(ctx, candidates, ReplaceAction, maxSavings(cheaper, results.NewNodeClaims[0].Requirements, candidatePrice), threshold)
+		return Command{}, pscheduling.Results{}, nil
+	}
+	// Filtering by price may have removed the flexibility that the NodePool requires through minValues
+	if _, err := results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions.SatisfiesMinValues(results.NewNodeClaims[0].Requirements); err != nil {
+		if len(candidates) == 1 {
+			c.recorder.Publish(disruptionevents.Unconsolidatable(candidates[0].Node, candidates[0].NodeClaim, fmt.Sprintf("Can't replace with a cheaper node that satisfies minValues, %s", err))...)
+		}
+		return Command{}, pscheduling.Results{}, nil
+	}
 
 	// We are consolidating a node from OD -> [OD,Spot] but have filtered the instance types by cost based on the
 	// assumption, that the spot variant will launch. We also need to add a requirement to the node to ensure that if
@@ -203,7 +251,7 @@ func (c *consolidation) computeConsolidation(ctx context.Context, candidates ...
 //     a. There are at least 15 cheapest instance type replacement options to consolidate.
 //     b. The current candidate is NOT part of the first 15 cheapest instance types inorder to avoid repeated consolidation.
 func (c *consolidation) computeSpotToSpotConsolidation(ctx context.Context, candidates []*Candidate, results pscheduling.Results,
-	candidatePrice float64) (Command, pscheduling.Results, error) {
+	candidatePrice float64, threshold float64) (Command, pscheduling.Results, error) {
 
 	// Spot consolidation is turned off.
 	if !options.FromContext(ctx).FeatureGates.SpotToSpotConsolidation {
@@ -220,15 +268,29 @@ func (c *consolidation) computeSpotToSpotConsolidation(ctx context.Context, cand
 		results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions.Compatible(results.NewNodeClaims[0].Requirements)
 
 	// Possible replacements that are lower priced than the current candidate
-	results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions = filterByPrice(instanceTypeOptionsWithSpotOfferings, results.NewNodeClaims[0].Requirements, candidatePrice)
+	cheaper := filterByPrice(instanceTypeOptionsWithSpotOfferings, results.NewNodeClaims[0].Requirements, candidatePrice)
 
-	if len(results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions) == 0 {
+	if len(cheaper) == 0 {
 		if len(candidates) == 1 {
 			c.recorder.Publish(disruptionevents.Unconsolidatable(candidates[0].Node, candidates[0].NodeClaim, "Can't replace spot node with a cheaper spot node")...)
 		}
 		// no instance types remain after filtering by price
 		return Command{}, pscheduling.Results{}, nil
 	}
+	results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions = filterByPrice(cheaper, results.NewNodeClaims[0].Requirements, candidatePrice-threshold)
+	if len(results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions) == 0 {
+		c.reportBelowThreshold(ctx, candidates, ReplaceAction, maxSavings(cheaper, results.NewNodeClaims[0].Requirements, candidatePrice), threshold)
+		return Command{}, pscheduling.Results{}, nil
+	}
+	// Filtering by price may have removed the flexibility that the NodePool requires through minValues
+	minNeededInstanceTypes, err := results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions.OrderByPrice(results.NewNodeClaims[0].Requirements).
+		SatisfiesMinValues(results.NewNodeClaims[0].Requirements)
+	if err != nil {
+		if len(candidates) == 1 {
+			c.recorder.Publish(disruptionevents.Unconsolidatable(candidates[0].Node, candidates[0].NodeClaim, fmt.Sprintf("Can't replace spot node with a cheaper spot node that satisfies minValues, %s", err))...)
+		}
+		return Command{}, pscheduling.Results{}, nil
+	}
 
 	// For multi-node consolidation:
 	// We don't have any requirement to check the remaining instance type flexibility, so exit early in this case.
@@ -256,7 +318,9 @@ func (c *consolidation) computeSpotToSpotConsolidation(ctx context.Context, cand
 	// 3) Assuming CreateInstanceFromTypes(A,B,C,D) returned D, we check if D is part of (A,B,C) and it isn't, so will have another consolidation send a CreateInstanceFromTypes(A,B,C), since they’re cheaper than D resulting in continual consolidation.
 	// If we had restricted instance types to min flexibility at launch at step (1) i.e CreateInstanceFromTypes(A,B,C), we would have received the instance type part of the list preventing immediate consolidation.
 	// Taking this to 15 types, we need to only send the 15 cheapest types in the CreateInstanceFromTypes call so that the resulting instance is always in that set of 15 and we won’t immediately consolidate.
-	results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions = lo.Slice(results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions.OrderByPrice(results.NewNodeClaims[0].Requirements), 0, MinInstanceTypesForSpotToSpotConsolidation)
+	// If the NodePool's minValues need more than 15 of the cheapest types to be satisfied, we keep as many as are needed instead.
+	results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions = lo.Slice(results.NewNodeClaims[0].NodeClaimTemplate.InstanceTypeOptions.OrderByPrice(results.NewNodeClaims[0].Requirements), 0,
+		lo.Max([]int{MinInstanceTypesForSpotToSpotConsolidation, minNeededInstanceTypes}))
 
 	return Command{
 		candidates:   candidates,
@@ -264,6 +328,34 @@ func (c *consolidation) computeSpotToSpotConsolidation(ctx context.Context, cand
 	}, results, nil
 }
 
+// reportBelowThreshold reports a consolidation that was abandoned because its estimated savings don't beat the
+// consolidation threshold of the candidates' NodePools
+func (c *consolidation) reportBelowThreshold(ctx context.Context, candidates []*Candidate, action Action, savings float64, threshold float64) {
+	disruptionConsolidationBelowThresholdCounter.With(map[string]string{actionLabel: string(action)}).Inc()
+	// Multi-node consolidation evaluates many sets of candidates, so we'll only report on single candidates like we
+	// do for the other reasons that consolidation is abandoned
+	if len(candidates) != 1 {
+		return
+	}
+	reason := fmt.Sprintf("estimated savings of %.4f per hour don't beat the consolidation threshold of %.4f per hour", savings, threshold)
+	if candidates[0].dryRun(ctx) {
+		c.recorder.Publish(disruptionevents.DryRun(candidates[0].Node, candidates[0].NodeClaim, fmt.Sprintf("Wouldn't %s, %s", action, reason))...)
+		return
+	}
+	c.recorder.Publish(disruptionevents.Unconsolidatable(candidates[0].Node, candidates[0].NodeClaim, fmt.Sprintf("Can't %s, %s", action, reason))...)
+}
+
+// onlyReservedCapacity returns true if the NodeClaims that would be launched for the pods run on reserved capacity.
+// Existing nodes are already paid for, so moving pods onto them doesn't cost more whether or not they're reserved.
+func onlyReservedCapacity(results pscheduling.Results) bool {
+	for _, n := range results.NewNodeClaims {
+		if capacityTypes := n.Requirements.Get(v1beta1.CapacityTypeLabelKey); capacityTypes.Len() != 1 || !capacityTypes.Has(v1beta1.CapacityTypeReserved) {
+			return false
+		}
+	}
+	return true
+}
+
 // getCandidatePrices returns the sum of the prices of the given candidates
 func getCandidatePrices(candidates []*Candidate) (float64, error) {
 	var price float64
@@ -31,6 +31,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -537,7 +538,7 @@ var _ = Describe("Consolidation", func() {
 			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, nodes, nodeClaims)
 
 			emptyConsolidation := disruption.NewEmptyNodeConsolidation(disruption.MakeConsolidation(fakeClock, cluster, env.Client, prov, cloudProvider, recorder, queue))
-			budgets, err := disruption.BuildDisruptionBudgets(ctx, cluster, fakeClock, env.Client, recorder)
+			budgets, err := disruption.BuildDisruptionBudgets(ctx, cluster, fakeClock, env.Client, recorder, emptyConsolidation.Reason())
 			Expect(err).To(Succeed())
 
 			candidates, err := disruption.GetCandidates(ctx, cluster, env.Client, recorder, fakeClock, cloudProvider, emptyConsolidation.ShouldDisrupt, queue)
@@ -601,7 +602,7 @@ var _ = Describe("Consolidation", func() {
 			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, nodes, nodeClaims)
 
 			emptyConsolidation := disruption.NewEmptyNodeConsolidation(disruption.MakeConsolidation(fakeClock, cluster, env.Client, prov, cloudProvider, recorder, queue))
-			budgets, err := disruption.BuildDisruptionBudgets(ctx, cluster, fakeClock, env.Client, recorder)
+			budgets, err := disruption.BuildDisruptionBudgets(ctx, cluster, fakeClock, env.Client, recorder, emptyConsolidation.Reason())
 			Expect(err).To(Succeed())
 
 			candidates, err := disruption.GetCandidates(ctx, cluster, env.Client, recorder, fakeClock, cloudProvider, emptyConsolidation.ShouldDisrupt, queue)
@@ -628,7 +629,7 @@ var _ = Describe("Consolidation", func() {
 			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, nodes, nodeClaims)
 
 			multiConsolidation := disruption.NewMultiNodeConsolidation(disruption.MakeConsolidation(fakeClock, cluster, env.Client, prov, cloudProvider, recorder, queue))
-			budgets, err := disruption.BuildDisruptionBudgets(ctx, cluster, fakeClock, env.Client, recorder)
+			budgets, err := disruption.BuildDisruptionBudgets(ctx, cluster, fakeClock, env.Client, recorder, multiConsolidation.Reason())
 			Expect(err).To(Succeed())
 
 			candidates, err := disruption.GetCandidates(ctx, cluster, env.Client, recorder, fakeClock, cloudProvider, multiConsolidation.ShouldDisrupt, queue)
@@ -692,7 +693,7 @@ var _ = Describe("Consolidation", func() {
 			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, nodes, nodeClaims)
 
 			multiConsolidation := disruption.NewMultiNodeConsolidation(disruption.MakeConsolidation(fakeClock, cluster, env.Client, prov, cloudProvider, recorder, queue))
-			budgets, err := disruption.BuildDisruptionBudgets(ctx, cluster, fakeClock, env.Client, recorder)
+			budgets, err := disruption.BuildDisruptionBudgets(ctx, cluster, fakeClock, env.Client, recorder, multiConsolidation.Reason())
 			Expect(err).To(Succeed())
 
 			candidates, err := disruption.GetCandidates(ctx, cluster, env.Client, recorder, fakeClock, cloudProvider, multiConsolidation.ShouldDisrupt, queue)
@@ -719,7 +720,7 @@ var _ = Describe("Consolidation", func() {
 			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, nodes, nodeClaims)
 
 			singleConsolidation := disruption.NewSingleNodeConsolidation(disruption.MakeConsolidation(fakeClock, cluster, env.Client, prov, cloudProvider, recorder, queue))
-			budgets, err := disruption.BuildDisruptionBudgets(ctx, cluster, fakeClock, env.Client, recorder)
+			budgets, err := disruption.BuildDisruptionBudgets(ctx, cluster, fakeClock, env.Client, recorder, singleConsolidation.Reason())
 			Expect(err).To(Succeed())
 
 			candidates, err := disruption.GetCandidates(ctx, cluster, env.Client, recorder, fakeClock, cloudProvider, singleConsolidation.ShouldDisrupt, queue)
@@ -783,7 +784,7 @@ var _ = Describe("Consolidation", func() {
 			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, nodes, nodeClaims)
 
 			singleConsolidation := disruption.NewSingleNodeConsolidation(disruption.MakeConsolidation(fakeClock, cluster, env.Client, prov, cloudProvider, recorder, queue))
-			budgets, err := disruption.BuildDisruptionBudgets(ctx, cluster, fakeClock, env.Client, recorder)
+			budgets, err := disruption.BuildDisruptionBudgets(ctx, cluster, fakeClock, env.Client, recorder, singleConsolidation.Reason())
 			Expect(err).To(Succeed())
 
 			candidates, err := disruption.GetCandidates(ctx, cluster, env.Client, recorder, fakeClock, cloudProvider, singleConsolidation.ShouldDisrupt, queue)
@@ -845,6 +846,33 @@ var _ = Describe("Consolidation", func() {
 			Expect(ExpectNodes(ctx, env.Client)).To(HaveLen(0))
 			ExpectNotFound(ctx, env.Client, nodeClaim, node)
 		})
+		It("should record the disruption", func() {
+			ExpectApplied(ctx, env.Client, nodeClaim, node, nodePool)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			records := &v1beta1.DisruptionRecordList{}
+			Expect(env.Client.List(ctx, records)).To(Succeed())
+			Expect(records.Items).To(HaveLen(1))
+			Expect(records.Items[0].Spec.ConsolidationType).To(Equal("empty"))
+			Expect(records.Items[0].Spec.Candidates).To(HaveLen(1))
+			Expect(records.Items[0].Spec.Candidates[0].NodeClaim).To(Equal(nodeClaim.Name))
+			Expect(records.Items[0].Spec.Candidates[0].InstanceType).To(Equal(mostExpensiveInstance.Name))
+			Expect(records.Items[0].Spec.PriceBefore).ToNot(BeEmpty())
+			Expect(records.Items[0].Status.Outcome).To(BeEmpty())
+
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+			record := ExpectExists(ctx, env.Client, &records.Items[0])
+			Expect(record.Status.Outcome).To(Equal(v1beta1.DisruptionOutcomeSucceeded))
+		})
 		It("can delete multiple empty nodes", func() {
 			ExpectApplied(ctx, env.Client, nodeClaim, node, nodeClaim2, node2, nodePool)
 
@@ -1109,6 +1137,355 @@ var _ = Describe("Consolidation", func() {
 			ExpectExists(ctx, env.Client, node)
 		})
 	})
+	Context("Dry Run", func() {
+		It("won't delete empty nodes when dry run is enabled globally", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{DisruptionDryRun: lo.ToPtr(true)}))
+			ExpectApplied(ctx, env.Client, nodeClaim, node, nodePool)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			// the node should be reported, but not tainted or deleted
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+			Expect(ExpectNodes(ctx, env.Client)).To(HaveLen(1))
+			node = ExpectExists(ctx, env.Client, node)
+			ExpectExists(ctx, env.Client, nodeClaim)
+			Expect(node.Spec.Taints).ToNot(ContainElement(v1beta1.DisruptionNoScheduleTaint))
+			Expect(recorder.Calls("DisruptionDryRun")).To(BeNumerically(">", 0))
+		})
+		It("won't delete empty nodes from a NodePool with dry run enabled", func() {
+			nodePool.Spec.Disruption.DryRun = true
+			ExpectApplied(ctx, env.Client, nodeClaim, node, nodePool)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+			Expect(ExpectNodes(ctx, env.Client)).To(HaveLen(1))
+			node = ExpectExists(ctx, env.Client, node)
+			ExpectExists(ctx, env.Client, nodeClaim)
+			Expect(node.Spec.Taints).ToNot(ContainElement(v1beta1.DisruptionNoScheduleTaint))
+			Expect(recorder.Calls("DisruptionDryRun")).To(BeNumerically(">", 0))
+		})
+		It("won't launch replacements when dry run is enabled", func() {
+			nodePool.Spec.Disruption.DryRun = true
+			// create our RS so we can link a pod to it
+			rs := test.ReplicaSet()
+			ExpectApplied(ctx, env.Client, rs)
+			Expect(env.Client.Get(ctx, client.ObjectKeyFromObject(rs), rs)).To(Succeed())
+
+			pod := test.Pod(test.PodOptions{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion:         "apps/v1",
+							Kind:               "ReplicaSet",
+							Name:               rs.Name,
+							UID:                rs.UID,
+							Controller:         lo.ToPtr(true),
+							BlockOwnerDeletion: lo.ToPtr(true),
+						},
+					}}})
+			ExpectApplied(ctx, env.Client, rs, pod, nodeClaim, node, nodePool)
+
+			// bind pods to node
+			ExpectManualBinding(ctx, env.Client, pod, node)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			// no replacement should be launched and the original node should be untouched
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+			Expect(ExpectNodes(ctx, env.Client)).To(HaveLen(1))
+			node = ExpectExists(ctx, env.Client, node)
+			ExpectExists(ctx, env.Client, nodeClaim)
+			Expect(node.Spec.Taints).ToNot(ContainElement(v1beta1.DisruptionNoScheduleTaint))
+			_, ok := lo.Find(recorder.Events(), func(e events.Event) bool {
+				return e.Reason == "DisruptionDryRun" && strings.Contains(e.Message, "Would")
+			})
+			Expect(ok).To(BeTrue())
+		})
+		It("should report a dry-run NodePool's nodes when the other NodePool's nodes can't be consolidated", func() {
+			dryRunNodePool := test.NodePool(v1beta1.NodePool{
+				Spec: v1beta1.NodePoolSpec{
+					Disruption: v1beta1.Disruption{
+						ConsolidationPolicy: v1beta1.ConsolidationPolicyWhenUnderutilized,
+						DryRun:              true,
+						Budgets:             []v1beta1.Budget{{Nodes: "100%"}},
+					},
+				},
+			})
+			// the dry-run node is empty, but tainted so that the other node's pod can't move to it
+			dryRunNodeClaim, dryRunNode := test.NodeClaimAndNode(v1beta1.NodeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						v1beta1.NodePoolLabelKey:     dryRunNodePool.Name,
+						v1.LabelInstanceTypeStable:   mostExpensiveInstance.Name,
+						v1beta1.CapacityTypeLabelKey: mostExpensiveOffering.CapacityType,
+						v1.LabelTopologyZone:         mostExpensiveOffering.Zone,
+					},
+				},
+				Spec: v1beta1.NodeClaimSpec{
+					Taints: []v1.Taint{{Key: "dry-run", Effect: v1.TaintEffectNoSchedule}},
+				},
+				Status: v1beta1.NodeClaimStatus{
+					Allocatable: map[v1.ResourceName]resource.Quantity{v1.ResourceCPU: resource.MustParse("32")},
+				},
+			})
+			// the other node is already as cheap as it gets, so it's a candidate that can't be consolidated
+			nodeClaim.Labels[v1.LabelInstanceTypeStable] = leastExpensiveSpotInstance.Name
+			nodeClaim.Labels[v1beta1.CapacityTypeLabelKey] = leastExpensiveSpotOffering.CapacityType
+			nodeClaim.Labels[v1.LabelTopologyZone] = leastExpensiveSpotOffering.Zone
+			node.Labels = nodeClaim.Labels
+			rs := test.ReplicaSet()
+			ExpectApplied(ctx, env.Client, rs)
+			pod := test.Pod(test.PodOptions{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion:         "apps/v1",
+							Kind:               "ReplicaSet",
+							Name:               rs.Name,
+							UID:                rs.UID,
+							Controller:         lo.ToPtr(true),
+							BlockOwnerDeletion: lo.ToPtr(true),
+						},
+					}}})
+			ExpectApplied(ctx, env.Client, pod, nodeClaim, node, nodePool, dryRunNodeClaim, dryRunNode, dryRunNodePool)
+			ExpectManualBinding(ctx, env.Client, pod, node)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node, dryRunNode}, []*v1beta1.NodeClaim{nodeClaim, dryRunNodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			// the dry-run node is reported even though the other NodePool's node was found to be consolidated first
+			_, ok := lo.Find(recorder.Events(), func(e events.Event) bool {
+				return e.Reason == "DisruptionDryRun" && strings.Contains(e.Message, "Would") && strings.Contains(e.Message, dryRunNode.Name)
+			})
+			Expect(ok).To(BeTrue())
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(2))
+			dryRunNode = ExpectExists(ctx, env.Client, dryRunNode)
+			Expect(dryRunNode.Spec.Taints).ToNot(ContainElement(v1beta1.DisruptionNoScheduleTaint))
+		})
+		It("should only count a dry-run action once while it doesn't change", func() {
+			nodePool.Spec.Disruption.DryRun = true
+			ExpectApplied(ctx, env.Client, nodeClaim, node, nodePool)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+
+			dryRunActions := func() float64 {
+				metric, found := FindMetricWithLabelValues("karpenter_disruption_dry_run_actions_total", map[string]string{
+					"action":             "delete",
+					"method":             "consolidation",
+					"consolidation_type": "empty",
+				})
+				if !found {
+					return 0
+				}
+				return metric.GetCounter().GetValue()
+			}
+			before := dryRunActions()
+			for i := 0; i < 2; i++ {
+				var wg sync.WaitGroup
+				ExpectTriggerVerifyAction(&wg)
+				ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+				wg.Wait()
+			}
+			Expect(dryRunActions() - before).To(BeNumerically("==", 1))
+		})
+	})
+	Context("Consolidation Threshold", func() {
+		var pod *v1.Pod
+
+		BeforeEach(func() {
+			// create our RS so we can link a pod to it
+			rs := test.ReplicaSet()
+			ExpectApplied(ctx, env.Client, rs)
+			Expect(env.Client.Get(ctx, client.ObjectKeyFromObject(rs), rs)).To(Succeed())
+
+			pod = test.Pod(test.PodOptions{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion:         "apps/v1",
+							Kind:               "ReplicaSet",
+							Name:               rs.Name,
+							UID:                rs.UID,
+							Controller:         lo.ToPtr(true),
+							BlockOwnerDeletion: lo.ToPtr(true),
+						},
+					}}})
+		})
+		It("won't replace a node when the savings don't beat the threshold", func() {
+			nodePool.Spec.Disruption.ConsolidationThreshold = "1000"
+			ExpectApplied(ctx, env.Client, pod, nodeClaim, node, nodePool)
+
+			// bind pods to node
+			ExpectManualBinding(ctx, env.Client, pod, node)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			// no replacement should be launched and the original node should be untouched
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+			Expect(ExpectNodes(ctx, env.Client)).To(HaveLen(1))
+			node = ExpectExists(ctx, env.Client, node)
+			Expect(node.Spec.Taints).ToNot(ContainElement(v1beta1.DisruptionNoScheduleTaint))
+			_, ok := lo.Find(recorder.Events(), func(e events.Event) bool {
+				return e.Reason == "Unconsolidatable" && strings.Contains(e.Message, "consolidation threshold")
+			})
+			Expect(ok).To(BeTrue())
+		})
+		It("can replace a node when the savings beat the threshold", func() {
+			nodePool.Spec.Disruption.ConsolidationThreshold = "1%"
+			ExpectApplied(ctx, env.Client, pod, nodeClaim, node, nodePool)
+
+			// bind pods to node
+			ExpectManualBinding(ctx, env.Client, pod, node)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectMakeNewNodeClaimsReady(ctx, env.Client, &wg, cluster, cloudProvider, 1)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			// Process the item so that the nodes can be deleted.
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			// Cascade any deletion of the nodeclaim to the node
+			ExpectNodeClaimsCascadeDeletion(ctx, env.Client, nodeClaim)
+
+			nodeClaims := ExpectNodeClaims(ctx, env.Client)
+			Expect(nodeClaims).To(HaveLen(1))
+			Expect(nodeClaims[0].Name).ToNot(Equal(nodeClaim.Name))
+			ExpectNotFound(ctx, env.Client, nodeClaim, node)
+		})
+		It("can replace a node with many pods when the savings beat a percentage threshold", func() {
+			// the disruption cost of this many pods would scale the threshold past the node's price if percentages
+			// were scaled like prices
+			nodePool.Spec.Disruption.ConsolidationThreshold = "25%"
+			pods := test.Pods(10, test.PodOptions{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels,
+					OwnerReferences: pod.OwnerReferences,
+				}})
+			ExpectApplied(ctx, env.Client, nodeClaim, node, nodePool)
+			for _, p := range pods {
+				ExpectApplied(ctx, env.Client, p)
+				ExpectManualBinding(ctx, env.Client, p, node)
+			}
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectMakeNewNodeClaimsReady(ctx, env.Client, &wg, cluster, cloudProvider, 1)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			// Process the item so that the nodes can be deleted.
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			// Cascade any deletion of the nodeclaim to the node
+			ExpectNodeClaimsCascadeDeletion(ctx, env.Client, nodeClaim)
+
+			nodeClaims := ExpectNodeClaims(ctx, env.Client)
+			Expect(nodeClaims).To(HaveLen(1))
+			Expect(nodeClaims[0].Name).ToNot(Equal(nodeClaim.Name))
+			ExpectNotFound(ctx, env.Client, nodeClaim, node)
+		})
+		It("should report consolidations that don't beat the threshold in dry run", func() {
+			nodePool.Spec.Disruption.ConsolidationThreshold = "1000"
+			nodePool.Spec.Disruption.DryRun = true
+			ExpectApplied(ctx, env.Client, pod, nodeClaim, node, nodePool)
+
+			// bind pods to node
+			ExpectManualBinding(ctx, env.Client, pod, node)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+			_, ok := lo.Find(recorder.Events(), func(e events.Event) bool {
+				return e.Reason == "DisruptionDryRun" && strings.Contains(e.Message, "Wouldn't replace") && strings.Contains(e.Message, "consolidation threshold")
+			})
+			Expect(ok).To(BeTrue())
+		})
+		It("can delete empty nodes regardless of the threshold", func() {
+			nodePool.Spec.Disruption.ConsolidationThreshold = "1000"
+			ExpectApplied(ctx, env.Client, nodeClaim, node, nodePool)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			// Cascade any deletion of the nodeclaim to the node
+			ExpectNodeClaimsCascadeDeletion(ctx, env.Client, nodeClaim)
+
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(0))
+			ExpectNotFound(ctx, env.Client, nodeClaim, node)
+		})
+	})
 	Context("Replace", func() {
 		DescribeTable("can replace node",
 			func(spotToSpot bool) {
@@ -1162,8 +1539,8 @@ var _ = Describe("Consolidation", func() {
 
 				// Expect that the new nodeclaim does not request the most expensive instance type
 				Expect(nodeClaims[0].Name).ToNot(Equal(nodeClaim.Name))
-				Expect(scheduling.NewNodeSelectorRequirements(nodeClaims[0].Spec.Requirements...).Has(v1.LabelInstanceTypeStable)).To(BeTrue())
-				Expect(scheduling.NewNodeSelectorRequirements(nodeClaims[0].Spec.Requirements...).Get(v1.LabelInstanceTypeStable).Has(mostExpensiveInstance.Name)).To(BeFalse())
+				Expect(scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaims[0].Spec.Requirements...).Has(v1.LabelInstanceTypeStable)).To(BeTrue())
+				Expect(scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaims[0].Spec.Requirements...).Get(v1.LabelInstanceTypeStable).Has(mostExpensiveInstance.Name)).To(BeFalse())
 
 				// and delete the old one
 				ExpectNotFound(ctx, env.Client, nodeClaim, node)
@@ -1171,6 +1548,74 @@ var _ = Describe("Consolidation", func() {
 			Entry("if the candidate is on-demand node", false),
 			Entry("if the candidate is spot node", true),
 		)
+		It("cannot replace a node with a cheaper instance type that doesn't have its pods' devices", func() {
+			deviceInstance := fake.NewInstanceType(fake.InstanceTypeOptions{
+				Name: "device-instance",
+				Resources: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("32"),
+					v1.ResourceMemory: resource.MustParse("64Gi"),
+				},
+				Devices: cloudprovider.Devices{{Driver: fake.DeviceDriver, Count: 1}},
+			})
+			cheapInstance := fake.NewInstanceType(fake.InstanceTypeOptions{Name: "cheap-instance"})
+			cloudProvider.InstanceTypes = []*cloudprovider.InstanceType{deviceInstance, cheapInstance}
+			offering, ok := lo.Find(deviceInstance.Offerings, func(o cloudprovider.Offering) bool {
+				return o.CapacityType == v1beta1.CapacityTypeOnDemand
+			})
+			Expect(ok).To(BeTrue())
+			nodeClaim.Labels = lo.Assign(nodeClaim.Labels, map[string]string{
+				v1.LabelInstanceTypeStable:   deviceInstance.Name,
+				v1beta1.CapacityTypeLabelKey: offering.CapacityType,
+				v1.LabelTopologyZone:         offering.Zone,
+			})
+			node.Labels = lo.Assign(node.Labels, nodeClaim.Labels)
+
+			resourceClass := &resourcev1alpha2.ResourceClass{ObjectMeta: test.ObjectMeta(), DriverName: fake.DeviceDriver}
+			claim := &resourcev1alpha2.ResourceClaim{
+				ObjectMeta: test.ObjectMeta(),
+				Spec:       resourcev1alpha2.ResourceClaimSpec{ResourceClassName: resourceClass.Name},
+			}
+			rs := test.ReplicaSet()
+			ExpectApplied(ctx, env.Client, rs, resourceClass, claim)
+			Expect(env.Client.Get(ctx, client.ObjectKeyFromObject(rs), rs)).To(Succeed())
+
+			pod := test.Pod(test.PodOptions{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion:         "apps/v1",
+							Kind:               "ReplicaSet",
+							Name:               rs.Name,
+							UID:                rs.UID,
+							Controller:         ptr.Bool(true),
+							BlockOwnerDeletion: ptr.Bool(true),
+						},
+					}}})
+			pod.Spec.ResourceClaims = []v1.PodResourceClaim{{
+				Name:   "device",
+				Source: v1.ClaimSource{ResourceClaimName: lo.ToPtr(claim.Name)},
+			}}
+			ExpectApplied(ctx, env.Client, pod, node, nodeClaim, nodePool)
+
+			// bind pods to node
+			ExpectManualBinding(ctx, env.Client, pod, node)
+
+			// inform cluster state about nodes and nodeClaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			// the cheaper instance type doesn't have a device for the pod's claim, so the node shouldn't be replaced
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+			Expect(ExpectNodes(ctx, env.Client)).To(HaveLen(1))
+			ExpectExists(ctx, env.Client, nodeClaim)
+			ExpectExists(ctx, env.Client, node)
+		})
 		It("cannot replace spot with spot if less than minimum InstanceTypes flexibility", func() {
 			// Forcefully shrink the possible instanceTypes to be lower than 15 to replace a nodeclaim
 			cloudProvider.InstanceTypes = lo.Slice(fake.InstanceTypesAssorted(), 0, 5)
@@ -1465,12 +1910,12 @@ var _ = Describe("Consolidation", func() {
 
 			// Expect that the new nodeclaim does not request the most expensive instance type
 			Expect(nodeClaims[0].Name).ToNot(Equal(spotNodeClaim.Name))
-			Expect(scheduling.NewNodeSelectorRequirements(nodeClaims[0].Spec.Requirements...).Has(v1.LabelInstanceTypeStable)).To(BeTrue())
-			Expect(scheduling.NewNodeSelectorRequirements(nodeClaims[0].Spec.Requirements...).Get(v1.LabelInstanceTypeStable).Has(mostExpensiveInstanceType.Name)).To(BeFalse())
+			Expect(scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaims[0].Spec.Requirements...).Has(v1.LabelInstanceTypeStable)).To(BeTrue())
+			Expect(scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaims[0].Spec.Requirements...).Get(v1.LabelInstanceTypeStable).Has(mostExpensiveInstanceType.Name)).To(BeFalse())
 
 			// Make sure that the cheapest instance that was outside the bound of 15 instance types is considered for consolidation.
-			Expect(scheduling.NewNodeSelectorRequirements(nodeClaims[0].Spec.Requirements...).Get(v1.LabelInstanceTypeStable).Has(cheapestSpotInstanceType.Name)).To(BeTrue())
-			spotInstancesConsideredForConsolidation := scheduling.NewNodeSelectorRequirements(nodeClaims[0].Spec.Requirements...).Get(v1.LabelInstanceTypeStable).Values()
+			Expect(scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaims[0].Spec.Requirements...).Get(v1.LabelInstanceTypeStable).Has(cheapestSpotInstanceType.Name)).To(BeTrue())
+			spotInstancesConsideredForConsolidation := scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaims[0].Spec.Requirements...).Get(v1.LabelInstanceTypeStable).Values()
 
 			// Make sure that we send only 15 instance types.
 			Expect(len(spotInstancesConsideredForConsolidation)).To(Equal(15))
@@ -1538,8 +1983,8 @@ var _ = Describe("Consolidation", func() {
 
 				// Expect that the new nodeclaim does not request the most expensive instance type
 				Expect(nodeclaims[0].Name).ToNot(Equal(nodeClaim.Name))
-				Expect(scheduling.NewNodeSelectorRequirements(nodeclaims[0].Spec.Requirements...).Has(v1.LabelInstanceTypeStable)).To(BeTrue())
-				Expect(scheduling.NewNodeSelectorRequirements(nodeclaims[0].Spec.Requirements...).Get(v1.LabelInstanceTypeStable).Has(mostExpensiveInstance.Name)).To(BeFalse())
+				Expect(scheduling.NewNodeSelectorRequirementsWithMinValues(nodeclaims[0].Spec.Requirements...).Has(v1.LabelInstanceTypeStable)).To(BeTrue())
+				Expect(scheduling.NewNodeSelectorRequirementsWithMinValues(nodeclaims[0].Spec.Requirements...).Get(v1.LabelInstanceTypeStable).Has(mostExpensiveInstance.Name)).To(BeFalse())
 
 				// and delete the old one
 				ExpectNotFound(ctx, env.Client, nodeClaim, node)
@@ -2259,66 +2704,253 @@ var _ = Describe("Consolidation", func() {
 						Available:    true,
 					},
 					{
-						CapacityType: v1beta1.CapacityTypeSpot,
-						Zone:         "test-zone-1c",
-						Price:        0.3,
+						CapacityType: v1beta1.CapacityTypeSpot,
+						Zone:         "test-zone-1c",
+						Price:        0.3,
+						Available:    true,
+					},
+				},
+			})
+
+			cloudProvider.InstanceTypes = []*cloudprovider.InstanceType{
+				currentInstance,
+				replacementInstance,
+			}
+
+			// create our RS so we can link a pod to it
+			rs := test.ReplicaSet()
+			ExpectApplied(ctx, env.Client, rs)
+			Expect(env.Client.Get(ctx, client.ObjectKeyFromObject(rs), rs)).To(Succeed())
+
+			pod := test.Pod(test.PodOptions{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion:         "apps/v1",
+							Kind:               "ReplicaSet",
+							Name:               rs.Name,
+							UID:                rs.UID,
+							Controller:         ptr.Bool(true),
+							BlockOwnerDeletion: ptr.Bool(true),
+						},
+					}}})
+
+			// nodePool should require on-demand instance for this test case
+			nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+				{NodeSelectorRequirement: v1.NodeSelectorRequirement{
+					Key:      v1beta1.CapacityTypeLabelKey,
+					Operator: v1.NodeSelectorOpIn,
+					Values:   []string{v1beta1.CapacityTypeOnDemand},
+				}},
+			}
+			nodeClaim, node = test.NodeClaimAndNode(v1beta1.NodeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						v1beta1.NodePoolLabelKey:     nodePool.Name,
+						v1.LabelInstanceTypeStable:   currentInstance.Name,
+						v1beta1.CapacityTypeLabelKey: currentInstance.Offerings[0].CapacityType,
+						v1.LabelTopologyZone:         currentInstance.Offerings[0].Zone,
+					},
+				},
+				Status: v1beta1.NodeClaimStatus{
+					Allocatable: map[v1.ResourceName]resource.Quantity{v1.ResourceCPU: resource.MustParse("32")},
+				},
+			})
+
+			ExpectApplied(ctx, env.Client, rs, pod, nodeClaim, node, nodePool)
+
+			// bind pods to node
+			ExpectManualBinding(ctx, env.Client, pod, node)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			// Expect to not create or delete more nodeclaims
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+			Expect(ExpectNodes(ctx, env.Client)).To(HaveLen(1))
+			ExpectExists(ctx, env.Client, nodeClaim)
+			ExpectExists(ctx, env.Client, node)
+		})
+		It("won't replace a reserved node with a cheaper node that isn't reserved", func() {
+			currentInstance := fake.NewInstanceType(fake.InstanceTypeOptions{
+				Name: "current-reserved",
+				Offerings: []cloudprovider.Offering{
+					{
+						CapacityType:  v1beta1.CapacityTypeReserved,
+						Zone:          "test-zone-1a",
+						Price:         0.5,
+						Available:     false,
+						ReservationID: "r-1",
+					},
+				},
+			})
+			replacementInstance := fake.NewInstanceType(fake.InstanceTypeOptions{
+				Name: "on-demand-replacement",
+				Offerings: []cloudprovider.Offering{
+					{
+						CapacityType: v1beta1.CapacityTypeOnDemand,
+						Zone:         "test-zone-1a",
+						Price:        0.2,
+						Available:    true,
+					},
+				},
+			})
+			cloudProvider.InstanceTypes = []*cloudprovider.InstanceType{
+				currentInstance,
+				replacementInstance,
+			}
+
+			// create our RS so we can link a pod to it
+			rs := test.ReplicaSet()
+			ExpectApplied(ctx, env.Client, rs)
+			Expect(env.Client.Get(ctx, client.ObjectKeyFromObject(rs), rs)).To(Succeed())
+
+			pod := test.Pod(test.PodOptions{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion:         "apps/v1",
+							Kind:               "ReplicaSet",
+							Name:               rs.Name,
+							UID:                rs.UID,
+							Controller:         ptr.Bool(true),
+							BlockOwnerDeletion: ptr.Bool(true),
+						},
+					}}})
+
+			nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+				{NodeSelectorRequirement: v1.NodeSelectorRequirement{
+					Key:      v1beta1.CapacityTypeLabelKey,
+					Operator: v1.NodeSelectorOpIn,
+					Values:   []string{v1beta1.CapacityTypeReserved, v1beta1.CapacityTypeOnDemand},
+				}},
+			}
+			nodeClaim, node = test.NodeClaimAndNode(v1beta1.NodeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						v1beta1.NodePoolLabelKey:     nodePool.Name,
+						v1.LabelInstanceTypeStable:   currentInstance.Name,
+						v1beta1.CapacityTypeLabelKey: currentInstance.Offerings[0].CapacityType,
+						v1.LabelTopologyZone:         currentInstance.Offerings[0].Zone,
+					},
+				},
+				Status: v1beta1.NodeClaimStatus{
+					Allocatable: map[v1.ResourceName]resource.Quantity{v1.ResourceCPU: resource.MustParse("32")},
+				},
+			})
+
+			ExpectApplied(ctx, env.Client, rs, pod, nodeClaim, node, nodePool)
+
+			// bind pods to node
+			ExpectManualBinding(ctx, env.Client, pod, node)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			// Expect to not create or delete more nodeclaims
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+			Expect(ExpectNodes(ctx, env.Client)).To(HaveLen(1))
+			ExpectExists(ctx, env.Client, nodeClaim)
+			ExpectExists(ctx, env.Client, node)
+		})
+		It("can move pods off a reserved node onto an existing node that isn't reserved", func() {
+			reservedInstance := fake.NewInstanceType(fake.InstanceTypeOptions{
+				Name: "current-reserved",
+				Offerings: []cloudprovider.Offering{
+					{
+						CapacityType:  v1beta1.CapacityTypeReserved,
+						Zone:          "test-zone-1a",
+						Price:         0.5,
+						Available:     false,
+						ReservationID: "r-1",
+					},
+				},
+			})
+			onDemandInstance := fake.NewInstanceType(fake.InstanceTypeOptions{
+				Name: "current-on-demand",
+				Offerings: []cloudprovider.Offering{
+					{
+						CapacityType: v1beta1.CapacityTypeOnDemand,
+						Zone:         "test-zone-1a",
+						Price:        0.5,
 						Available:    true,
 					},
 				},
 			})
-
 			cloudProvider.InstanceTypes = []*cloudprovider.InstanceType{
-				currentInstance,
-				replacementInstance,
+				reservedInstance,
+				onDemandInstance,
 			}
 
 			// create our RS so we can link a pod to it
 			rs := test.ReplicaSet()
 			ExpectApplied(ctx, env.Client, rs)
 			Expect(env.Client.Get(ctx, client.ObjectKeyFromObject(rs), rs)).To(Succeed())
-
-			pod := test.Pod(test.PodOptions{
-				ObjectMeta: metav1.ObjectMeta{Labels: labels,
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion:         "apps/v1",
-							Kind:               "ReplicaSet",
-							Name:               rs.Name,
-							UID:                rs.UID,
-							Controller:         ptr.Bool(true),
-							BlockOwnerDeletion: ptr.Bool(true),
-						},
-					}}})
-
-			// nodePool should require on-demand instance for this test case
-			nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
+			ownerReferences := []metav1.OwnerReference{
 				{
+					APIVersion:         "apps/v1",
+					Kind:               "ReplicaSet",
+					Name:               rs.Name,
+					UID:                rs.UID,
+					Controller:         ptr.Bool(true),
+					BlockOwnerDeletion: ptr.Bool(true),
+				},
+			}
+			pod := test.Pod(test.PodOptions{ObjectMeta: metav1.ObjectMeta{Labels: labels, OwnerReferences: ownerReferences}})
+			// the pod on the on-demand node can't move, so its node can't be consolidated
+			pinned := test.Pod(test.PodOptions{
+				ObjectMeta:   metav1.ObjectMeta{Labels: labels, OwnerReferences: ownerReferences},
+				NodeSelector: map[string]string{"pinned": "true"},
+			})
+
+			nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+				{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 					Key:      v1beta1.CapacityTypeLabelKey,
 					Operator: v1.NodeSelectorOpIn,
-					Values:   []string{v1beta1.CapacityTypeOnDemand},
-				},
+					Values:   []string{v1beta1.CapacityTypeReserved, v1beta1.CapacityTypeOnDemand},
+				}},
 			}
-			nodeClaim, node = test.NodeClaimAndNode(v1beta1.NodeClaim{
+			nodeClaims, nodes := test.NodeClaimsAndNodes(2, v1beta1.NodeClaim{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
 						v1beta1.NodePoolLabelKey:     nodePool.Name,
-						v1.LabelInstanceTypeStable:   currentInstance.Name,
-						v1beta1.CapacityTypeLabelKey: currentInstance.Offerings[0].CapacityType,
-						v1.LabelTopologyZone:         currentInstance.Offerings[0].Zone,
+						v1.LabelInstanceTypeStable:   reservedInstance.Name,
+						v1beta1.CapacityTypeLabelKey: reservedInstance.Offerings[0].CapacityType,
+						v1.LabelTopologyZone:         reservedInstance.Offerings[0].Zone,
 					},
 				},
 				Status: v1beta1.NodeClaimStatus{
 					Allocatable: map[v1.ResourceName]resource.Quantity{v1.ResourceCPU: resource.MustParse("32")},
 				},
 			})
+			for _, obj := range []client.Object{nodeClaims[1], nodes[1]} {
+				obj.SetLabels(lo.Assign(obj.GetLabels(), map[string]string{
+					v1.LabelInstanceTypeStable:   onDemandInstance.Name,
+					v1beta1.CapacityTypeLabelKey: onDemandInstance.Offerings[0].CapacityType,
+					"pinned":                     "true",
+				}))
+			}
 
-			ExpectApplied(ctx, env.Client, rs, pod, nodeClaim, node, nodePool)
+			ExpectApplied(ctx, env.Client, rs, pod, pinned, nodeClaims[0], nodes[0], nodeClaims[1], nodes[1], nodePool)
 
-			// bind pods to node
-			ExpectManualBinding(ctx, env.Client, pod, node)
+			// bind pods to nodes
+			ExpectManualBinding(ctx, env.Client, pod, nodes[0])
+			ExpectManualBinding(ctx, env.Client, pinned, nodes[1])
 
 			// inform cluster state about nodes and nodeclaims
-			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, nodes, nodeClaims)
 
 			fakeClock.Step(10 * time.Minute)
 			var wg sync.WaitGroup
@@ -2326,11 +2958,17 @@ var _ = Describe("Consolidation", func() {
 			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
 			wg.Wait()
 
-			// Expect to not create or delete more nodeclaims
+			// Process the item so that the nodes can be deleted.
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			// Cascade any deletion of the nodeclaim to the node
+			ExpectNodeClaimsCascadeDeletion(ctx, env.Client, nodeClaims[0])
+
+			// the reserved node should be deleted without launching a replacement
 			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
 			Expect(ExpectNodes(ctx, env.Client)).To(HaveLen(1))
-			ExpectExists(ctx, env.Client, nodeClaim)
-			ExpectExists(ctx, env.Client, node)
+			ExpectNotFound(ctx, env.Client, nodeClaims[0], nodes[0])
+			ExpectExists(ctx, env.Client, nodeClaims[1])
 		})
 	})
 	Context("Delete", func() {
@@ -3113,7 +3751,7 @@ var _ = Describe("Consolidation", func() {
 				Spec: v1beta1.NodePoolSpec{
 					Template: v1beta1.NodeClaimTemplate{
 						Spec: v1beta1.NodeClaimSpec{
-							Requirements: []v1.NodeSelectorRequirement{},
+							Requirements: []v1beta1.NodeSelectorRequirementWithMinValues{},
 							NodeClassRef: &v1beta1.NodeClassReference{
 								Name: "non-existent",
 							},
@@ -3168,6 +3806,108 @@ var _ = Describe("Consolidation", func() {
 			ExpectNotFound(ctx, env.Client, nodeClaims[1], nodes[1])
 		})
 	})
+	Context("ConsolidateAfter", func() {
+		var nodeClaims []*v1beta1.NodeClaim
+		var nodes []*v1.Node
+		var pods []*v1.Pod
+
+		BeforeEach(func() {
+			nodePool.Spec.Disruption.ConsolidateAfter = &v1beta1.NillableDuration{Duration: lo.ToPtr(time.Hour)}
+			nodeClaims, nodes = test.NodeClaimsAndNodes(2, v1beta1.NodeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						v1beta1.NodePoolLabelKey:     nodePool.Name,
+						v1.LabelInstanceTypeStable:   leastExpensiveInstance.Name,
+						v1beta1.CapacityTypeLabelKey: leastExpensiveOffering.CapacityType,
+						v1.LabelTopologyZone:         leastExpensiveOffering.Zone,
+					},
+				},
+				Status: v1beta1.NodeClaimStatus{
+					Allocatable: map[v1.ResourceName]resource.Quantity{
+						v1.ResourceCPU:  resource.MustParse("32"),
+						v1.ResourcePods: resource.MustParse("100"),
+					},
+				},
+			})
+			// create our RS so we can link a pod to it
+			rs := test.ReplicaSet()
+			ExpectApplied(ctx, env.Client, rs)
+			pods = test.Pods(3, test.PodOptions{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion:         "apps/v1",
+							Kind:               "ReplicaSet",
+							Name:               rs.Name,
+							UID:                rs.UID,
+							Controller:         ptr.Bool(true),
+							BlockOwnerDeletion: ptr.Bool(true),
+						},
+					}}})
+			ExpectApplied(ctx, env.Client, pods[0], pods[1], pods[2], nodeClaims[0], nodes[0], nodeClaims[1], nodes[1], nodePool)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{nodes[0], nodes[1]}, []*v1beta1.NodeClaim{nodeClaims[0], nodeClaims[1]})
+
+			// bind pods to node and inform cluster state about the bindings
+			ExpectManualBinding(ctx, env.Client, pods[0], nodes[0])
+			ExpectManualBinding(ctx, env.Client, pods[1], nodes[0])
+			ExpectManualBinding(ctx, env.Client, pods[2], nodes[1])
+			for _, p := range pods {
+				Expect(cluster.UpdatePod(ctx, ExpectPodExists(ctx, env.Client, p.Name, p.Namespace))).To(Succeed())
+			}
+		})
+		It("should not consolidate nodes that have had pods scheduled within consolidateAfter", func() {
+			fakeClock.Step(10 * time.Minute)
+
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(2))
+			Expect(ExpectNodes(ctx, env.Client)).To(HaveLen(2))
+		})
+		It("should consolidate nodes once pod scheduling has been stable for consolidateAfter", func() {
+			fakeClock.Step(time.Hour)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			// Process the item so that the nodes can be deleted.
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			// Cascade any deletion of the nodeclaim to the node
+			ExpectNodeClaimsCascadeDeletion(ctx, env.Client, nodeClaims[1])
+
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+			Expect(ExpectNodes(ctx, env.Client)).To(HaveLen(1))
+			ExpectNotFound(ctx, env.Client, nodeClaims[1], nodes[1])
+		})
+		It("should only consolidate nodes whose pod scheduling has been stable for consolidateAfter", func() {
+			fakeClock.Step(50 * time.Minute)
+			// removing a pod from nodes[0] restarts its stabilization window
+			ExpectDeleted(ctx, env.Client, pods[1])
+			cluster.DeletePod(client.ObjectKeyFromObject(pods[1]))
+			fakeClock.Step(20 * time.Minute)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			// Process the item so that the nodes can be deleted.
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			// Cascade any deletion of the nodeclaim to the node
+			ExpectNodeClaimsCascadeDeletion(ctx, env.Client, nodeClaims[1])
+
+			// nodes[1] is the only candidate, and its pod can move to nodes[0]
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+			ExpectExists(ctx, env.Client, nodeClaims[0])
+			ExpectNotFound(ctx, env.Client, nodeClaims[1], nodes[1])
+		})
+	})
 	Context("TTL", func() {
 		var nodeClaims []*v1beta1.NodeClaim
 		var nodes []*v1.Node
@@ -4402,6 +5142,176 @@ var _ = Describe("Consolidation", func() {
 			Entry("if the candidate is spot node", true),
 		)
 	})
+	Context("Global", func() {
+		var nodeClaims []*v1beta1.NodeClaim
+		var nodes []*v1.Node
+
+		BeforeEach(func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{ConsolidationPlanner: lo.ToPtr(options.ConsolidationPlannerGlobal)}))
+			nodeClaims, nodes = test.NodeClaimsAndNodes(3, v1beta1.NodeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						v1beta1.NodePoolLabelKey:     nodePool.Name,
+						v1.LabelInstanceTypeStable:   mostExpensiveInstance.Name,
+						v1beta1.CapacityTypeLabelKey: mostExpensiveOffering.CapacityType,
+						v1.LabelTopologyZone:         mostExpensiveOffering.Zone,
+					},
+				},
+				Status: v1beta1.NodeClaimStatus{
+					Allocatable: map[v1.ResourceName]resource.Quantity{
+						v1.ResourceCPU:  resource.MustParse("32"),
+						v1.ResourcePods: resource.MustParse("100"),
+					},
+				},
+			})
+		})
+		It("can merge 3 nodes into 1", func() {
+			// create our RS so we can link a pod to it
+			rs := test.ReplicaSet()
+			ExpectApplied(ctx, env.Client, rs)
+			pods := test.Pods(3, test.PodOptions{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion:         "apps/v1",
+							Kind:               "ReplicaSet",
+							Name:               rs.Name,
+							UID:                rs.UID,
+							Controller:         ptr.Bool(true),
+							BlockOwnerDeletion: ptr.Bool(true),
+						},
+					}}})
+
+			ExpectApplied(ctx, env.Client, rs, pods[0], pods[1], pods[2], nodeClaims[0], nodes[0], nodeClaims[1], nodes[1], nodeClaims[2], nodes[2], nodePool)
+			ExpectMakeNodesInitialized(ctx, env.Client, nodes[0], nodes[1], nodes[2])
+
+			// bind pods to nodes
+			ExpectManualBinding(ctx, env.Client, pods[0], nodes[0])
+			ExpectManualBinding(ctx, env.Client, pods[1], nodes[1])
+			ExpectManualBinding(ctx, env.Client, pods[2], nodes[2])
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{nodes[0], nodes[1], nodes[2]}, []*v1beta1.NodeClaim{nodeClaims[0], nodeClaims[1], nodeClaims[2]})
+
+			fakeClock.Step(10 * time.Minute)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectMakeNewNodeClaimsReady(ctx, env.Client, &wg, cluster, cloudProvider, 1)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			// the command should have been chosen by global consolidation rather than multi-node consolidation
+			records := &v1beta1.DisruptionRecordList{}
+			Expect(env.Client.List(ctx, records)).To(Succeed())
+			Expect(records.Items).To(HaveLen(1))
+			Expect(records.Items[0].Spec.ConsolidationType).To(Equal("global"))
+			Expect(records.Items[0].Spec.Candidates).To(HaveLen(3))
+
+			// Process the item so that the nodes can be deleted.
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			// Cascade any deletion of the nodeclaim to the node
+			ExpectNodeClaimsCascadeDeletion(ctx, env.Client, nodeClaims[0], nodeClaims[1], nodeClaims[2])
+
+			// three nodeclaims should be replaced with a single nodeclaim
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+			Expect(ExpectNodes(ctx, env.Client)).To(HaveLen(1))
+			ExpectNotFound(ctx, env.Client, nodeClaims[0], nodes[0], nodeClaims[1], nodes[1], nodeClaims[2], nodes[2])
+		})
+		It("can consolidate a subset of the candidates that multi-node consolidation doesn't consider", func() {
+			rs := test.ReplicaSet()
+			ExpectApplied(ctx, env.Client, rs)
+			ownerReferences := []metav1.OwnerReference{
+				{
+					APIVersion:         "apps/v1",
+					Kind:               "ReplicaSet",
+					Name:               rs.Name,
+					UID:                rs.UID,
+					Controller:         ptr.Bool(true),
+					BlockOwnerDeletion: ptr.Bool(true),
+				},
+			}
+			// the pod on the first node can only schedule to it, so no subset that includes the first node can be
+			// consolidated, while the pods of the other nodes fit on it
+			nodeClaims[0].Labels["pinned"] = "true"
+			nodes[0].Labels["pinned"] = "true"
+			pinned := test.Pod(test.PodOptions{
+				ObjectMeta:   metav1.ObjectMeta{Labels: labels, OwnerReferences: ownerReferences},
+				NodeSelector: map[string]string{"pinned": "true"},
+			})
+			pods := test.Pods(5, test.PodOptions{ObjectMeta: metav1.ObjectMeta{Labels: labels, OwnerReferences: ownerReferences}})
+
+			ExpectApplied(ctx, env.Client, pinned, pods[0], pods[1], pods[2], pods[3], pods[4], nodeClaims[0], nodes[0], nodeClaims[1], nodes[1], nodeClaims[2], nodes[2], nodePool)
+
+			// the nodes are ordered by disruption cost, with the pinned node being the cheapest to disrupt
+			ExpectManualBinding(ctx, env.Client, pinned, nodes[0])
+			ExpectManualBinding(ctx, env.Client, pods[0], nodes[1])
+			ExpectManualBinding(ctx, env.Client, pods[1], nodes[1])
+			ExpectManualBinding(ctx, env.Client, pods[2], nodes[2])
+			ExpectManualBinding(ctx, env.Client, pods[3], nodes[2])
+			ExpectManualBinding(ctx, env.Client, pods[4], nodes[2])
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{nodes[0], nodes[1], nodes[2]}, []*v1beta1.NodeClaim{nodeClaims[0], nodeClaims[1], nodeClaims[2]})
+
+			// multi-node consolidation only considers the candidates that are cheapest to disrupt, which include the pinned node
+			multiConsolidation := disruption.NewMultiNodeConsolidation(disruption.MakeConsolidation(fakeClock, cluster, env.Client, prov, cloudProvider, recorder, queue))
+			candidates, err := disruption.GetCandidates(ctx, cluster, env.Client, recorder, fakeClock, cloudProvider, disruption.NewGlobalConsolidation(disruption.MakeConsolidation(fakeClock, cluster, env.Client, prov, cloudProvider, recorder, queue)).ShouldDisrupt, queue)
+			Expect(err).To(Succeed())
+			Expect(candidates).To(HaveLen(3))
+			budgets, err := disruption.BuildDisruptionBudgets(ctx, cluster, fakeClock, env.Client, recorder, multiConsolidation.Reason())
+			Expect(err).To(Succeed())
+			cmd, results, err := multiConsolidation.ComputeCommand(ctx, budgets, candidates...)
+			Expect(err).To(Succeed())
+			Expect(results).To(Equal(pscheduling.Results{}))
+			Expect(cmd).To(Equal(disruption.Command{}))
+
+			recorder.Reset()
+			fakeClock.Step(10 * time.Minute)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			records := &v1beta1.DisruptionRecordList{}
+			Expect(env.Client.List(ctx, records)).To(Succeed())
+			Expect(records.Items).To(HaveLen(1))
+			Expect(records.Items[0].Spec.ConsolidationType).To(Equal("global"))
+			Expect(lo.Map(records.Items[0].Spec.Candidates, func(c v1beta1.DisruptionRecordCandidate, _ int) string { return c.NodeClaim })).To(ConsistOf(nodeClaims[1].Name, nodeClaims[2].Name))
+			// searching the subsets that include the pinned node shouldn't report that it can't be consolidated
+			Expect(recorder.Calls("Unconsolidatable")).To(Equal(0))
+		})
+		It("should not consider candidates when the binary-search planner is selected", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{ConsolidationPlanner: lo.ToPtr(options.ConsolidationPlannerBinarySearch)}))
+			ExpectApplied(ctx, env.Client, nodeClaims[0], nodes[0], nodeClaims[1], nodes[1], nodeClaims[2], nodes[2], nodePool)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{nodes[0], nodes[1], nodes[2]}, []*v1beta1.NodeClaim{nodeClaims[0], nodeClaims[1], nodeClaims[2]})
+
+			globalConsolidation := disruption.NewGlobalConsolidation(disruption.MakeConsolidation(fakeClock, cluster, env.Client, prov, cloudProvider, recorder, queue))
+			candidates, err := disruption.GetCandidates(ctx, cluster, env.Client, recorder, fakeClock, cloudProvider, globalConsolidation.ShouldDisrupt, queue)
+			Expect(err).To(Succeed())
+			Expect(candidates).To(BeEmpty())
+
+			multiConsolidation := disruption.NewMultiNodeConsolidation(disruption.MakeConsolidation(fakeClock, cluster, env.Client, prov, cloudProvider, recorder, queue))
+			candidates, err = disruption.GetCandidates(ctx, cluster, env.Client, recorder, fakeClock, cloudProvider, multiConsolidation.ShouldDisrupt, queue)
+			Expect(err).To(Succeed())
+			Expect(candidates).To(HaveLen(3))
+		})
+		It("should not consider candidates for multi-node consolidation when the global planner is selected", func() {
+			ExpectApplied(ctx, env.Client, nodeClaims[0], nodes[0], nodeClaims[1], nodes[1], nodeClaims[2], nodes[2], nodePool)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{nodes[0], nodes[1], nodes[2]}, []*v1beta1.NodeClaim{nodeClaims[0], nodeClaims[1], nodeClaims[2]})
+
+			multiConsolidation := disruption.NewMultiNodeConsolidation(disruption.MakeConsolidation(fakeClock, cluster, env.Client, prov, cloudProvider, recorder, queue))
+			candidates, err := disruption.GetCandidates(ctx, cluster, env.Client, recorder, fakeClock, cloudProvider, multiConsolidation.ShouldDisrupt, queue)
+			Expect(err).To(Succeed())
+			Expect(candidates).To(BeEmpty())
+		})
+	})
 	Context("Node Lifetime Consideration", func() {
 		var nodeClaims []*v1beta1.NodeClaim
 		var nodes []*v1.Node
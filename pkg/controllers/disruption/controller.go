@@ -34,6 +34,7 @@ import (
 
 	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
 	"sigs.k8s.io/karpenter/pkg/cloudprovider"
+	disruptionevents "sigs.k8s.io/karpenter/pkg/controllers/disruption/events"
 	"sigs.k8s.io/karpenter/pkg/controllers/disruption/orchestration"
 	"sigs.k8s.io/karpenter/pkg/controllers/provisioning"
 	"sigs.k8s.io/karpenter/pkg/controllers/provisioning/scheduling"
@@ -52,8 +53,13 @@ type Controller struct {
 	clock         clock.Clock
 	cloudProvider cloudprovider.CloudProvider
 	methods       []Method
-	mu            sync.Mutex
-	lastRun       map[string]time.Time
+	// dryRunMethods evaluate the candidates in dry-run mode. They're separate instances of the methods so that what the
+	// methods remember about the cluster between passes, like whether it's consolidated, only reflects their candidates.
+	dryRunMethods []Method
+	// lastDryRun is the last command that was reported for the candidates in dry-run mode by each method
+	lastDryRun map[string]string
+	mu         sync.Mutex
+	lastRun    map[string]time.Time
 }
 
 // pollingPeriod that we inspect cluster to look for opportunities to disrupt
@@ -64,7 +70,6 @@ var errCandidateDeleting = fmt.Errorf("candidate is deleting")
 func NewController(clk clock.Clock, kubeClient client.Client, provisioner *provisioning.Provisioner,
 	cp cloudprovider.CloudProvider, recorder events.Recorder, cluster *state.Cluster, queue *orchestration.Queue,
 ) *Controller {
-	c := MakeConsolidation(clk, cluster, kubeClient, provisioner, cp, recorder, queue)
 	return &Controller{
 		queue:         queue,
 		clock:         clk,
@@ -74,20 +79,31 @@ func NewController(clk clock.Clock, kubeClient client.Client, provisioner *provi
 		recorder:      recorder,
 		cloudProvider: cp,
 		lastRun:       map[string]time.Time{},
-		methods: []Method{
-			// Expire any NodeClaims that must be deleted, allowing their pods to potentially land on currently
-			NewExpiration(clk, kubeClient, cluster, provisioner, recorder),
-			// Terminate any NodeClaims that have drifted from provisioning specifications, allowing the pods to reschedule.
-			NewDrift(kubeClient, cluster, provisioner, recorder),
-			// Delete any remaining empty NodeClaims as there is zero cost in terms of disruption.  Emptiness and
-			// emptyNodeConsolidation are mutually exclusive, only one of these will operate
-			NewEmptiness(clk, recorder),
-			NewEmptyNodeConsolidation(c),
-			// Attempt to identify multiple NodeClaims that we can consolidate simultaneously to reduce pod churn
-			NewMultiNodeConsolidation(c),
-			// And finally fall back our single NodeClaim consolidation to further reduce cluster cost.
-			NewSingleNodeConsolidation(c),
-		},
+		lastDryRun:    map[string]string{},
+		methods:       newMethods(clk, kubeClient, provisioner, cp, recorder, cluster, queue),
+		dryRunMethods: newMethods(clk, kubeClient, provisioner, cp, recorder, cluster, queue),
+	}
+}
+
+func newMethods(clk clock.Clock, kubeClient client.Client, provisioner *provisioning.Provisioner,
+	cp cloudprovider.CloudProvider, recorder events.Recorder, cluster *state.Cluster, queue *orchestration.Queue,
+) []Method {
+	c := MakeConsolidation(clk, cluster, kubeClient, provisioner, cp, recorder, queue)
+	return []Method{
+		// Expire any NodeClaims that must be deleted, allowing their pods to potentially land on currently
+		NewExpiration(clk, kubeClient, cluster, provisioner, recorder),
+		// Terminate any NodeClaims that have drifted from provisioning specifications, allowing the pods to reschedule.
+		NewDrift(kubeClient, cluster, provisioner, recorder),
+		// Delete any remaining empty NodeClaims as there is zero cost in terms of disruption.  Emptiness and
+		// emptyNodeConsolidation are mutually exclusive, only one of these will operate
+		NewEmptiness(clk, recorder),
+		NewEmptyNodeConsolidation(c),
+		// Attempt to identify multiple NodeClaims that we can consolidate simultaneously to reduce pod churn. Global
+		// and multi-node consolidation are mutually exclusive, the consolidation planner selects which one operates
+		NewGlobalConsolidation(c),
+		NewMultiNodeConsolidation(c),
+		// And finally fall back our single NodeClaim consolidation to further reduce cluster cost.
+		NewSingleNodeConsolidation(c),
 	}
 }
 
@@ -116,6 +132,12 @@ func (c *Controller) Reconcile(ctx context.Context, _ reconcile.Request) (reconc
 		return reconcile.Result{RequeueAfter: time.Second}, nil
 	}
 
+	// Commands are persisted on their candidates, so resume any commands that were in flight when Karpenter last
+	// stopped before we consider the taints on the cluster's nodes.
+	if err := c.queue.Restore(ctx); err != nil {
+		return reconcile.Result{}, fmt.Errorf("restoring disruption commands, %w", err)
+	}
+
 	// Karpenter taints nodes with a karpenter.sh/disruption taint as part of the disruption process
 	// while it progresses in memory. If Karpenter restarts during a disruption action, some nodes can be left tainted.
 	// Idempotently remove this taint from candidates that are not in the orchestration queue before continuing.
@@ -126,9 +148,9 @@ func (c *Controller) Reconcile(ctx context.Context, _ reconcile.Request) (reconc
 	}
 
 	// Attempt different disruption methods. We'll only let one method perform an action
-	for _, m := range c.methods {
+	for i, m := range c.methods {
 		c.recordRun(fmt.Sprintf("%T", m))
-		success, err := c.disrupt(ctx, m)
+		success, err := c.disrupt(ctx, m, c.dryRunMethods[i])
 		if err != nil {
 			return reconcile.Result{}, fmt.Errorf("disrupting via %q, %w", m.Type(), err)
 		}
@@ -141,7 +163,7 @@ func (c *Controller) Reconcile(ctx context.Context, _ reconcile.Request) (reconc
 	return reconcile.Result{RequeueAfter: pollingPeriod}, nil
 }
 
-func (c *Controller) disrupt(ctx context.Context, disruption Method) (bool, error) {
+func (c *Controller) disrupt(ctx context.Context, disruption Method, dryRun Method) (bool, error) {
 	defer metrics.Measure(disruptionEvaluationDurationHistogram.With(map[string]string{
 		methodLabel:            disruption.Type(),
 		consolidationTypeLabel: disruption.ConsolidationType(),
@@ -152,28 +174,94 @@ func (c *Controller) disrupt(ctx context.Context, disruption Method) (bool, erro
 	}
 	// If there are no candidates, move to the next disruption
 	if len(candidates) == 0 {
+		c.reportDryRun(ctx, dryRun, Command{})
 		return false, nil
 	}
-	disruptionBudgetMapping, err := BuildDisruptionBudgets(ctx, c.cluster, c.clock, c.kubeClient, c.recorder)
+	disruptionBudgetMapping, err := BuildDisruptionBudgets(ctx, c.cluster, c.clock, c.kubeClient, c.recorder, disruption.Reason())
 	if err != nil {
 		return false, fmt.Errorf("building disruption budgets, %w", err)
 	}
+	// Candidates in dry-run mode are considered separately so that a command that we'd only report never holds up
+	// disrupting the other candidates
+	dryRunCandidates := lo.Filter(candidates, func(cn *Candidate, _ int) bool { return cn.dryRun(ctx) })
+	candidates = lo.Reject(candidates, func(cn *Candidate, _ int) bool { return cn.dryRun(ctx) })
+
+	if len(candidates) > 0 {
+		// Computing the command consumes the budgets of its candidates, so the dry-run candidates get their own copy
+		cmd, schedulingResults, err := c.computeCommand(ctx, disruption, lo.Assign(disruptionBudgetMapping), candidates...)
+		if err != nil {
+			return false, err
+		}
+		if cmd.Action() != NoOpAction {
+			// Attempt to disrupt
+			if err := c.executeCommand(ctx, disruption, cmd, schedulingResults); err != nil {
+				return false, fmt.Errorf("disrupting candidates, %w", err)
+			}
+			return true, nil
+		}
+	}
+	dryRunCmd := Command{}
+	if len(dryRunCandidates) > 0 {
+		if dryRunCmd, _, err = c.computeCommand(ctx, dryRun, disruptionBudgetMapping, dryRunCandidates...); err != nil {
+			return false, err
+		}
+	}
+	c.reportDryRun(ctx, dryRun, dryRunCmd)
+	return false, nil
+}
 
-	// Determine the disruption action
+// computeCommand determines the disruption action for the candidates
+func (c *Controller) computeCommand(ctx context.Context, disruption Method, disruptionBudgetMapping map[string]int, candidates ...*Candidate) (Command, scheduling.Results, error) {
 	cmd, schedulingResults, err := disruption.ComputeCommand(ctx, disruptionBudgetMapping, candidates...)
 	if err != nil {
-		return false, fmt.Errorf("computing disruption decision, %w", err)
+		return Command{}, scheduling.Results{}, fmt.Errorf("computing disruption decision, %w", err)
 	}
-	if cmd.Action() == NoOpAction {
-		return false, nil
+	// Consolidation is the only way we voluntarily scale a NodePool down, so it shouldn't take a NodePool below its
+	// minimums or remove the capacity that its headroom relies on. Drift and expiration replace nodes, and the
+	// minimum controller and provisioner relaunch anything they remove.
+	if disruption.Reason() == v1beta1.DisruptionReasonEmpty || disruption.Reason() == v1beta1.DisruptionReasonUnderutilized {
+		cmd = EnforceMinimums(c.cluster, c.recorder, cmd)
 	}
-
-	// Attempt to disrupt
-	if err := c.executeCommand(ctx, disruption, cmd, schedulingResults); err != nil {
-		return false, fmt.Errorf("disrupting candidates, %w", err)
+	// Empty nodes are deleted without simulating scheduling, so we check that the headroom doesn't rely on them here
+	if disruption.Reason() == v1beta1.DisruptionReasonEmpty {
+		if cmd, err = EnforceHeadroom(ctx, c.kubeClient, c.cluster, c.provisioner, c.recorder, cmd); err != nil {
+			return Command{}, scheduling.Results{}, fmt.Errorf("enforcing headroom, %w", err)
+		}
 	}
+	return cmd, schedulingResults, nil
+}
 
-	return true, nil
+// reportDryRun publishes the command that we would have executed if its candidates weren't in dry-run mode. The
+// candidates are left alone, so the same command is computed on each pass until the cluster changes. It's only
+// counted and logged when it changes, but its events are published each time so that they don't expire.
+func (c *Controller) reportDryRun(ctx context.Context, m Method, cmd Command) {
+	key := m.Type() + "/" + m.ConsolidationType()
+	if cmd.Action() == NoOpAction {
+		delete(c.lastDryRun, key)
+		return
+	}
+	labels := map[string]string{
+		actionLabel:            string(cmd.Action()),
+		methodLabel:            m.Type(),
+		consolidationTypeLabel: m.ConsolidationType(),
+	}
+	description := fmt.Sprintf("disrupt via %s %s", m.Type(), cmd)
+	savings, err := estimatedSavings(cmd)
+	if err == nil {
+		disruptionDryRunEstimatedSavingsGauge.With(labels).Set(savings)
+		description = fmt.Sprintf("%s, saving an estimated %.4f per hour", description, savings)
+	}
+	if c.lastDryRun[key] != cmd.String() {
+		c.lastDryRun[key] = cmd.String()
+		disruptionDryRunActionsCounter.With(labels).Inc()
+		if err != nil {
+			logging.FromContext(ctx).Debugf("estimating savings of dry-run disruption, %s", err)
+		}
+		logging.FromContext(ctx).Infof("dry run, would %s", description)
+	}
+	for _, candidate := range cmd.candidates {
+		c.recorder.Publish(disruptionevents.DryRun(candidate.Node, candidate.NodeClaim, fmt.Sprintf("Would %s", description))...)
+	}
 }
 
 // executeCommand will do the following, untainting if the step fails.
@@ -229,9 +317,12 @@ func (c *Controller) executeCommand(ctx context.Context, m Method, cmd Command,
 	// We have the new NodeClaims created at the API server so mark the old NodeClaims for deletion
 	c.cluster.MarkForDeletion(providerIDs...)
 
-	if err := c.queue.Add(orchestration.NewCommand(nodeClaimNames,
+	// The record is created before the command is queued so that the queue can always find it to record the outcome
+	c.recordCommand(ctx, m, cmd, commandID, nodeClaimNames)
+	if err := c.queue.Add(ctx, orchestration.NewCommand(nodeClaimNames,
 		lo.Map(cmd.candidates, func(c *Candidate, _ int) *state.StateNode { return c.StateNode }), commandID, m.Type(), m.ConsolidationType())); err != nil {
 		c.cluster.UnmarkForDeletion(providerIDs...)
+		c.deleteRecord(ctx, commandID)
 		return fmt.Errorf("adding command to queue (command-id: %s), %w", commandID, multierr.Append(err, state.RequireNoScheduleTaint(ctx, c.kubeClient, false, stateNodes...)))
 	}
 	return nil
@@ -123,6 +123,10 @@ func (d *Drift) Type() string {
 	return metrics.DriftReason
 }
 
+func (d *Drift) Reason() v1beta1.DisruptionReason {
+	return v1beta1.DisruptionReasonDrifted
+}
+
 func (d *Drift) ConsolidationType() string {
 	return ""
 }
@@ -94,6 +94,10 @@ func (e *Emptiness) Type() string {
 	return metrics.EmptinessReason
 }
 
+func (e *Emptiness) Reason() v1beta1.DisruptionReason {
+	return v1beta1.DisruptionReasonEmpty
+}
+
 func (e *Emptiness) ConsolidationType() string {
 	return ""
 }
@@ -389,6 +389,216 @@ var _ = Describe("Emptiness", func() {
 			Expect(len(ExpectNodeClaims(ctx, env.Client))).To(Equal(0))
 		})
 	})
+	Context("Paused", func() {
+		It("should not disrupt empty nodes when disruption is paused for the nodepool", func() {
+			nodePool.Spec.Paused = []v1beta1.PausedOperation{v1beta1.PausedOperationDisruption}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClaim, node)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			ExpectExists(ctx, env.Client, nodeClaim)
+			ExpectExists(ctx, env.Client, node)
+			Expect(recorder.Calls("DisruptionPaused")).To(BeNumerically(">", 0))
+		})
+		It("should disrupt empty nodes when only provisioning is paused for the nodepool", func() {
+			nodePool.Spec.Paused = []v1beta1.PausedOperation{v1beta1.PausedOperationProvisioning}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClaim, node)
+
+			// inform cluster state about nodes and nodeclaims
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+			fakeClock.Step(10 * time.Minute)
+			wg := sync.WaitGroup{}
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			// Process the item so that the nodes can be deleted.
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+			// Cascade any deletion of the nodeClaim to the node
+			ExpectNodeClaimsCascadeDeletion(ctx, env.Client, nodeClaim)
+
+			ExpectNotFound(ctx, env.Client, nodeClaim, node)
+		})
+	})
+	Context("Minimums", func() {
+		var numNodes = 10
+		var nodeClaims []*v1beta1.NodeClaim
+		var nodes []*v1.Node
+		BeforeEach(func() {
+			nodeClaims, nodes = test.NodeClaimsAndNodes(numNodes, v1beta1.NodeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						v1beta1.NodePoolLabelKey:     nodePool.Name,
+						v1.LabelInstanceTypeStable:   mostExpensiveInstance.Name,
+						v1beta1.CapacityTypeLabelKey: mostExpensiveOffering.CapacityType,
+						v1.LabelTopologyZone:         mostExpensiveOffering.Zone,
+					},
+				},
+				Status: v1beta1.NodeClaimStatus{
+					Capacity: map[v1.ResourceName]resource.Quantity{
+						v1.ResourceCPU:  resource.MustParse("32"),
+						v1.ResourcePods: resource.MustParse("100"),
+					},
+					Allocatable: map[v1.ResourceName]resource.Quantity{
+						v1.ResourceCPU:  resource.MustParse("32"),
+						v1.ResourcePods: resource.MustParse("100"),
+					},
+				},
+			})
+		})
+		DescribeTable("should not disrupt empty nodes past the nodepool minimums",
+			func(minimums v1beta1.Minimums, remaining int) {
+				nodePool.Spec.Minimums = minimums
+				ExpectApplied(ctx, env.Client, nodePool)
+				for i := 0; i < numNodes; i++ {
+					nodeClaims[i].StatusConditions().MarkTrue(v1beta1.Empty)
+					ExpectApplied(ctx, env.Client, nodeClaims[i], nodes[i])
+				}
+
+				// Step the clock 10 minutes so that the emptiness expires
+				fakeClock.Step(10 * time.Minute)
+
+				// inform cluster state about nodes and nodeclaims
+				ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, nodes, nodeClaims)
+
+				var wg sync.WaitGroup
+				ExpectTriggerVerifyAction(&wg)
+				ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+				wg.Wait()
+
+				// Execute the command in the queue, only deleting the nodes above the minimums
+				ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+				Expect(len(ExpectNodeClaims(ctx, env.Client))).To(Equal(remaining))
+				Expect(recorder.Calls("DisruptionBlocked")).To(BeNumerically(">", 0))
+			},
+			Entry("with a node minimum", v1beta1.Minimums{v1beta1.ResourceNodes: resource.MustParse("4")}, 4),
+			Entry("with a resource minimum", v1beta1.Minimums{v1.ResourceCPU: resource.MustParse("96")}, 3),
+		)
+		It("should not disrupt any empty nodes when the nodepool is at its minimums", func() {
+			nodePool.Spec.Minimums = v1beta1.Minimums{v1beta1.ResourceNodes: resource.MustParse("10")}
+			ExpectApplied(ctx, env.Client, nodePool)
+			for i := 0; i < numNodes; i++ {
+				nodeClaims[i].StatusConditions().MarkTrue(v1beta1.Empty)
+				ExpectApplied(ctx, env.Client, nodeClaims[i], nodes[i])
+			}
+			fakeClock.Step(10 * time.Minute)
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, nodes, nodeClaims)
+
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+			Expect(len(ExpectNodeClaims(ctx, env.Client))).To(Equal(10))
+		})
+	})
+	Context("Headroom", func() {
+		var numNodes = 10
+		var nodeClaims []*v1beta1.NodeClaim
+		var nodes []*v1.Node
+		BeforeEach(func() {
+			nodeClaims, nodes = test.NodeClaimsAndNodes(numNodes, v1beta1.NodeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						v1beta1.NodePoolLabelKey:     nodePool.Name,
+						v1.LabelInstanceTypeStable:   mostExpensiveInstance.Name,
+						v1beta1.CapacityTypeLabelKey: mostExpensiveOffering.CapacityType,
+						v1.LabelTopologyZone:         mostExpensiveOffering.Zone,
+					},
+				},
+				Status: v1beta1.NodeClaimStatus{
+					Capacity: map[v1.ResourceName]resource.Quantity{
+						v1.ResourceCPU:  resource.MustParse("32"),
+						v1.ResourcePods: resource.MustParse("100"),
+					},
+					Allocatable: map[v1.ResourceName]resource.Quantity{
+						v1.ResourceCPU:  resource.MustParse("32"),
+						v1.ResourcePods: resource.MustParse("100"),
+					},
+				},
+			})
+		})
+		It("should not disrupt empty nodes that the nodepool headroom relies on", func() {
+			// Each headroom pod needs a node of its own
+			nodePool.Spec.Headroom = []v1beta1.Headroom{{
+				Count:    3,
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("30")},
+			}}
+			ExpectApplied(ctx, env.Client, nodePool)
+			for i := 0; i < numNodes; i++ {
+				nodeClaims[i].StatusConditions().MarkTrue(v1beta1.Empty)
+				ExpectApplied(ctx, env.Client, nodeClaims[i], nodes[i])
+			}
+			fakeClock.Step(10 * time.Minute)
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, nodes, nodeClaims)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+			Expect(len(ExpectNodeClaims(ctx, env.Client))).To(Equal(3))
+			Expect(recorder.Calls("DisruptionBlocked")).To(BeNumerically(">", 0))
+		})
+		It("should disrupt empty nodes when the headroom needs new capacity regardless", func() {
+			// No existing node is large enough for the headroom, so removing them doesn't displace it
+			nodePool.Spec.Headroom = []v1beta1.Headroom{{
+				Count:    1,
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("40")},
+			}}
+			ExpectApplied(ctx, env.Client, nodePool)
+			for i := 0; i < numNodes; i++ {
+				nodeClaims[i].StatusConditions().MarkTrue(v1beta1.Empty)
+				ExpectApplied(ctx, env.Client, nodeClaims[i], nodes[i])
+			}
+			fakeClock.Step(10 * time.Minute)
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, nodes, nodeClaims)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+			Expect(len(ExpectNodeClaims(ctx, env.Client))).To(Equal(0))
+			Expect(recorder.Calls("DisruptionBlocked")).To(Equal(0))
+		})
+		It("should ignore the headroom of other nodepools", func() {
+			nodePool.Spec.Headroom = []v1beta1.Headroom{{
+				Count:    1,
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("30")},
+			}}
+			// The other nodepool has no nodes, so its headroom always needs new capacity
+			other := test.NodePool(v1beta1.NodePool{
+				Spec: v1beta1.NodePoolSpec{
+					Headroom: []v1beta1.Headroom{{
+						Count:    2,
+						Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+					}},
+				},
+			})
+			ExpectApplied(ctx, env.Client, nodePool, other)
+			for i := 0; i < numNodes; i++ {
+				nodeClaims[i].StatusConditions().MarkTrue(v1beta1.Empty)
+				ExpectApplied(ctx, env.Client, nodeClaims[i], nodes[i])
+			}
+			fakeClock.Step(10 * time.Minute)
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, nodes, nodeClaims)
+
+			var wg sync.WaitGroup
+			ExpectTriggerVerifyAction(&wg)
+			ExpectReconcileSucceeded(ctx, disruptionController, client.ObjectKey{})
+			wg.Wait()
+
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+			// only the node that the nodepool's own headroom relies on is kept
+			Expect(len(ExpectNodeClaims(ctx, env.Client))).To(Equal(1))
+		})
+	})
 	Context("Emptiness", func() {
 		It("can delete empty nodes", func() {
 			ExpectApplied(ctx, env.Client, nodePool, nodeClaim, node)
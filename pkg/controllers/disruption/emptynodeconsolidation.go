@@ -23,6 +23,7 @@ import (
 
 	"knative.dev/pkg/logging"
 
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
 	"sigs.k8s.io/karpenter/pkg/controllers/provisioning/scheduling"
 	"sigs.k8s.io/karpenter/pkg/metrics"
 )
@@ -97,7 +98,7 @@ func (c *EmptyNodeConsolidation) ComputeCommand(ctx context.Context, disruptionB
 	// We do this so that we can re-validate that the candidates that were computed before we made the decision are the same
 	candidatesToDelete := mapCandidates(cmd.candidates, validationCandidates)
 
-	postValidationMapping, err := BuildDisruptionBudgets(ctx, c.cluster, c.clock, c.kubeClient, c.recorder)
+	postValidationMapping, err := BuildDisruptionBudgets(ctx, c.cluster, c.clock, c.kubeClient, c.recorder, c.Reason())
 	if err != nil {
 		return Command{}, scheduling.Results{}, fmt.Errorf("building disruption budgets, %w", err)
 	}
@@ -120,6 +121,10 @@ func (c *EmptyNodeConsolidation) Type() string {
 	return metrics.ConsolidationReason
 }
 
+func (c *EmptyNodeConsolidation) Reason() v1beta1.DisruptionReason {
+	return v1beta1.DisruptionReasonEmpty
+}
+
 func (c *EmptyNodeConsolidation) ConsolidationType() string {
 	return "empty"
 }
@@ -121,14 +121,46 @@ func Blocked(node *v1.Node, nodeClaim *v1beta1.NodeClaim, reason string) []event
 	}
 }
 
-func NodePoolBlocked(nodePool *v1beta1.NodePool) events.Event {
+func NodePoolBlocked(nodePool *v1beta1.NodePool, reason v1beta1.DisruptionReason) events.Event {
 	return events.Event{
 		InvolvedObject: nodePool,
 		Type:           v1.EventTypeNormal,
 		Reason:         "DisruptionBlocked",
-		Message:        "No allowed disruptions due to blocking budget",
-		DedupeValues:   []string{string(nodePool.UID)},
+		Message:        fmt.Sprintf("No allowed disruptions for disruption reason %s due to blocking budget", reason),
+		DedupeValues:   []string{string(nodePool.UID), string(reason)},
 		// Set a small timeout as a NodePool's disruption budget can change every minute.
 		DedupeTimeout: 1 * time.Minute,
 	}
 }
+
+func NodePoolPaused(nodePool *v1beta1.NodePool) events.Event {
+	return events.Event{
+		InvolvedObject: nodePool,
+		Type:           v1.EventTypeNormal,
+		Reason:         "DisruptionPaused",
+		Message:        "Disruption is paused for the NodePool",
+		DedupeValues:   []string{string(nodePool.UID)},
+		DedupeTimeout:  5 * time.Minute,
+	}
+}
+
+// DryRun is an event that informs the user of the disruption that would have been performed on a NodeClaim/Node
+// combination if it weren't in dry-run mode
+func DryRun(node *v1.Node, nodeClaim *v1beta1.NodeClaim, message string) []events.Event {
+	return []events.Event{
+		{
+			InvolvedObject: node,
+			Type:           v1.EventTypeNormal,
+			Reason:         "DisruptionDryRun",
+			Message:        message,
+			DedupeValues:   []string{string(node.UID), message},
+		},
+		{
+			InvolvedObject: nodeClaim,
+			Type:           v1.EventTypeNormal,
+			Reason:         "DisruptionDryRun",
+			Message:        message,
+			DedupeValues:   []string{string(nodeClaim.UID), message},
+		},
+	}
+}
@@ -129,6 +129,10 @@ func (e *Expiration) Type() string {
 	return metrics.ExpirationReason
 }
 
+func (e *Expiration) Reason() v1beta1.DisruptionReason {
+	return v1beta1.DisruptionReasonExpired
+}
+
 func (e *Expiration) ConsolidationType() string {
 	return ""
 }
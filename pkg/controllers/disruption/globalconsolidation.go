@@ -0,0 +1,282 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disruption
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/samber/lo"
+	"knative.dev/pkg/logging"
+
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+	"sigs.k8s.io/karpenter/pkg/controllers/provisioning/scheduling"
+	"sigs.k8s.io/karpenter/pkg/events"
+	"sigs.k8s.io/karpenter/pkg/metrics"
+	"sigs.k8s.io/karpenter/pkg/operator/options"
+)
+
+const (
+	GlobalConsolidationTimeoutDuration = 1 * time.Minute
+	// globalConsolidationMaxCandidates is the most candidates that global consolidation searches subsets of
+	globalConsolidationMaxCandidates = 100
+	// scoreEpsilon is the difference in score or hourly savings below which two plans are considered equal
+	scoreEpsilon = 1e-6
+)
+
+// GlobalConsolidation finds a consolidation of multiple nodes by searching subsets of the candidates for the one that
+// saves the most per unit of disruption cost, rather than binary searching the candidates in order of disruption cost
+// like multi-node consolidation does. It's used in place of multi-node consolidation when the global consolidation
+// planner is selected.
+type GlobalConsolidation struct {
+	consolidation
+	// evaluator computes the consolidations of the subsets that are searched without publishing events, since the
+	// subsets that can't be consolidated aren't a reason that their nodes can't be consolidated
+	evaluator consolidation
+}
+
+func NewGlobalConsolidation(consolidation consolidation) *GlobalConsolidation {
+	evaluator := consolidation
+	evaluator.recorder = discardRecorder{}
+	return &GlobalConsolidation{consolidation: consolidation, evaluator: evaluator}
+}
+
+// discardRecorder is an events.Recorder that doesn't publish any events
+type discardRecorder struct{}
+
+func (discardRecorder) Publish(...events.Event) {}
+
+// plan is a consolidation command along with what it's scored by
+type plan struct {
+	cmd            Command
+	results        scheduling.Results
+	savings        float64
+	disruptionCost float64
+}
+
+// score is how much the plan saves per unit of disruption cost. Empty nodes don't cost anything to disrupt, so the
+// disruption cost is offset by one to keep plans of empty nodes comparable by their savings.
+func (p plan) score() float64 {
+	return p.savings / (1 + p.disruptionCost)
+}
+
+// betterThan returns true if the plan scores higher than the other plan. Plans that score the same are compared by
+// their savings, and then by their disruption cost.
+func (p plan) betterThan(other plan) bool {
+	if d := p.score() - other.score(); d > scoreEpsilon || d < -scoreEpsilon {
+		return d > 0
+	}
+	if d := p.savings - other.savings; d > scoreEpsilon || d < -scoreEpsilon {
+		return d > 0
+	}
+	return p.disruptionCost < other.disruptionCost
+}
+
+// ShouldDisrupt is a predicate used to filter candidates
+func (g *GlobalConsolidation) ShouldDisrupt(ctx context.Context, cn *Candidate) bool {
+	return options.FromContext(ctx).ConsolidationPlanner == options.ConsolidationPlannerGlobal && g.consolidation.ShouldDisrupt(ctx, cn)
+}
+
+func (g *GlobalConsolidation) ComputeCommand(ctx context.Context, disruptionBudgetMapping map[string]int, candidates ...*Candidate) (Command, scheduling.Results, error) {
+	if g.IsConsolidated() {
+		return Command{}, scheduling.Results{}, nil
+	}
+	candidates = g.sortCandidates(candidates)
+	disruptionEligibleNodesGauge.With(map[string]string{
+		methodLabel:            g.Type(),
+		consolidationTypeLabel: g.ConsolidationType(),
+	}).Set(float64(len(candidates)))
+
+	// Filter out the candidates that would violate the budgets, so that any subset of the remaining candidates can be
+	// disrupted. Candidates are considered in order of disruption cost, so the candidates that are kept are the ones
+	// that are cheapest to disrupt.
+	disruptableCandidates := make([]*Candidate, 0, len(candidates))
+	constrainedByBudgets := false
+	for _, candidate := range candidates {
+		if disruptionBudgetMapping[candidate.nodePool.Name] == 0 {
+			constrainedByBudgets = true
+			continue
+		}
+		disruptableCandidates = append(disruptableCandidates, candidate)
+		disruptionBudgetMapping[candidate.nodePool.Name]--
+	}
+	disruptableCandidates = lo.Slice(disruptableCandidates, 0, globalConsolidationMaxCandidates)
+
+	best, err := g.search(ctx, disruptableCandidates)
+	if err != nil {
+		return Command{}, scheduling.Results{}, err
+	}
+	if best.cmd.Action() == NoOpAction {
+		// if there are no candidates because of a budget, don't mark as consolidated, as it's possible it should be
+		// consolidatable the next time we try to disrupt.
+		if !constrainedByBudgets {
+			g.markConsolidated()
+		}
+		return Command{}, scheduling.Results{}, nil
+	}
+
+	v := NewValidation(consolidationTTL, g.clock, g.cluster, g.kubeClient, g.provisioner, g.cloudProvider, g.recorder, g.queue)
+	isValid, err := v.IsValid(ctx, best.cmd)
+	if err != nil {
+		return Command{}, scheduling.Results{}, fmt.Errorf("validating, %w", err)
+	}
+	if !isValid {
+		logging.FromContext(ctx).Debugf("abandoning global consolidation attempt due to pod churn, command is no longer valid, %s", best.cmd)
+		return Command{}, scheduling.Results{}, nil
+	}
+	return best.cmd, best.results, nil
+}
+
+// search looks for the subset of the candidates that saves the most per unit of disruption cost. It greedily builds a
+// plan by adding candidates in order of how much they cost per unit of disruption cost, keeping each candidate that
+// can be consolidated along with the ones before it, and then improves the best plan that it found by removing
+// candidates from it or swapping them for candidates outside of it, until no change improves the plan or the search
+// times out. Only plans of at least two candidates are returned, since single-node consolidation finds the others.
+func (g *GlobalConsolidation) search(ctx context.Context, candidates []*Candidate) (plan, error) {
+	if len(candidates) < 2 {
+		return plan{}, nil
+	}
+	deadline := g.clock.Now().Add(GlobalConsolidationTimeoutDuration)
+	candidates = sortByDensity(candidates)
+
+	var current, best plan
+	timedOut := func() bool {
+		if g.clock.Now().After(deadline) {
+			disruptionConsolidationTimeoutTotalCounter.WithLabelValues(g.ConsolidationType()).Inc()
+			logging.FromContext(ctx).Debugf("stopping global consolidation after timeout, returning best command %s", best.cmd)
+			return true
+		}
+		return false
+	}
+
+	// Build the plan greedily. A plan that scores lower is still extended, since a plan of one candidate would
+	// otherwise outscore every plan of two candidates that's more disruptive.
+	for _, candidate := range candidates {
+		if timedOut() {
+			return best, nil
+		}
+		p, ok, err := g.evaluate(ctx, append(append([]*Candidate{}, current.cmd.candidates...), candidate))
+		if err != nil {
+			return plan{}, err
+		}
+		if !ok {
+			continue
+		}
+		current = p
+		if len(current.cmd.candidates) >= 2 && current.betterThan(best) {
+			best = current
+		}
+	}
+	// Improve the best plan with local search
+	for improved := len(best.cmd.candidates) > 0; improved; {
+		improved = false
+		for _, subset := range neighbors(best.cmd.candidates, candidates) {
+			if timedOut() {
+				return best, nil
+			}
+			p, ok, err := g.evaluate(ctx, subset)
+			if err != nil {
+				return plan{}, err
+			}
+			if ok && p.betterThan(best) {
+				best = p
+				improved = true
+				break
+			}
+		}
+	}
+	return best, nil
+}
+
+// evaluate computes the consolidation of the candidates, returning false if they can't be consolidated
+func (g *GlobalConsolidation) evaluate(ctx context.Context, candidates []*Candidate) (plan, bool, error) {
+	cmd, results, err := g.evaluator.computeConsolidation(ctx, candidates...)
+	if err != nil {
+		return plan{}, false, err
+	}
+	switch cmd.Action() {
+	case ReplaceAction:
+		// see explanation on filterOutSameType for why this is required
+		cmd.replacements[0].InstanceTypeOptions = filterOutSameType(cmd.replacements[0], candidates)
+		if len(cmd.replacements[0].InstanceTypeOptions) == 0 {
+			return plan{}, false, nil
+		}
+	case DeleteAction:
+	default:
+		return plan{}, false, nil
+	}
+	savings, err := estimatedSavings(cmd)
+	if err != nil {
+		return plan{}, false, nil
+	}
+	return plan{
+		cmd:            cmd,
+		results:        results,
+		savings:        savings,
+		disruptionCost: lo.SumBy(candidates, func(cn *Candidate) float64 { return cn.disruptionCost }),
+	}, true, nil
+}
+
+// neighbors returns the subsets of at least two candidates that are one change away from the plan's candidates, by
+// removing one of them or by swapping one of them for a candidate that isn't in the plan. The candidates that were
+// added to the plan last are changed first.
+func neighbors(planned []*Candidate, candidates []*Candidate) [][]*Candidate {
+	var subsets [][]*Candidate
+	unplanned := lo.Without(candidates, planned...)
+	for i := len(planned) - 1; i >= 0; i-- {
+		without := append(append([]*Candidate{}, planned[:i]...), planned[i+1:]...)
+		if len(without) >= 2 {
+			subsets = append(subsets, without)
+		}
+		for _, candidate := range unplanned {
+			subsets = append(subsets, append(append([]*Candidate{}, without...), candidate))
+		}
+	}
+	return subsets
+}
+
+// sortByDensity sorts the candidates by how much they cost per unit of disruption cost, like the value density of
+// items in a knapsack, so that candidates that save the most for the least disruption are considered first
+func sortByDensity(candidates []*Candidate) []*Candidate {
+	density := lo.SliceToMap(candidates, func(cn *Candidate) (*Candidate, float64) {
+		price, err := getCandidatePrices([]*Candidate{cn})
+		if err != nil {
+			return cn, 0
+		}
+		// empty nodes don't cost anything to disrupt
+		return cn, price / (1 + cn.disruptionCost)
+	})
+	sorted := append([]*Candidate{}, candidates...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return density[sorted[i]] > density[sorted[j]]
+	})
+	return sorted
+}
+
+func (g *GlobalConsolidation) Type() string {
+	return metrics.ConsolidationReason
+}
+
+func (g *GlobalConsolidation) Reason() v1beta1.DisruptionReason {
+	return v1beta1.DisruptionReasonUnderutilized
+}
+
+func (g *GlobalConsolidation) ConsolidationType() string {
+	return "global"
+}
@@ -21,13 +21,18 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 
 	"github.com/samber/lo"
 
 	disruptionevents "sigs.k8s.io/karpenter/pkg/controllers/disruption/events"
 	nodeutils "sigs.k8s.io/karpenter/pkg/utils/node"
+	podutil "sigs.k8s.io/karpenter/pkg/utils/pod"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/clock"
 	"knative.dev/pkg/logging"
@@ -42,11 +47,25 @@ import (
 	"sigs.k8s.io/karpenter/pkg/events"
 	"sigs.k8s.io/karpenter/pkg/metrics"
 	"sigs.k8s.io/karpenter/pkg/scheduling"
+	"sigs.k8s.io/karpenter/pkg/utils/resources"
 )
 
-//nolint:gocyclo
+// SimulateScheduling simulates scheduling the pods of the candidates, along with any pending pods, as if the candidates
+// were removed. The headroom of the candidates' NodePools is included so that we don't disrupt capacity that it relies on.
 func SimulateScheduling(ctx context.Context, kubeClient client.Client, cluster *state.Cluster, provisioner *provisioning.Provisioner,
 	candidates ...*Candidate,
+) (pscheduling.Results, error) {
+	return simulateScheduling(ctx, kubeClient, cluster, provisioner, candidateNodePools(candidates), candidates...)
+}
+
+// candidateNodePools returns the names of the NodePools that the candidates belong to
+func candidateNodePools(candidates []*Candidate) sets.Set[string] {
+	return sets.New(lo.Map(candidates, func(c *Candidate, _ int) string { return c.nodePool.Name })...)
+}
+
+//nolint:gocyclo
+func simulateScheduling(ctx context.Context, kubeClient client.Client, cluster *state.Cluster, provisioner *provisioning.Provisioner,
+	headroomNodePools sets.Set[string], candidates ...*Candidate,
 ) (pscheduling.Results, error) {
 	candidateNames := sets.NewString(lo.Map(candidates, func(t *Candidate, i int) string { return t.Name() })...)
 	nodes := cluster.Nodes()
@@ -78,6 +97,12 @@ func SimulateScheduling(ctx context.Context, kubeClient client.Client, cluster *
 		pods = append(pods, n.reschedulablePods...)
 	}
 	pods = append(pods, deletingNodePods...)
+	// include NodePool headroom so that we don't disrupt capacity that the headroom relies on
+	headroomPods, err := provisioner.GetHeadroomPods(ctx)
+	if err != nil {
+		return pscheduling.Results{}, fmt.Errorf("determining headroom pods, %w", err)
+	}
+	pods = append(pods, lo.Filter(headroomPods, func(p *v1.Pod, _ int) bool { return headroomNodePools.Has(headroomNodePool(p)) })...)
 	scheduler, err := provisioner.NewScheduler(ctx, pods, stateNodes, pscheduling.SchedulerOptions{
 		SimulationMode: true,
 	})
@@ -156,6 +181,14 @@ func filterByPrice(options []*cloudprovider.InstanceType, reqs scheduling.Requir
 	return result
 }
 
+// maxSavings returns the most that replacing candidates of the given price with one of the instance types is sure to
+// save, based on the worst launch price of each instance type
+func maxSavings(options []*cloudprovider.InstanceType, reqs scheduling.Requirements, price float64) float64 {
+	return price - lo.Min(lo.Map(options, func(it *cloudprovider.InstanceType, _ int) float64 {
+		return worstLaunchPrice(it.Offerings.Available(), reqs)
+	}))
+}
+
 func disruptionCost(ctx context.Context, pods []*v1.Pod) float64 {
 	cost := 0.0
 	for _, p := range pods {
@@ -184,8 +217,10 @@ func GetCandidates(ctx context.Context, cluster *state.Cluster, kubeClient clien
 	return lo.Filter(candidates, func(c *Candidate, _ int) bool { return shouldDeprovision(ctx, c) }), nil
 }
 
-// BuildDisruptionBudgets will return a map for nodePoolName -> numAllowedDisruptions and an error
-func BuildDisruptionBudgets(ctx context.Context, cluster *state.Cluster, clk clock.Clock, kubeClient client.Client, recorder events.Recorder) (map[string]int, error) {
+// BuildDisruptionBudgets will return a map for nodePoolName -> numAllowedDisruptions and an error. Only the budgets
+// that apply to the passed disruption reason are considered.
+func BuildDisruptionBudgets(ctx context.Context, cluster *state.Cluster, clk clock.Clock, kubeClient client.Client, recorder events.Recorder,
+	reason v1beta1.DisruptionReason) (map[string]int, error) {
 	nodePoolList := &v1beta1.NodePoolList{}
 	if err := kubeClient.List(ctx, nodePoolList); err != nil {
 		return nil, fmt.Errorf("listing node pools, %w", err)
@@ -222,7 +257,7 @@ func BuildDisruptionBudgets(ctx context.Context, cluster *state.Cluster, clk clo
 
 	for i := range nodePoolList.Items {
 		nodePool := nodePoolList.Items[i]
-		disruptions := nodePool.MustGetAllowedDisruptions(ctx, clk, numNodes[nodePool.Name])
+		disruptions := nodePool.MustGetAllowedDisruptionsByReason(ctx, clk, numNodes[nodePool.Name], reason)
 		// Subtract the allowed number of disruptions from the number of already deleting nodes.
 		// Floor the value since the number of deleting nodes can exceed the number of allowed disruptions.
 		// Allowing this value to be negative breaks assumptions in the code used to calculate how
@@ -231,15 +266,126 @@ func BuildDisruptionBudgets(ctx context.Context, cluster *state.Cluster, clk clo
 		disruptionBudgetMapping[nodePool.Name] = allowedDisruptions
 		// If the nodepool is fully blocked, emit an event
 		if allowedDisruptions == 0 {
-			recorder.Publish(disruptionevents.NodePoolBlocked(lo.ToPtr(nodePool)))
+			recorder.Publish(disruptionevents.NodePoolBlocked(lo.ToPtr(nodePool), reason))
 		}
 		disruptionBudgetsAllowedDisruptionsGauge.With(map[string]string{
 			metrics.NodePoolLabel: nodePool.Name,
+			metrics.ReasonLabel:   strings.ToLower(string(reason)),
 		}).Set(float64(allowedDisruptions))
 	}
 	return disruptionBudgetMapping, nil
 }
 
+// EnforceMinimums removes candidates from the command that would take their NodePool below its minimums. Candidates
+// that are only deleted can be dropped individually since the rest of them can still be deleted safely. Replacements
+// are computed for the full set of candidates, so a replace command is dropped entirely if any of its candidates
+// would take a NodePool below its minimums.
+func EnforceMinimums(cluster *state.Cluster, recorder events.Recorder, cmd Command) Command {
+	usage := map[string]v1.ResourceList{}
+	for _, n := range cluster.Nodes().Active() {
+		nodePoolName, ok := n.Labels()[v1beta1.NodePoolLabelKey]
+		if !ok {
+			continue
+		}
+		usage[nodePoolName] = resources.Merge(usage[nodePoolName], n.Capacity(), singleNode)
+	}
+	for _, r := range cmd.replacements {
+		usage[r.NodePoolName] = resources.Merge(usage[r.NodePoolName], minCapacity(r.InstanceTypeOptions), singleNode)
+	}
+	var candidates []*Candidate
+	for _, c := range cmd.candidates {
+		remaining := resources.Subtract(usage[c.nodePool.Name], resources.Merge(c.Capacity(), singleNode))
+		if err := c.nodePool.Spec.Minimums.UnsatisfiedBy(remaining); err != nil {
+			recorder.Publish(disruptionevents.Blocked(c.Node, c.NodeClaim, fmt.Sprintf("Disrupting would take NodePool %q below its minimums, %s", c.nodePool.Name, err))...)
+			if len(cmd.replacements) > 0 {
+				return Command{}
+			}
+			continue
+		}
+		usage[c.nodePool.Name] = remaining
+		candidates = append(candidates, c)
+	}
+	return Command{candidates: candidates, replacements: cmd.replacements}
+}
+
+// EnforceHeadroom removes candidates from a command that deletes empty nodes if removing them would leave NodePool
+// headroom without room to schedule. Consolidation of nodes with pods simulates scheduling with the headroom of the
+// candidates' NodePools, so it never deletes capacity that the headroom relies on and doesn't need to be checked.
+func EnforceHeadroom(ctx context.Context, kubeClient client.Client, cluster *state.Cluster, provisioner *provisioning.Provisioner,
+	recorder events.Recorder, cmd Command) (Command, error) {
+	if len(cmd.replacements) > 0 || !lo.ContainsBy(cmd.candidates, func(c *Candidate) bool { return len(c.nodePool.Spec.Headroom) > 0 }) {
+		return cmd, nil
+	}
+	nodePools := candidateNodePools(cmd.candidates)
+	// Headroom that needs new capacity even if we don't disrupt anything isn't displaced by the candidates
+	baseline, err := simulateScheduling(ctx, kubeClient, cluster, provisioner, nodePools)
+	if err != nil {
+		return Command{}, err
+	}
+	unsatisfied := sets.New(lo.FlatMap(baseline.NewNodeClaims, func(n *pscheduling.NodeClaim, _ int) []types.UID {
+		return lo.FilterMap(n.Pods, func(p *v1.Pod, _ int) (types.UID, bool) { return p.UID, podutil.IsOwnedByNodePool(p) })
+	})...)
+	candidates := cmd.candidates
+	for len(candidates) > 0 {
+		results, err := simulateScheduling(ctx, kubeClient, cluster, provisioner, nodePools, candidates...)
+		if err != nil {
+			return Command{}, err
+		}
+		// Count the new NodeClaims that each NodePool's displaced headroom needs, and keep that many of its candidates
+		displaced := map[string]int{}
+		for _, n := range results.NewNodeClaims {
+			for nodePool := range sets.New(lo.FilterMap(n.Pods, func(p *v1.Pod, _ int) (string, bool) {
+				return headroomNodePool(p), podutil.IsOwnedByNodePool(p) && !unsatisfied.Has(p.UID)
+			})...) {
+				displaced[nodePool]++
+			}
+		}
+		if len(displaced) == 0 {
+			break
+		}
+		var kept []*Candidate
+		for i := len(candidates) - 1; i >= 0; i-- {
+			if c := candidates[i]; displaced[c.nodePool.Name] > 0 {
+				displaced[c.nodePool.Name]--
+				recorder.Publish(disruptionevents.Blocked(c.Node, c.NodeClaim, fmt.Sprintf("Disrupting would leave no room for the headroom of NodePool %q", c.nodePool.Name))...)
+				continue
+			}
+			kept = append([]*Candidate{candidates[i]}, kept...)
+		}
+		candidates = kept
+	}
+	return Command{candidates: candidates}, nil
+}
+
+// headroomNodePool returns the name of the NodePool that owns a headroom pod
+func headroomNodePool(p *v1.Pod) string {
+	owner, _ := lo.Find(p.OwnerReferences, func(o metav1.OwnerReference) bool { return o.Kind == "NodePool" })
+	return owner.Name
+}
+
+// singleNode is the usage of a single node against the "nodes" minimum
+var singleNode = v1.ResourceList{v1beta1.ResourceNodes: *resource.NewQuantity(1, resource.DecimalSI)}
+
+// minCapacity returns the smallest capacity of each resource across the instance types. A replacement can launch as
+// any of its instance types, so this is the capacity that it's guaranteed to add.
+func minCapacity(instanceTypes []*cloudprovider.InstanceType) v1.ResourceList {
+	capacity := v1.ResourceList{}
+	for i, it := range instanceTypes {
+		for resourceName, quantity := range it.Capacity {
+			if value, ok := capacity[resourceName]; i == 0 || (ok && quantity.Cmp(value) < 0) {
+				capacity[resourceName] = quantity
+			}
+		}
+		// A resource that an instance type doesn't have isn't guaranteed to be added
+		for resourceName := range capacity {
+			if _, ok := it.Capacity[resourceName]; !ok {
+				delete(capacity, resourceName)
+			}
+		}
+	}
+	return capacity
+}
+
 // BuildNodePoolMap builds a provName -> nodePool map and a provName -> instanceName -> instance type map
 func BuildNodePoolMap(ctx context.Context, kubeClient client.Client, cloudProvider cloudprovider.CloudProvider) (map[string]*v1beta1.NodePool, map[string]map[string]*cloudprovider.InstanceType, error) {
 	nodePoolMap := map[string]*v1beta1.NodePool{}
@@ -282,7 +428,17 @@ func mapCandidates(proposed, current []*Candidate) []*Candidate {
 // on an instance type. If the instance type has a spot offering available, then it uses the spot offering
 // to get the launch price; else, it uses the on-demand launch price
 func worstLaunchPrice(ofs []cloudprovider.Offering, reqs scheduling.Requirements) float64 {
-	// We prefer to launch spot offerings, so we will get the worst price based on the node requirements
+	// We prefer to launch into reservations, and then spot offerings, so we will get the worst price based on the node requirements
+	if reqs.Get(v1beta1.CapacityTypeLabelKey).Has(v1beta1.CapacityTypeReserved) {
+		reservedOfferings := lo.Filter(ofs, func(of cloudprovider.Offering, _ int) bool {
+			return of.CapacityType == v1beta1.CapacityTypeReserved && reqs.Get(v1.LabelTopologyZone).Has(of.Zone)
+		})
+		if len(reservedOfferings) > 0 {
+			return lo.MaxBy(reservedOfferings, func(of1, of2 cloudprovider.Offering) bool {
+				return of1.Price > of2.Price
+			}).Price
+		}
+	}
 	if reqs.Get(v1beta1.CapacityTypeLabelKey).Has(v1beta1.CapacityTypeSpot) {
 		spotOfferings := lo.Filter(ofs, func(of cloudprovider.Offering, _ int) bool {
 			return of.CapacityType == v1beta1.CapacityTypeSpot && reqs.Get(v1.LabelTopologyZone).Has(of.Zone)
@@ -306,6 +462,62 @@ func worstLaunchPrice(ofs []cloudprovider.Offering, reqs scheduling.Requirements
 	return math.MaxFloat64
 }
 
+// estimatedSavings returns how much cheaper per hour the command's replacements are than its candidates
+func estimatedSavings(cmd Command) (float64, error) {
+	candidatePrice, err := getCandidatePrices(cmd.candidates)
+	if err != nil {
+		return 0, err
+	}
+	replacementPrice, err := getReplacementPrices(cmd.replacements)
+	if err != nil {
+		return 0, err
+	}
+	return candidatePrice - replacementPrice, nil
+}
+
+// getReplacementPrices returns the sum of the prices of the replacements, assuming that each replacement launches with
+// its cheapest compatible offering
+func getReplacementPrices(replacements []*pscheduling.NodeClaim) (float64, error) {
+	var price float64
+	for _, replacement := range replacements {
+		offerings := cloudprovider.Offerings(lo.FlatMap(replacement.InstanceTypeOptions, func(it *cloudprovider.InstanceType, _ int) []cloudprovider.Offering {
+			return it.Offerings.Available().Compatible(replacement.Requirements)
+		}))
+		if len(offerings) == 0 {
+			return 0, fmt.Errorf("no compatible offerings for replacement")
+		}
+		price += offerings.Cheapest().Price
+	}
+	return price, nil
+}
+
+// consolidationThreshold returns the hourly savings that consolidating the candidates must beat. Each candidate with
+// pods contributes its NodePool's threshold. An absolute threshold is scaled up logarithmically with the disruption
+// cost of the candidate's pods so that moving many pods needs more savings without making large nodes impossible to
+// consolidate. A percentage is already relative to the candidate's price, so it isn't scaled, since a scaled
+// percentage could exceed what deleting the candidate saves.
+func consolidationThreshold(candidates []*Candidate) (float64, error) {
+	var threshold float64
+	for _, cn := range candidates {
+		if len(cn.reschedulablePods) == 0 || cn.nodePool.Spec.Disruption.ConsolidationThreshold == "" {
+			continue
+		}
+		price, err := getCandidatePrices([]*Candidate{cn})
+		if err != nil {
+			return 0, err
+		}
+		nodePoolThreshold, err := cn.nodePool.Spec.Disruption.GetConsolidationThreshold(price)
+		if err != nil {
+			return 0, fmt.Errorf("nodepool %s, %w", cn.nodePool.Name, err)
+		}
+		if !strings.HasSuffix(cn.nodePool.Spec.Disruption.ConsolidationThreshold, "%") {
+			nodePoolThreshold *= 1 + math.Log2(1+math.Max(0, cn.disruptionCost))
+		}
+		threshold += nodePoolThreshold
+	}
+	return threshold, nil
+}
+
 func clamp(min, val, max float64) float64 {
 	if val < min {
 		return min
@@ -0,0 +1,73 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disruption
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+)
+
+// recordCommand creates a DisruptionRecord for the command, which outlives the command's events so that disruptions
+// can be audited later. The orchestration queue records the command's outcome on it once the command completes.
+func (c *Controller) recordCommand(ctx context.Context, m Method, cmd Command, commandID types.UID, replacements []string) {
+	record := &v1beta1.DisruptionRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: string(commandID),
+		},
+		Spec: v1beta1.DisruptionRecordSpec{
+			CommandID:         string(commandID),
+			Method:            m.Type(),
+			ConsolidationType: m.ConsolidationType(),
+			Candidates: lo.Map(cmd.candidates, func(cn *Candidate, _ int) v1beta1.DisruptionRecordCandidate {
+				return v1beta1.DisruptionRecordCandidate{
+					NodeClaim:    cn.NodeClaim.Name,
+					Node:         cn.Node.Name,
+					NodePool:     cn.nodePool.Name,
+					InstanceType: cn.instanceType.Name,
+					CapacityType: cn.capacityType,
+					Zone:         cn.zone,
+				}
+			}),
+			Replacements: replacements,
+		},
+	}
+	if price, err := getCandidatePrices(cmd.candidates); err == nil {
+		record.Spec.PriceBefore = fmt.Sprintf("%.4f", price)
+	}
+	if price, err := getReplacementPrices(cmd.replacements); err == nil {
+		record.Spec.PriceAfter = fmt.Sprintf("%.4f", price)
+	}
+	// The record is only for auditing, so failing to create it doesn't stop the command
+	if err := c.kubeClient.Create(ctx, record); err != nil {
+		logging.FromContext(ctx).With("command-id", commandID).Errorf("creating disruption record, %s", err)
+	}
+}
+
+// deleteRecord deletes the DisruptionRecord of a command that wasn't executed after all
+func (c *Controller) deleteRecord(ctx context.Context, commandID types.UID) {
+	if err := c.kubeClient.Delete(ctx, &v1beta1.DisruptionRecord{ObjectMeta: metav1.ObjectMeta{Name: string(commandID)}}); client.IgnoreNotFound(err) != nil {
+		logging.FromContext(ctx).With("command-id", commandID).Errorf("deleting disruption record, %s", err)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/logging"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+	operatorcontroller "sigs.k8s.io/karpenter/pkg/operator/controller"
+	"sigs.k8s.io/karpenter/pkg/operator/options"
+)
+
+var _ operatorcontroller.TypedController[*v1beta1.DisruptionRecord] = (*Controller)(nil)
+
+// Controller deletes DisruptionRecords once they're older than the configured TTL
+type Controller struct {
+	clock      clock.Clock
+	kubeClient client.Client
+}
+
+// NewController is a constructor
+func NewController(clk clock.Clock, kubeClient client.Client) operatorcontroller.Controller {
+	return operatorcontroller.Typed[*v1beta1.DisruptionRecord](kubeClient, &Controller{
+		clock:      clk,
+		kubeClient: kubeClient,
+	})
+}
+
+func (c *Controller) Name() string {
+	return "disruption.history"
+}
+
+// Reconcile deletes the record if it has expired, or requeues it for when it will
+func (c *Controller) Reconcile(ctx context.Context, record *v1beta1.DisruptionRecord) (reconcile.Result, error) {
+	if remaining := options.FromContext(ctx).DisruptionRecordTTL - c.clock.Since(record.CreationTimestamp.Time); remaining > 0 {
+		return reconcile.Result{RequeueAfter: remaining}, nil
+	}
+	if err := c.kubeClient.Delete(ctx, record); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	logging.FromContext(ctx).Debugf("deleted expired disruption record")
+	return reconcile.Result{}, nil
+}
+
+func (c *Controller) Builder(_ context.Context, m manager.Manager) operatorcontroller.Builder {
+	return operatorcontroller.Adapt(controllerruntime.
+		NewControllerManagedBy(m).
+		For(&v1beta1.DisruptionRecord{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: 10}),
+	)
+}
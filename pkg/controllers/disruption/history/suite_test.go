@@ -0,0 +1,111 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/karpenter/pkg/apis"
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+	"sigs.k8s.io/karpenter/pkg/controllers/disruption/history"
+	"sigs.k8s.io/karpenter/pkg/operator/controller"
+	"sigs.k8s.io/karpenter/pkg/operator/options"
+	"sigs.k8s.io/karpenter/pkg/operator/scheme"
+	"sigs.k8s.io/karpenter/pkg/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "knative.dev/pkg/logging/testing"
+
+	. "sigs.k8s.io/karpenter/pkg/test/expectations"
+)
+
+var ctx context.Context
+var env *test.Environment
+var fakeClock *clock.FakeClock
+var historyController controller.Controller
+
+func TestAPIs(t *testing.T) {
+	ctx = TestContextWithLogger(t)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Disruption/History")
+}
+
+var _ = BeforeSuite(func() {
+	env = test.NewEnvironment(scheme.Scheme, test.WithCRDs(apis.CRDs...))
+	ctx = options.ToContext(ctx, test.Options())
+	fakeClock = clock.NewFakeClock(time.Now())
+	historyController = history.NewController(fakeClock, env.Client)
+})
+
+var _ = AfterSuite(func() {
+	Expect(env.Stop()).To(Succeed(), "Failed to stop environment")
+})
+
+var _ = BeforeEach(func() {
+	ctx = options.ToContext(ctx, test.Options(test.OptionsFields{DisruptionRecordTTL: lo.ToPtr(time.Hour)}))
+	fakeClock.SetTime(time.Now())
+})
+
+var _ = AfterEach(func() {
+	ExpectCleanedUp(ctx, env.Client)
+})
+
+var _ = Describe("History", func() {
+	var record *v1beta1.DisruptionRecord
+	BeforeEach(func() {
+		record = &v1beta1.DisruptionRecord{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: test.RandomName(),
+			},
+			Spec: v1beta1.DisruptionRecordSpec{
+				CommandID: "test-id",
+				Method:    "consolidation",
+				Candidates: []v1beta1.DisruptionRecordCandidate{{
+					NodeClaim: test.RandomName(),
+				}},
+			},
+		}
+	})
+	It("should keep records that haven't expired", func() {
+		ExpectApplied(ctx, env.Client, record)
+		fakeClock.Step(30 * time.Minute)
+		result := ExpectReconcileSucceeded(ctx, historyController, client.ObjectKeyFromObject(record))
+		Expect(result.RequeueAfter).To(BeNumerically("~", 30*time.Minute, time.Minute))
+		ExpectExists(ctx, env.Client, record)
+	})
+	It("should delete records once they expire", func() {
+		ExpectApplied(ctx, env.Client, record)
+		fakeClock.Step(2 * time.Hour)
+		ExpectReconcileSucceeded(ctx, historyController, client.ObjectKeyFromObject(record))
+		ExpectNotFound(ctx, env.Client, record)
+	})
+	It("should use the configured TTL", func() {
+		ctx = options.ToContext(ctx, test.Options(test.OptionsFields{DisruptionRecordTTL: lo.ToPtr(3 * time.Hour)}))
+		ExpectApplied(ctx, env.Client, record)
+		fakeClock.Step(2 * time.Hour)
+		ExpectReconcileSucceeded(ctx, historyController, client.ObjectKeyFromObject(record))
+		ExpectExists(ctx, env.Client, record)
+	})
+})
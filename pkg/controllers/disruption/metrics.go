@@ -25,7 +25,8 @@ import (
 
 func init() {
 	crmetrics.Registry.MustRegister(disruptionEvaluationDurationHistogram, disruptionActionsPerformedCounter,
-		disruptionEligibleNodesGauge, disruptionConsolidationTimeoutTotalCounter, disruptionBudgetsAllowedDisruptionsGauge)
+		disruptionEligibleNodesGauge, disruptionConsolidationTimeoutTotalCounter, disruptionBudgetsAllowedDisruptionsGauge,
+		disruptionDryRunActionsCounter, disruptionDryRunEstimatedSavingsGauge, disruptionConsolidationBelowThresholdCounter)
 }
 
 const (
@@ -78,8 +79,35 @@ var (
 			Namespace: metrics.Namespace,
 			Subsystem: disruptionSubsystem,
 			Name:      "budgets_allowed_disruptions",
-			Help:      "The number of nodes for a given NodePool that can be disrupted at a point in time. Labeled by NodePool. Note that allowed disruptions can change very rapidly, as new nodes may be created and others may be deleted at any point.",
+			Help:      "The number of nodes for a given NodePool that can be disrupted at a point in time. Labeled by NodePool and disruption reason. Note that allowed disruptions can change very rapidly, as new nodes may be created and others may be deleted at any point.",
 		},
-		[]string{metrics.NodePoolLabel},
+		[]string{metrics.NodePoolLabel, metrics.ReasonLabel},
+	)
+	disruptionDryRunActionsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: disruptionSubsystem,
+			Name:      "dry_run_actions_total",
+			Help:      "Number of distinct disruption actions that would have been performed for candidates in dry-run mode. An action that is computed again on later passes is only counted once. Labeled by disruption method.",
+		},
+		[]string{actionLabel, methodLabel, consolidationTypeLabel},
+	)
+	disruptionDryRunEstimatedSavingsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: disruptionSubsystem,
+			Name:      "dry_run_estimated_savings",
+			Help:      "Estimated hourly savings of the last disruption action that would have been performed for candidates in dry-run mode. Labeled by disruption method.",
+		},
+		[]string{actionLabel, methodLabel, consolidationTypeLabel},
+	)
+	disruptionConsolidationBelowThresholdCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: disruptionSubsystem,
+			Name:      "consolidation_below_threshold_total",
+			Help:      "Number of consolidation actions that were abandoned because they didn't save more than the consolidation threshold of their NodePools. Multi-node and global consolidation count each set of candidates that they evaluate. Labeled by action.",
+		},
+		[]string{actionLabel},
 	)
 )
@@ -26,9 +26,11 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/logging"
 
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
 	"sigs.k8s.io/karpenter/pkg/cloudprovider"
 	"sigs.k8s.io/karpenter/pkg/controllers/provisioning/scheduling"
 	"sigs.k8s.io/karpenter/pkg/metrics"
+	"sigs.k8s.io/karpenter/pkg/operator/options"
 )
 
 const MultiNodeConsolidationTimeoutDuration = 1 * time.Minute
@@ -41,6 +43,11 @@ func NewMultiNodeConsolidation(consolidation consolidation) *MultiNodeConsolidat
 	return &MultiNodeConsolidation{consolidation: consolidation}
 }
 
+// ShouldDisrupt is a predicate used to filter candidates
+func (m *MultiNodeConsolidation) ShouldDisrupt(ctx context.Context, cn *Candidate) bool {
+	return options.FromContext(ctx).ConsolidationPlanner != options.ConsolidationPlannerGlobal && m.consolidation.ShouldDisrupt(ctx, cn)
+}
+
 func (m *MultiNodeConsolidation) ComputeCommand(ctx context.Context, disruptionBudgetMapping map[string]int, candidates ...*Candidate) (Command, scheduling.Results, error) {
 	if m.IsConsolidated() {
 		return Command{}, scheduling.Results{}, nil
@@ -215,6 +222,10 @@ func (m *MultiNodeConsolidation) Type() string {
 	return metrics.ConsolidationReason
 }
 
+func (m *MultiNodeConsolidation) Reason() v1beta1.DisruptionReason {
+	return v1beta1.DisruptionReasonUnderutilized
+}
+
 func (m *MultiNodeConsolidation) ConsolidationType() string {
 	return "multi"
 }
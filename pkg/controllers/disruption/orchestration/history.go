@@ -0,0 +1,47 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orchestration
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+)
+
+// recordOutcome records how the command ended on its DisruptionRecord. Commands that don't have a record, such as
+// those created before records were, are skipped.
+func (q *Queue) recordOutcome(ctx context.Context, cmd *Command, outcome v1beta1.DisruptionOutcome, message string) {
+	record := &v1beta1.DisruptionRecord{}
+	if err := q.kubeClient.Get(ctx, types.NamespacedName{Name: string(cmd.id)}, record); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			logging.FromContext(ctx).Errorf("getting disruption record, %s", err)
+		}
+		return
+	}
+	stored := record.DeepCopy()
+	record.Status.Outcome = outcome
+	record.Status.Message = message
+	record.Status.CompletionTime = &metav1.Time{Time: q.clock.Now()}
+	if err := q.kubeClient.Status().Patch(ctx, record, client.MergeFrom(stored)); client.IgnoreNotFound(err) != nil {
+		logging.FromContext(ctx).Errorf("recording disruption outcome, %s", err)
+	}
+}
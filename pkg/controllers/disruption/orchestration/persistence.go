@@ -0,0 +1,149 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+	"sigs.k8s.io/karpenter/pkg/controllers/state"
+)
+
+// persistedCommand is the part of a command that is stored on each of its candidates so that the command can be
+// resumed if the controller restarts before the command completes
+type persistedCommand struct {
+	ID                types.UID `json:"id"`
+	Method            string    `json:"method"`
+	ConsolidationType string    `json:"consolidationType,omitempty"`
+	Replacements      []string  `json:"replacements,omitempty"`
+	TimeAdded         time.Time `json:"timeAdded"`
+}
+
+// persist annotates each of the command's candidates with the command
+func (q *Queue) persist(ctx context.Context, cmd *Command) error {
+	raw, err := json.Marshal(persistedCommand{
+		ID:                cmd.id,
+		Method:            cmd.method,
+		ConsolidationType: cmd.consolidationType,
+		Replacements:      lo.Map(cmd.Replacements, func(r Replacement, _ int) string { return r.name }),
+		TimeAdded:         cmd.timeAdded,
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling command, %w", err)
+	}
+	return q.annotateCandidates(ctx, cmd, lo.ToPtr(string(raw)))
+}
+
+// unpersist removes the command from each of its candidates
+func (q *Queue) unpersist(ctx context.Context, cmd *Command) error {
+	return q.annotateCandidates(ctx, cmd, nil)
+}
+
+// annotateCandidates sets the command annotation of the command's candidates, removing it if the value is nil
+func (q *Queue) annotateCandidates(ctx context.Context, cmd *Command, value *string) error {
+	var multiErr error
+	for _, candidate := range cmd.candidates {
+		if candidate.NodeClaim == nil {
+			continue
+		}
+		nodeClaim := &v1beta1.NodeClaim{}
+		if err := q.kubeClient.Get(ctx, client.ObjectKeyFromObject(candidate.NodeClaim), nodeClaim); err != nil {
+			multiErr = multierr.Append(multiErr, client.IgnoreNotFound(fmt.Errorf("getting nodeclaim, %w", err)))
+			continue
+		}
+		current, ok := nodeClaim.Annotations[v1beta1.DisruptionCommandAnnotationKey]
+		if ok == (value != nil) && current == lo.FromPtr(value) {
+			continue
+		}
+		stored := nodeClaim.DeepCopy()
+		if value == nil {
+			delete(nodeClaim.Annotations, v1beta1.DisruptionCommandAnnotationKey)
+		} else {
+			nodeClaim.Annotations = lo.Assign(nodeClaim.Annotations, map[string]string{v1beta1.DisruptionCommandAnnotationKey: *value})
+		}
+		if err := q.kubeClient.Patch(ctx, nodeClaim, client.MergeFrom(stored)); err != nil {
+			multiErr = multierr.Append(multiErr, client.IgnoreNotFound(fmt.Errorf("patching nodeclaim %s, %w", nodeClaim.Name, err)))
+		}
+	}
+	return multiErr
+}
+
+// Restore adds the commands that were persisted on NodeClaims back to the queue, so that commands that were in flight
+// when the controller last stopped are completed instead of rolled back. Commands are only restored once, and the
+// cluster state must be synced before calling it so that each candidate has a state node.
+func (q *Queue) Restore(ctx context.Context) error {
+	q.mu.RLock()
+	restored := q.restored
+	q.mu.RUnlock()
+	if restored {
+		return nil
+	}
+	nodeClaimList := &v1beta1.NodeClaimList{}
+	if err := q.kubeClient.List(ctx, nodeClaimList); err != nil {
+		return fmt.Errorf("listing nodeclaims, %w", err)
+	}
+	stateNodes := lo.SliceToMap(lo.Filter(q.cluster.Nodes(), func(s *state.StateNode, _ int) bool { return s.NodeClaim != nil }),
+		func(s *state.StateNode) (string, *state.StateNode) { return s.NodeClaim.Name, s })
+	commands := map[types.UID]*Command{}
+	var ids []types.UID
+	for i := range nodeClaimList.Items {
+		nodeClaim := &nodeClaimList.Items[i]
+		value, ok := nodeClaim.Annotations[v1beta1.DisruptionCommandAnnotationKey]
+		// NodeClaims that are already deleting were terminated by their command, so there's nothing left to do for them
+		if !ok || !nodeClaim.DeletionTimestamp.IsZero() {
+			continue
+		}
+		persisted := persistedCommand{}
+		if err := json.Unmarshal([]byte(value), &persisted); err != nil {
+			logging.FromContext(ctx).With("nodeclaim", nodeClaim.Name).Errorf("unmarshaling disruption command, %s", err)
+			continue
+		}
+		stateNode, ok := stateNodes[nodeClaim.Name]
+		if !ok {
+			continue
+		}
+		if _, ok := commands[persisted.ID]; !ok {
+			commands[persisted.ID] = NewCommand(persisted.Replacements, nil, persisted.ID, persisted.Method, persisted.ConsolidationType)
+			commands[persisted.ID].timeAdded = persisted.TimeAdded
+			ids = append(ids, persisted.ID)
+		}
+		commands[persisted.ID].candidates = append(commands[persisted.ID].candidates, stateNode)
+	}
+	for _, id := range ids {
+		cmd := commands[id]
+		providerIDs := lo.Map(cmd.candidates, func(s *state.StateNode, _ int) string { return s.ProviderID() })
+		if q.HasAny(providerIDs...) {
+			continue
+		}
+		q.cluster.MarkForDeletion(providerIDs...)
+		q.add(cmd)
+		logging.FromContext(ctx).With("command-id", string(cmd.id)).Infof("resuming disruption via %s", cmd.Reason())
+	}
+	q.mu.Lock()
+	q.restored = true
+	q.mu.Unlock()
+	return nil
+}
@@ -99,6 +99,7 @@ type Queue struct {
 
 	mu                  sync.RWMutex
 	providerIDToCommand map[string]*Command // providerID -> command, maps a candidate to its command
+	restored            bool                // restored is whether the persisted commands were added back to the queue
 
 	kubeClient  client.Client
 	recorder    events.Recorder
@@ -180,6 +181,7 @@ func (q *Queue) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.R
 	}
 	cmd := item.(*Command)
 	ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With("command-id", string(cmd.id)))
+	outcome, message := v1beta1.DisruptionOutcomeSucceeded, ""
 	if err := q.waitOrTerminate(ctx, cmd); err != nil {
 		// If recoverable, re-queue and try again.
 		if !IsUnrecoverableError(err) {
@@ -201,12 +203,16 @@ func (q *Queue) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.R
 			methodLabel:            cmd.method,
 			consolidationTypeLabel: cmd.consolidationType,
 		}).Add(float64(len(failedLaunches)))
-		multiErr := multierr.Combine(err, cmd.lastError, state.RequireNoScheduleTaint(ctx, q.kubeClient, false, cmd.candidates...))
+		multiErr := multierr.Combine(err, cmd.lastError, state.RequireNoScheduleTaint(ctx, q.kubeClient, false, cmd.candidates...), q.unpersist(ctx, cmd))
 		// Log the error
 		logging.FromContext(ctx).With("nodes", strings.Join(lo.Map(cmd.candidates, func(s *state.StateNode, _ int) string {
 			return s.Name()
 		}), ",")).Errorf("failed to disrupt nodes, %s", multiErr)
+		// Commands only fail by timing out or by losing a replacement
+		outcome = lo.Ternary(q.clock.Since(cmd.timeAdded) > maxRetryDuration, v1beta1.DisruptionOutcomeTimedOut, v1beta1.DisruptionOutcomeReplacementFailed)
+		message = multiErr.Error()
 	}
+	q.recordOutcome(ctx, cmd, outcome, message)
 	// If command is complete, remove command from queue.
 	q.Remove(cmd)
 	logging.FromContext(ctx).Infof("command succeeded")
@@ -283,9 +289,9 @@ func (q *Queue) waitOrTerminate(ctx context.Context, cmd *Command) error {
 	return nil
 }
 
-// Add adds commands to the Queue
+// Add adds commands to the Queue and persists them on their candidates
 // Each command added to the queue should already be validated and ready for execution.
-func (q *Queue) Add(cmd *Command) error {
+func (q *Queue) Add(ctx context.Context, cmd *Command) error {
 	providerIDs := lo.Map(cmd.candidates, func(s *state.StateNode, _ int) string {
 		return s.ProviderID()
 	})
@@ -295,13 +301,21 @@ func (q *Queue) Add(cmd *Command) error {
 	}
 
 	cmd.timeAdded = q.clock.Now()
+	if err := q.persist(ctx, cmd); err != nil {
+		return multierr.Append(fmt.Errorf("persisting command, %w", err), q.unpersist(ctx, cmd))
+	}
+	q.add(cmd)
+	return nil
+}
+
+// add adds the command to the queue's internal data structures
+func (q *Queue) add(cmd *Command) {
 	q.mu.Lock()
 	for _, candidate := range cmd.candidates {
 		q.providerIDToCommand[candidate.ProviderID()] = cmd
 	}
 	q.mu.Unlock()
 	q.RateLimitingInterface.Add(cmd)
-	return nil
 }
 
 // HasAny checks to see if the candidate is part of an currently executing command.
@@ -337,6 +351,7 @@ func (q *Queue) Reset() {
 	defer q.mu.Unlock()
 	q.RateLimitingInterface = &controllertest.Queue{Interface: workqueue.New()}
 	q.providerIDToCommand = map[string]*Command{}
+	q.restored = false
 }
 
 func (q *Queue) IsEmpty() bool {
@@ -168,7 +168,7 @@ var _ = Describe("Queue", func() {
 			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node1}, []*v1beta1.NodeClaim{nodeClaim1})
 
 			stateNode := ExpectStateNodeExists(cluster, node1)
-			Expect(queue.Add(orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "", "test-method", "fake-type"))).To(BeNil())
+			Expect(queue.Add(ctx, orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "", "test-method", "fake-type"))).To(BeNil())
 
 			node1 = ExpectNodeExists(ctx, env.Client, node1.Name)
 			Expect(node1.Spec.Taints).To(ContainElement(v1beta1.DisruptionNoScheduleTaint))
@@ -186,7 +186,7 @@ var _ = Describe("Queue", func() {
 			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node1}, []*v1beta1.NodeClaim{nodeClaim1})
 			stateNode := ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim1)
 
-			Expect(queue.Add(orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "", "test-method", "fake-type"))).To(BeNil())
+			Expect(queue.Add(ctx, orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "", "test-method", "fake-type"))).To(BeNil())
 			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
 		})
 		It("should untaint nodes when a command times out", func() {
@@ -194,7 +194,7 @@ var _ = Describe("Queue", func() {
 			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node1}, []*v1beta1.NodeClaim{nodeClaim1})
 			stateNode := ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim1)
 
-			Expect(queue.Add(orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "", "test-method", "fake-type"))).To(BeNil())
+			Expect(queue.Add(ctx, orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "", "test-method", "fake-type"))).To(BeNil())
 
 			// Step the clock to trigger the timeout.
 			fakeClock.Step(11 * time.Minute)
@@ -209,7 +209,7 @@ var _ = Describe("Queue", func() {
 			stateNode := ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim1)
 
 			cmd := orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "", "test-method", "fake-type")
-			Expect(queue.Add(cmd)).To(BeNil())
+			Expect(queue.Add(ctx, cmd)).To(BeNil())
 			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
 
 			// Get the command
@@ -246,7 +246,7 @@ var _ = Describe("Queue", func() {
 			stateNode := ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim1)
 
 			cmd := orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "", "test-method", "fake-type")
-			Expect(queue.Add(cmd)).To(BeNil())
+			Expect(queue.Add(ctx, cmd)).To(BeNil())
 
 			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
 			Expect(cmd.Replacements[0].Initialized).To(BeFalse())
@@ -275,7 +275,7 @@ var _ = Describe("Queue", func() {
 			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node1}, []*v1beta1.NodeClaim{nodeClaim1})
 			stateNode := ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim1)
 			cmd := orchestration.NewCommand([]string{}, []*state.StateNode{stateNode}, "", "test-method", "fake-type")
-			Expect(queue.Add(cmd)).To(BeNil())
+			Expect(queue.Add(ctx, cmd)).To(BeNil())
 
 			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
 
@@ -302,9 +302,9 @@ var _ = Describe("Queue", func() {
 			stateNode2 := ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim2)
 
 			cmd := orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "", "test-method", "fake-type")
-			Expect(queue.Add(cmd)).To(BeNil())
+			Expect(queue.Add(ctx, cmd)).To(BeNil())
 			cmd2 := orchestration.NewCommand(replacements2, []*state.StateNode{stateNode2}, "", "test-method", "fake-type")
-			Expect(queue.Add(cmd2)).To(BeNil())
+			Expect(queue.Add(ctx, cmd2)).To(BeNil())
 
 			// Reconcile the first command and expect nothing to be initialized
 			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
@@ -344,4 +344,124 @@ var _ = Describe("Queue", func() {
 		})
 
 	})
+	Context("History", func() {
+		var record *v1beta1.DisruptionRecord
+		BeforeEach(func() {
+			record = &v1beta1.DisruptionRecord{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-id"},
+				Spec: v1beta1.DisruptionRecordSpec{
+					CommandID:  "test-id",
+					Method:     "test-method",
+					Candidates: []v1beta1.DisruptionRecordCandidate{{NodeClaim: nodeClaim1.Name}},
+				},
+			}
+		})
+		It("should record that a command succeeded", func() {
+			ExpectApplied(ctx, env.Client, nodeClaim1, node1, nodePool, record)
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node1}, []*v1beta1.NodeClaim{nodeClaim1})
+			stateNode := ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim1)
+
+			Expect(queue.Add(ctx, orchestration.NewCommand([]string{}, []*state.StateNode{stateNode}, "test-id", "test-method", "fake-type"))).To(Succeed())
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			record = ExpectExists(ctx, env.Client, record)
+			Expect(record.Status.Outcome).To(Equal(v1beta1.DisruptionOutcomeSucceeded))
+			Expect(record.Status.CompletionTime).ToNot(BeNil())
+			ExpectNodeClaimsCascadeDeletion(ctx, env.Client, nodeClaim1)
+		})
+		It("should record that a command timed out", func() {
+			ExpectApplied(ctx, env.Client, nodeClaim1, node1, nodePool, record)
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node1}, []*v1beta1.NodeClaim{nodeClaim1})
+			stateNode := ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim1)
+
+			Expect(queue.Add(ctx, orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "test-id", "test-method", "fake-type"))).To(Succeed())
+
+			// Step the clock to trigger the timeout.
+			fakeClock.Step(11 * time.Minute)
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			record = ExpectExists(ctx, env.Client, record)
+			Expect(record.Status.Outcome).To(Equal(v1beta1.DisruptionOutcomeTimedOut))
+			Expect(record.Status.Message).To(ContainSubstring("timeout"))
+		})
+		It("should record that a command's replacement failed", func() {
+			ExpectApplied(ctx, env.Client, nodeClaim1, node1, nodePool, record)
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node1}, []*v1beta1.NodeClaim{nodeClaim1})
+			stateNode := ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim1)
+
+			// The replacement was never created, so it looks like it was deleted once the command is old enough
+			Expect(queue.Add(ctx, orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "test-id", "test-method", "fake-type"))).To(Succeed())
+			fakeClock.Step(time.Minute)
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			record = ExpectExists(ctx, env.Client, record)
+			Expect(record.Status.Outcome).To(Equal(v1beta1.DisruptionOutcomeReplacementFailed))
+			node1 = ExpectNodeExists(ctx, env.Client, node1.Name)
+			Expect(node1.Spec.Taints).ToNot(ContainElement(v1beta1.DisruptionNoScheduleTaint))
+		})
+	})
+	Context("Persistence", func() {
+		It("should persist commands on their candidates", func() {
+			ExpectApplied(ctx, env.Client, nodeClaim1, node1, nodePool, replacementNodeClaim)
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node1}, []*v1beta1.NodeClaim{nodeClaim1})
+			stateNode := ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim1)
+
+			Expect(queue.Add(ctx, orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "test-id", "test-method", "fake-type"))).To(Succeed())
+
+			nodeClaim1 = ExpectExists(ctx, env.Client, nodeClaim1)
+			Expect(nodeClaim1.Annotations).To(HaveKey(v1beta1.DisruptionCommandAnnotationKey))
+			Expect(nodeClaim1.Annotations[v1beta1.DisruptionCommandAnnotationKey]).To(ContainSubstring("test-id"))
+			Expect(nodeClaim1.Annotations[v1beta1.DisruptionCommandAnnotationKey]).To(ContainSubstring(ncName))
+		})
+		It("should remove the persisted command when a command fails", func() {
+			ExpectApplied(ctx, env.Client, nodeClaim1, node1, nodePool)
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node1}, []*v1beta1.NodeClaim{nodeClaim1})
+			stateNode := ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim1)
+
+			Expect(queue.Add(ctx, orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "test-id", "test-method", "fake-type"))).To(Succeed())
+
+			// Step the clock to trigger the timeout.
+			fakeClock.Step(11 * time.Minute)
+
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+			nodeClaim1 = ExpectExists(ctx, env.Client, nodeClaim1)
+			Expect(nodeClaim1.Annotations).ToNot(HaveKey(v1beta1.DisruptionCommandAnnotationKey))
+		})
+		It("should resume a persisted command after a restart", func() {
+			ExpectApplied(ctx, env.Client, nodeClaim1, node1, nodePool, replacementNodeClaim, replacementNode)
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node1}, []*v1beta1.NodeClaim{nodeClaim1})
+			stateNode := ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim1)
+
+			Expect(queue.Add(ctx, orchestration.NewCommand(replacements, []*state.StateNode{stateNode}, "test-id", "test-method", "fake-type"))).To(Succeed())
+
+			// Simulate a restart, losing the in-memory commands
+			queue.Reset()
+			Expect(queue.HasAny(stateNode.ProviderID())).To(BeFalse())
+
+			Expect(queue.Restore(ctx)).To(Succeed())
+			Expect(queue.HasAny(stateNode.ProviderID())).To(BeTrue())
+			Expect(ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim1).MarkedForDeletion()).To(BeTrue())
+
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{replacementNode}, []*v1beta1.NodeClaim{replacementNodeClaim})
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			ExpectNodeClaimsCascadeDeletion(ctx, env.Client, nodeClaim1)
+			// And expect the nodeClaim and node to be deleted
+			ExpectNotFound(ctx, env.Client, nodeClaim1, node1)
+		})
+		It("should not restore commands whose candidates are already deleting", func() {
+			ExpectApplied(ctx, env.Client, nodeClaim1, node1, nodePool)
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeStateController, nodeClaimStateController, []*v1.Node{node1}, []*v1beta1.NodeClaim{nodeClaim1})
+			stateNode := ExpectStateNodeExistsForNodeClaim(cluster, nodeClaim1)
+
+			Expect(queue.Add(ctx, orchestration.NewCommand([]string{}, []*state.StateNode{stateNode}, "test-id", "test-method", "fake-type"))).To(Succeed())
+			ExpectReconcileSucceeded(ctx, queue, types.NamespacedName{})
+
+			// Simulate a restart while the candidate is still terminating
+			queue.Reset()
+			Expect(queue.Restore(ctx)).To(Succeed())
+			Expect(queue.IsEmpty()).To(BeTrue())
+			ExpectNodeClaimsCascadeDeletion(ctx, env.Client, nodeClaim1)
+		})
+	})
 })
@@ -23,6 +23,7 @@ import (
 
 	"knative.dev/pkg/logging"
 
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
 	"sigs.k8s.io/karpenter/pkg/controllers/provisioning/scheduling"
 	"sigs.k8s.io/karpenter/pkg/metrics"
 )
@@ -101,6 +102,10 @@ func (s *SingleNodeConsolidation) Type() string {
 	return metrics.ConsolidationReason
 }
 
+func (s *SingleNodeConsolidation) Reason() v1beta1.DisruptionReason {
+	return v1beta1.DisruptionReasonUnderutilized
+}
+
 func (s *SingleNodeConsolidation) ConsolidationType() string {
 	return "single"
 }
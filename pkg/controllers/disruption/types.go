@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/karpenter/pkg/controllers/provisioning/scheduling"
 	"sigs.k8s.io/karpenter/pkg/controllers/state"
 	"sigs.k8s.io/karpenter/pkg/events"
+	"sigs.k8s.io/karpenter/pkg/operator/options"
 )
 
 type Method interface {
@@ -43,6 +44,7 @@ type Method interface {
 	ComputeCommand(context.Context, map[string]int, ...*Candidate) (Command, scheduling.Results, error)
 	Type() string
 	ConsolidationType() string
+	Reason() v1beta1.DisruptionReason
 }
 
 type CandidateFilter func(context.Context, *Candidate) bool
@@ -103,6 +105,10 @@ func NewCandidate(ctx context.Context, kubeClient client.Client, recorder events
 		recorder.Publish(disruptionevents.Blocked(node.Node, node.NodeClaim, fmt.Sprintf("Owning nodepool %q not found", nodePoolName))...)
 		return nil, fmt.Errorf("nodepool %q can't be resolved for state node", nodePoolName)
 	}
+	if nodePool.IsPaused(v1beta1.PausedOperationDisruption) {
+		recorder.Publish(disruptionevents.NodePoolPaused(nodePool))
+		return nil, fmt.Errorf("disruption is paused for nodepool %q", nodePoolName)
+	}
 	instanceType := instanceTypeMap[node.Labels()[v1.LabelInstanceTypeStable]]
 	// skip any candidates that we can't determine the instance of
 	if instanceType == nil {
@@ -143,6 +149,11 @@ func NewCandidate(ctx context.Context, kubeClient client.Client, recorder events
 	}, nil
 }
 
+// dryRun returns true if the disruptions of the candidate should only be reported
+func (c *Candidate) dryRun(ctx context.Context) bool {
+	return options.FromContext(ctx).DisruptionDryRun || c.nodePool.Spec.Disruption.DryRun
+}
+
 // lifetimeRemaining calculates the fraction of node lifetime remaining in the range [0.0, 1.0].  If the TTLSecondsUntilExpired
 // is non-zero, we use it to scale down the disruption costs of candidates that are going to expire.  Just after creation, the
 // disruption cost is highest, and it approaches zero as the node ages towards its expiration time.
@@ -26,6 +26,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 	"knative.dev/pkg/logging"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -48,6 +49,7 @@ var _ operatorcontroller.FinalizingTypedController[*v1.Node] = (*Controller)(nil
 
 // Controller for the resource
 type Controller struct {
+	clock         clock.Clock
 	kubeClient    client.Client
 	cloudProvider cloudprovider.CloudProvider
 	terminator    *terminator.Terminator
@@ -55,8 +57,9 @@ type Controller struct {
 }
 
 // NewController constructs a controller instance
-func NewController(kubeClient client.Client, cloudProvider cloudprovider.CloudProvider, terminator *terminator.Terminator, recorder events.Recorder) operatorcontroller.Controller {
+func NewController(clk clock.Clock, kubeClient client.Client, cloudProvider cloudprovider.CloudProvider, terminator *terminator.Terminator, recorder events.Recorder) operatorcontroller.Controller {
 	return operatorcontroller.Typed[*v1.Node](kubeClient, &Controller{
+		clock:         clk,
 		kubeClient:    kubeClient,
 		cloudProvider: cloudProvider,
 		terminator:    terminator,
@@ -83,7 +86,11 @@ func (c *Controller) Finalize(ctx context.Context, node *v1.Node) (reconcile.Res
 	if err := c.terminator.Taint(ctx, node); err != nil {
 		return reconcile.Result{}, fmt.Errorf("tainting node, %w", err)
 	}
-	if err := c.terminator.Drain(ctx, node); err != nil {
+	nodeTerminationTime, err := c.nodeTerminationTime(ctx, node)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("resolving node termination time, %w", err)
+	}
+	if err := c.terminator.Drain(ctx, node, nodeTerminationTime); err != nil {
 		if !terminator.IsNodeDrainError(err) {
 			return reconcile.Result{}, fmt.Errorf("draining node, %w", err)
 		}
@@ -95,7 +102,11 @@ func (c *Controller) Finalize(ctx context.Context, node *v1.Node) (reconcile.Res
 			}
 			return reconcile.Result{}, fmt.Errorf("getting nodeclaim, %w", err)
 		}
-		return reconcile.Result{RequeueAfter: 1 * time.Second}, nil
+		// Once the node's termination grace period has expired, terminate the instance regardless of the pods left on it
+		if nodeTerminationTime == nil || c.clock.Now().Before(*nodeTerminationTime) {
+			return reconcile.Result{RequeueAfter: 1 * time.Second}, nil
+		}
+		logging.FromContext(ctx).Infof("node termination grace period expired, terminating instance")
 	}
 	if err := c.cloudProvider.Delete(ctx, nodeclaimutil.NewFromNode(node)); cloudprovider.IgnoreNodeClaimNotFoundError(err) != nil {
 		return reconcile.Result{}, fmt.Errorf("terminating cloudprovider instance, %w", err)
@@ -119,6 +130,27 @@ func (c *Controller) deleteAllNodeClaims(ctx context.Context, node *v1.Node) err
 	return nil
 }
 
+// nodeTerminationTime returns the time at which the node's termination grace period expires, measured from the
+// deletion of its NodeClaim. If multiple NodeClaims resolve to the node, the earliest expiration is used.
+func (c *Controller) nodeTerminationTime(ctx context.Context, node *v1.Node) (*time.Time, error) {
+	nodeClaimList := &v1beta1.NodeClaimList{}
+	if err := c.kubeClient.List(ctx, nodeClaimList, client.MatchingFields{"status.providerID": node.Spec.ProviderID}); err != nil {
+		return nil, err
+	}
+	var terminationTime *time.Time
+	for i := range nodeClaimList.Items {
+		nodeClaim := &nodeClaimList.Items[i]
+		if nodeClaim.DeletionTimestamp.IsZero() || nodeClaim.Spec.TerminationGracePeriod == nil {
+			continue
+		}
+		expirationTime := nodeClaim.DeletionTimestamp.Add(nodeClaim.Spec.TerminationGracePeriod.Duration)
+		if terminationTime == nil || expirationTime.Before(*terminationTime) {
+			terminationTime = &expirationTime
+		}
+	}
+	return terminationTime, nil
+}
+
 func (c *Controller) removeFinalizer(ctx context.Context, n *v1.Node) error {
 	stored := n.DeepCopy()
 	controllerutil.RemoveFinalizer(n, v1beta1.TerminationFinalizer)
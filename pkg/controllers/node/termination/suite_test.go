@@ -71,7 +71,7 @@ var _ = BeforeSuite(func() {
 	cloudProvider = fake.NewCloudProvider()
 	recorder = test.NewEventRecorder()
 	queue = terminator.NewQueue(env.Client, recorder)
-	terminationController = termination.NewController(env.Client, cloudProvider, terminator.NewTerminator(fakeClock, env.Client, queue), recorder)
+	terminationController = termination.NewController(fakeClock, env.Client, cloudProvider, terminator.NewTerminator(fakeClock, env.Client, queue, recorder), recorder)
 })
 
 var _ = AfterSuite(func() {
@@ -87,6 +87,7 @@ var _ = Describe("Termination", func() {
 		node.Labels[v1beta1.NodePoolLabelKey] = test.NodePool().Name
 		cloudProvider.CreatedNodeClaims[node.Spec.ProviderID] = nodeClaim
 		queue.Reset()
+		recorder.Reset()
 	})
 
 	AfterEach(func() {
@@ -583,6 +584,102 @@ var _ = Describe("Termination", func() {
 			ExpectReconcileSucceeded(ctx, terminationController, client.ObjectKeyFromObject(node))
 			ExpectNotFound(ctx, env.Client, node)
 		})
+		It("should delete pods blocked by a PDB to meet the node's termination grace period", func() {
+			nodeClaim.Spec.TerminationGracePeriod = &metav1.Duration{Duration: 5 * time.Minute}
+			minAvailable := intstr.FromInt(1)
+			labelSelector := map[string]string{test.RandomName(): test.RandomName()}
+			pdb := test.PodDisruptionBudget(test.PDBOptions{
+				Labels: labelSelector,
+				// Don't let any pod evict
+				MinAvailable: &minAvailable,
+			})
+			pod := test.Pod(test.PodOptions{
+				NodeName: node.Name,
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:          labelSelector,
+					OwnerReferences: defaultOwnerRefs,
+				},
+				Phase:                         v1.PodRunning,
+				TerminationGracePeriodSeconds: lo.ToPtr[int64](60),
+			})
+			fakeClock.SetTime(time.Now())
+			ExpectApplied(ctx, env.Client, node, nodeClaim, pod, pdb)
+
+			// Trigger Termination Controller
+			Expect(env.Client.Delete(ctx, node)).To(Succeed())
+			node = ExpectNodeExists(ctx, env.Client, node.Name)
+			ExpectReconcileSucceeded(ctx, terminationController, client.ObjectKeyFromObject(node))
+			ExpectReconcileSucceeded(ctx, queue, client.ObjectKey{})
+
+			// The pod still has more than its own grace period left before the node's grace period expires
+			fakeClock.SetTime(time.Now().Add(3 * time.Minute))
+			ExpectReconcileSucceeded(ctx, terminationController, client.ObjectKeyFromObject(node))
+			pod = ExpectPodExists(ctx, env.Client, pod.Name, pod.Namespace)
+			Expect(pod.DeletionTimestamp.IsZero()).To(BeTrue())
+			ExpectNodeWithNodeClaimDraining(env.Client, node.Name)
+
+			// Once the pod's grace period would outlast the node's, the pod is deleted despite the PDB
+			fakeClock.SetTime(time.Now().Add(4*time.Minute + 30*time.Second))
+			ExpectReconcileSucceeded(ctx, terminationController, client.ObjectKeyFromObject(node))
+			EventuallyExpectTerminating(ctx, env.Client, pod)
+			pod = ExpectPodExists(ctx, env.Client, pod.Name, pod.Namespace)
+			Expect(lo.FromPtr(pod.DeletionGracePeriodSeconds)).To(BeNumerically("<=", 30))
+			Expect(recorder.Calls("Deleted")).To(Equal(1))
+			ExpectNodeWithNodeClaimDraining(env.Client, node.Name)
+		})
+		It("should shorten the grace period of terminating pods to meet the node's termination grace period", func() {
+			nodeClaim.Spec.TerminationGracePeriod = &metav1.Duration{Duration: 5 * time.Minute}
+			pod := test.Pod(test.PodOptions{
+				NodeName:                      node.Name,
+				ObjectMeta:                    metav1.ObjectMeta{OwnerReferences: defaultOwnerRefs},
+				TerminationGracePeriodSeconds: lo.ToPtr[int64](3600),
+			})
+			fakeClock.SetTime(time.Now())
+			ExpectApplied(ctx, env.Client, node, nodeClaim, pod)
+
+			// Trigger Termination Controller
+			Expect(env.Client.Delete(ctx, node)).To(Succeed())
+			node = ExpectNodeExists(ctx, env.Client, node.Name)
+			ExpectReconcileSucceeded(ctx, terminationController, client.ObjectKeyFromObject(node))
+			ExpectReconcileSucceeded(ctx, queue, client.ObjectKey{})
+			EventuallyExpectTerminating(ctx, env.Client, pod)
+			pod = ExpectPodExists(ctx, env.Client, pod.Name, pod.Namespace)
+			Expect(lo.FromPtr(pod.DeletionGracePeriodSeconds)).To(BeNumerically("==", 3600))
+
+			// The evicted pod would outlast the node, so it's deleted again with the time that remains
+			fakeClock.SetTime(time.Now().Add(4 * time.Minute))
+			ExpectReconcileSucceeded(ctx, terminationController, client.ObjectKeyFromObject(node))
+			pod = ExpectPodExists(ctx, env.Client, pod.Name, pod.Namespace)
+			Expect(lo.FromPtr(pod.DeletionGracePeriodSeconds)).To(BeNumerically("<=", 60))
+			Expect(recorder.Calls("Deleted")).To(Equal(1))
+			ExpectNodeWithNodeClaimDraining(env.Client, node.Name)
+		})
+		It("should terminate the instance once the node's termination grace period has expired", func() {
+			nodeClaim.Spec.TerminationGracePeriod = &metav1.Duration{Duration: 5 * time.Minute}
+			pod := test.Pod(test.PodOptions{
+				NodeName:                      node.Name,
+				ObjectMeta:                    metav1.ObjectMeta{OwnerReferences: defaultOwnerRefs},
+				TerminationGracePeriodSeconds: lo.ToPtr[int64](3600),
+			})
+			fakeClock.SetTime(time.Now())
+			ExpectApplied(ctx, env.Client, node, nodeClaim, pod)
+
+			// Trigger Termination Controller
+			Expect(env.Client.Delete(ctx, node)).To(Succeed())
+			node = ExpectNodeExists(ctx, env.Client, node.Name)
+			ExpectReconcileSucceeded(ctx, terminationController, client.ObjectKeyFromObject(node))
+			ExpectReconcileSucceeded(ctx, queue, client.ObjectKey{})
+			EventuallyExpectTerminating(ctx, env.Client, pod)
+
+			// The pod is still within its own grace period, so the node keeps draining
+			fakeClock.SetTime(time.Now().Add(4 * time.Minute))
+			ExpectReconcileSucceeded(ctx, terminationController, client.ObjectKeyFromObject(node))
+			ExpectNodeWithNodeClaimDraining(env.Client, node.Name)
+
+			fakeClock.SetTime(time.Now().Add(5*time.Minute + 30*time.Second))
+			ExpectReconcileSucceeded(ctx, terminationController, client.ObjectKeyFromObject(node))
+			ExpectNotFound(ctx, env.Client, node)
+		})
 		It("should not evict a new pod with the same name using the old pod's eviction queue key", func() {
 			pod := test.Pod(test.PodOptions{
 				NodeName: node.Name,
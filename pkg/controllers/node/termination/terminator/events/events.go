@@ -18,6 +18,7 @@ package events
 
 import (
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 
@@ -43,3 +44,14 @@ func NodeFailedToDrain(node *v1.Node, err error) events.Event {
 		DedupeValues:   []string{node.Name},
 	}
 }
+
+func DeletePod(pod *v1.Pod, gracePeriodSeconds int64, nodeGracePeriodExpirationTime time.Time) events.Event {
+	return events.Event{
+		InvolvedObject: pod,
+		Type:           v1.EventTypeNormal,
+		Reason:         "Deleted",
+		Message: fmt.Sprintf("Deleted pod with a grace period of %ds to meet the node termination grace period expiring at %s",
+			gracePeriodSeconds, nodeGracePeriodExpirationTime.Format(time.RFC3339)),
+		DedupeValues: []string{pod.Name},
+	}
+}
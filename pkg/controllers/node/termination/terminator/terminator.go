@@ -19,6 +19,7 @@ package terminator
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/samber/lo"
 	v1 "k8s.io/api/core/v1"
@@ -30,6 +31,8 @@ import (
 	nodeutil "sigs.k8s.io/karpenter/pkg/utils/node"
 
 	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+	terminatorevents "sigs.k8s.io/karpenter/pkg/controllers/node/termination/terminator/events"
+	"sigs.k8s.io/karpenter/pkg/events"
 	podutil "sigs.k8s.io/karpenter/pkg/utils/pod"
 )
 
@@ -37,13 +40,15 @@ type Terminator struct {
 	clock         clock.Clock
 	kubeClient    client.Client
 	evictionQueue *Queue
+	recorder      events.Recorder
 }
 
-func NewTerminator(clk clock.Clock, kubeClient client.Client, eq *Queue) *Terminator {
+func NewTerminator(clk clock.Clock, kubeClient client.Client, eq *Queue, recorder events.Recorder) *Terminator {
 	return &Terminator{
 		clock:         clk,
 		kubeClient:    kubeClient,
 		evictionQueue: eq,
+		recorder:      recorder,
 	}
 }
 
@@ -77,13 +82,19 @@ func (t *Terminator) Taint(ctx context.Context, node *v1.Node) error {
 	return nil
 }
 
-// Drain evicts pods from the node and returns true when all pods are evicted
+// Drain evicts pods from the node and returns true when all pods are evicted. If the node has a grace period
+// expiration time, pods that would otherwise not finish terminating before it are deleted without eviction.
 // https://kubernetes.io/docs/concepts/architecture/nodes/#graceful-node-shutdown
-func (t *Terminator) Drain(ctx context.Context, node *v1.Node) error {
+func (t *Terminator) Drain(ctx context.Context, node *v1.Node, nodeGracePeriodExpirationTime *time.Time) error {
 	pods, err := nodeutil.GetPods(ctx, t.kubeClient, node)
 	if err != nil {
 		return fmt.Errorf("listing pods on node, %w", err)
 	}
+	if nodeGracePeriodExpirationTime != nil {
+		if err := t.DeleteExpiringPods(ctx, pods, *nodeGracePeriodExpirationTime); err != nil {
+			return fmt.Errorf("deleting expiring pods, %w", err)
+		}
+	}
 	// evictablePods are pods that aren't yet terminating are eligible to have the eviction API called against them
 	evictablePods := lo.Filter(pods, func(p *v1.Pod, _ int) bool { return podutil.IsEvictable(p) })
 	t.Evict(evictablePods)
@@ -97,6 +108,37 @@ func (t *Terminator) Drain(ctx context.Context, node *v1.Node) error {
 	return nil
 }
 
+// DeleteExpiringPods deletes any pods that would not finish their terminationGracePeriodSeconds before the node's
+// grace period expires. Each pod is deleted at T = nodeGracePeriodExpirationTime - terminationGracePeriodSeconds with
+// its grace period shortened to the time remaining, so that it is terminated before the instance is. Pods that are
+// already terminating are deleted again if they would outlast the node's grace period, which shortens theirs.
+func (t *Terminator) DeleteExpiringPods(ctx context.Context, pods []*v1.Pod, nodeGracePeriodExpirationTime time.Time) error {
+	for _, pod := range pods {
+		// Only pods that are holding up the drain need to be considered
+		if !podutil.IsWaitingEviction(pod, t.clock) {
+			continue
+		}
+		if podutil.IsTerminating(pod) {
+			// The pod's deletion timestamp is when its grace period ends
+			if !pod.DeletionTimestamp.Time.After(nodeGracePeriodExpirationTime) {
+				continue
+			}
+		} else {
+			podGracePeriod := time.Duration(lo.FromPtrOr(pod.Spec.TerminationGracePeriodSeconds, v1.DefaultTerminationGracePeriodSeconds)) * time.Second
+			if t.clock.Now().Before(nodeGracePeriodExpirationTime.Add(-podGracePeriod)) {
+				continue
+			}
+		}
+		remaining := lo.Max([]int64{int64(nodeGracePeriodExpirationTime.Sub(t.clock.Now()).Seconds()), 0})
+		if err := t.kubeClient.Delete(ctx, pod, &client.DeleteOptions{GracePeriodSeconds: lo.ToPtr(remaining)}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		logging.FromContext(ctx).With("pod", client.ObjectKeyFromObject(pod), "grace-period-seconds", remaining).Infof("deleted pod to meet node termination grace period")
+		t.recorder.Publish(terminatorevents.DeletePod(pod, remaining, nodeGracePeriodExpirationTime))
+	}
+	return nil
+}
+
 func (t *Terminator) Evict(pods []*v1.Pod) {
 	// 1. Prioritize noncritical pods, non-daemon pods https://kubernetes.io/docs/concepts/architecture/nodes/#graceful-node-shutdown
 	var criticalNonDaemon, criticalDaemon, nonCriticalNonDaemon, nonCriticalDaemon []*v1.Pod
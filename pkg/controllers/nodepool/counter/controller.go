@@ -18,23 +18,33 @@ package counter
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/samber/lo"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/apis"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+	"sigs.k8s.io/karpenter/pkg/cloudprovider"
 	"sigs.k8s.io/karpenter/pkg/controllers/state"
 	operatorcontroller "sigs.k8s.io/karpenter/pkg/operator/controller"
 	"sigs.k8s.io/karpenter/pkg/utils/functional"
 
 	v1 "k8s.io/api/core/v1"
 	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"sigs.k8s.io/karpenter/pkg/utils/resources"
@@ -42,17 +52,36 @@ import (
 
 var _ operatorcontroller.TypedController[*v1beta1.NodePool] = (*Controller)(nil)
 
+// instanceTypesCheckPeriod is how long the result of resolving a NodePool's instance types is used for before they're
+// resolved again. Resolving them can be expensive for the cloudprovider, and NodePools are reconciled on every event
+// of their NodeClaims and nodes.
+const instanceTypesCheckPeriod = 5 * time.Minute
+
+// instanceTypesCheck is when a generation of a NodePool last had its instance types resolved
+type instanceTypesCheck struct {
+	generation int64
+	checked    time.Time
+}
+
 // Controller for the resource
 type Controller struct {
-	kubeClient client.Client
-	cluster    *state.Cluster
+	clock         clock.Clock
+	kubeClient    client.Client
+	cluster       *state.Cluster
+	cloudProvider cloudprovider.CloudProvider
+
+	mu     sync.Mutex
+	checks map[string]instanceTypesCheck // (NodePool name) -> last time its instance types were resolved
 }
 
 // NewController is a constructor
-func NewController(kubeClient client.Client, cluster *state.Cluster) operatorcontroller.Controller {
+func NewController(clk clock.Clock, kubeClient client.Client, cluster *state.Cluster, cloudProvider cloudprovider.CloudProvider) operatorcontroller.Controller {
 	return operatorcontroller.Typed[*v1beta1.NodePool](kubeClient, &Controller{
-		kubeClient: kubeClient,
-		cluster:    cluster,
+		clock:         clk,
+		kubeClient:    kubeClient,
+		cluster:       cluster,
+		cloudProvider: cloudProvider,
+		checks:        map[string]instanceTypesCheck{},
 	})
 }
 
@@ -67,12 +96,129 @@ func (c *Controller) Reconcile(ctx context.Context, nodePool *v1beta1.NodePool)
 	stored := nodePool.DeepCopy()
 	// Determine resource usage and update nodepool.status.resources
 	nodePool.Status.Resources = c.resourceCountsFor(v1beta1.NodePoolLabelKey, nodePool.Name)
+	nodePool.Status.Nodes = c.nodeCountFor(v1beta1.NodePoolLabelKey, nodePool.Name)
+	nodeClaims, err := c.nodeClaimCountFor(ctx, nodePool.Name)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("counting nodeclaims, %w", err)
+	}
+	nodePool.Status.NodeClaims = nodeClaims
+	c.updateConditions(ctx, nodePool)
 	if !equality.Semantic.DeepEqual(stored, nodePool) {
 		if err := c.kubeClient.Status().Patch(ctx, nodePool, client.MergeFrom(stored)); err != nil {
 			return reconcile.Result{}, client.IgnoreNotFound(err)
 		}
 	}
-	return reconcile.Result{}, nil
+	// NodePools are reconciled as their NodeClaims and nodes change, but their NodeClass and instance types can change
+	// without any of those events, so we requeue them to resolve their instance types again once the check period passes
+	return reconcile.Result{RequeueAfter: instanceTypesCheckPeriod}, nil
+}
+
+// forget removes what we remember about a NodePool once it's deleted
+func (c *Controller) forget(nodePoolName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.checks, nodePoolName)
+}
+
+// shouldResolveInstanceTypes returns true if the NodePool's instance types haven't been resolved since its spec last
+// changed or within the check period, recording that they're being resolved now
+func (c *Controller) shouldResolveInstanceTypes(nodePool *v1beta1.NodePool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// the conditions may not have been written if we failed to patch the status after resolving the instance types
+	if check, ok := c.checks[nodePool.Name]; ok && check.generation == nodePool.Generation && c.clock.Since(check.checked) < instanceTypesCheckPeriod &&
+		nodePool.StatusConditions().GetCondition(v1beta1.InstanceTypesResolved) != nil {
+		return false
+	}
+	c.checks[nodePool.Name] = instanceTypesCheck{generation: nodePool.Generation, checked: c.clock.Now()}
+	return true
+}
+
+// updateConditions sets the conditions that describe whether the NodePool is able to launch capacity. These mirror
+// the checks that the provisioner performs when it builds the scheduler so that the reason a NodePool is being
+// skipped is visible on the NodePool itself
+func (c *Controller) updateConditions(ctx context.Context, nodePool *v1beta1.NodePool) {
+	if err := nodePool.RuntimeValidate(); err != nil {
+		nodePool.StatusConditions().MarkFalse(v1beta1.NodePoolValidationSucceeded, "ValidationFailed", err.Error())
+	} else {
+		nodePool.StatusConditions().MarkTrue(v1beta1.NodePoolValidationSucceeded)
+	}
+	if c.shouldResolveInstanceTypes(nodePool) {
+		c.updateInstanceTypeConditions(ctx, nodePool)
+	}
+	if len(nodePool.Spec.Paused) > 0 {
+		nodePool.StatusConditions().SetCondition(apis.Condition{
+			Type:     v1beta1.Paused,
+			Status:   v1.ConditionTrue,
+			Severity: apis.ConditionSeverityInfo,
+			Reason:   "Paused",
+			Message:  fmt.Sprintf("%s paused", strings.Join(lo.Map(nodePool.Spec.Paused, func(o v1beta1.PausedOperation, _ int) string { return string(o) }), ", ")),
+		})
+	} else {
+		nodePool.StatusConditions().SetCondition(apis.Condition{
+			Type:     v1beta1.Paused,
+			Status:   v1.ConditionFalse,
+			Severity: apis.ConditionSeverityInfo,
+		})
+	}
+	usage := resources.Merge(nodePool.Status.Resources, v1.ResourceList{v1beta1.ResourceNodes: *resource.NewQuantity(nodePool.Status.Nodes, resource.DecimalSI)})
+	if err := nodePool.Spec.Limits.ExceededBy(usage); err != nil {
+		nodePool.StatusConditions().SetCondition(apis.Condition{
+			Type:     v1beta1.LimitsExceeded,
+			Status:   v1.ConditionTrue,
+			Severity: apis.ConditionSeverityInfo,
+			Reason:   "LimitsExceeded",
+			Message:  err.Error(),
+		})
+	} else {
+		nodePool.StatusConditions().SetCondition(apis.Condition{
+			Type:     v1beta1.LimitsExceeded,
+			Status:   v1.ConditionFalse,
+			Severity: apis.ConditionSeverityInfo,
+		})
+	}
+}
+
+// updateInstanceTypeConditions sets the conditions that describe whether the cloudprovider can resolve the NodePool's
+// NodeClass and instance types
+func (c *Controller) updateInstanceTypeConditions(ctx context.Context, nodePool *v1beta1.NodePool) {
+	instanceTypes, err := c.cloudProvider.GetInstanceTypes(ctx, nodePool)
+	switch {
+	case cloudprovider.IsNodeClassNotReadyError(err):
+		nodePool.StatusConditions().MarkFalse(v1beta1.NodeClassReady, "NodeClassNotReady", err.Error())
+		nodePool.StatusConditions().MarkUnknown(v1beta1.InstanceTypesResolved, "NodeClassNotReady", "waiting on nodeclass readiness")
+	case err != nil:
+		nodePool.StatusConditions().MarkTrue(v1beta1.NodeClassReady)
+		nodePool.StatusConditions().MarkFalse(v1beta1.InstanceTypesResolved, "InstanceTypeResolutionFailed", err.Error())
+	case len(instanceTypes) == 0:
+		nodePool.StatusConditions().MarkTrue(v1beta1.NodeClassReady)
+		nodePool.StatusConditions().MarkFalse(v1beta1.InstanceTypesResolved, "NoInstanceTypes", "no instance types satisfy the nodepool requirements")
+	default:
+		nodePool.StatusConditions().MarkTrue(v1beta1.NodeClassReady)
+		nodePool.StatusConditions().MarkTrue(v1beta1.InstanceTypesResolved)
+	}
+}
+
+func (c *Controller) nodeCountFor(ownerLabel string, ownerName string) int64 {
+	var count int64
+	c.cluster.ForEachNode(func(n *state.StateNode) bool {
+		if n.MarkedForDeletion() {
+			return true
+		}
+		if n.Labels()[ownerLabel] == ownerName {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+func (c *Controller) nodeClaimCountFor(ctx context.Context, nodePoolName string) (int64, error) {
+	nodeClaimList := &v1beta1.NodeClaimList{}
+	if err := c.kubeClient.List(ctx, nodeClaimList, client.MatchingLabels{v1beta1.NodePoolLabelKey: nodePoolName}); err != nil {
+		return 0, err
+	}
+	return int64(lo.CountBy(nodeClaimList.Items, func(nc v1beta1.NodeClaim) bool { return nc.DeletionTimestamp.IsZero() })), nil
 }
 
 func (c *Controller) resourceCountsFor(ownerLabel string, ownerName string) v1.ResourceList {
@@ -101,7 +247,12 @@ func (c *Controller) Name() string {
 func (c *Controller) Builder(_ context.Context, m manager.Manager) operatorcontroller.Builder {
 	return operatorcontroller.Adapt(controllerruntime.
 		NewControllerManagedBy(m).
-		For(&v1beta1.NodePool{}).
+		For(&v1beta1.NodePool{}, builder.WithPredicates(predicate.Funcs{
+			DeleteFunc: func(e event.DeleteEvent) bool {
+				c.forget(e.Object.GetName())
+				return false
+			},
+		})).
 		Watches(
 			&v1beta1.NodeClaim{},
 			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []reconcile.Request {
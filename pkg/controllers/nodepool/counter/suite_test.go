@@ -18,11 +18,14 @@ package counter_test
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +37,7 @@ import (
 
 	"sigs.k8s.io/karpenter/pkg/apis"
 	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+	"sigs.k8s.io/karpenter/pkg/cloudprovider"
 	"sigs.k8s.io/karpenter/pkg/cloudprovider/fake"
 	"sigs.k8s.io/karpenter/pkg/controllers/nodepool/counter"
 	"sigs.k8s.io/karpenter/pkg/controllers/state"
@@ -68,7 +72,7 @@ var _ = BeforeSuite(func() {
 	nodeClaimController = informer.NewNodeClaimController(env.Client, cluster)
 	nodeController = informer.NewNodeController(env.Client, cluster)
 	nodePoolInformerController = informer.NewNodePoolController(env.Client, cluster)
-	nodePoolController = counter.NewController(env.Client, cluster)
+	nodePoolController = counter.NewController(fakeClock, env.Client, cluster, cloudProvider)
 })
 
 var _ = AfterSuite(func() {
@@ -116,6 +120,9 @@ var _ = Describe("Counter", func() {
 		ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
 		nodePool = ExpectExists(ctx, env.Client, nodePool)
 	})
+	AfterEach(func() {
+		cloudProvider.Reset()
+	})
 	It("should set the counter from the nodeClaim and then to the node when it initializes", func() {
 		ExpectApplied(ctx, env.Client, node, nodeClaim)
 		// Don't initialize the node yet
@@ -203,4 +210,133 @@ var _ = Describe("Counter", func() {
 		nodePool = ExpectExists(ctx, env.Client, nodePool)
 		Expect(nodePool.Status.Resources).To(BeNil())
 	})
+	It("should count the nodes and nodeClaims that belong to the nodePool", func() {
+		ExpectApplied(ctx, env.Client, node, nodeClaim, node2, nodeClaim2)
+		ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeController, nodeClaimController, []*v1.Node{node, node2}, []*v1beta1.NodeClaim{nodeClaim, nodeClaim2})
+
+		ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+		nodePool = ExpectExists(ctx, env.Client, nodePool)
+		Expect(nodePool.Status.Nodes).To(BeNumerically("==", 2))
+		Expect(nodePool.Status.NodeClaims).To(BeNumerically("==", 2))
+
+		ExpectDeleted(ctx, env.Client, node, nodeClaim)
+		ExpectReconcileSucceeded(ctx, nodeController, client.ObjectKeyFromObject(node))
+		ExpectReconcileSucceeded(ctx, nodeClaimController, client.ObjectKeyFromObject(nodeClaim))
+		ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+		nodePool = ExpectExists(ctx, env.Client, nodePool)
+		Expect(nodePool.Status.Nodes).To(BeNumerically("==", 1))
+		Expect(nodePool.Status.NodeClaims).To(BeNumerically("==", 1))
+	})
+	Context("Conditions", func() {
+		It("should mark the nodePool as ready when it can launch capacity", func() {
+			Expect(nodePool.StatusConditions().IsHappy()).To(BeTrue())
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.NodePoolValidationSucceeded).Status).To(Equal(v1.ConditionTrue))
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.NodeClassReady).Status).To(Equal(v1.ConditionTrue))
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.InstanceTypesResolved).Status).To(Equal(v1.ConditionTrue))
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.LimitsExceeded).Status).To(Equal(v1.ConditionFalse))
+		})
+		It("should mark NodeClassReady false when the nodeClass isn't ready", func() {
+			cloudProvider.ErrorsForNodePool[nodePool.Name] = cloudprovider.NewNodeClassNotReadyError(fmt.Errorf("nodeClass isn't ready"))
+			// the instance types were resolved when the nodePool was created, so they aren't resolved again until the check period passes
+			fakeClock.Step(5 * time.Minute)
+			ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+
+			Expect(nodePool.StatusConditions().IsHappy()).To(BeFalse())
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.NodeClassReady).Status).To(Equal(v1.ConditionFalse))
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.InstanceTypesResolved).Status).To(Equal(v1.ConditionUnknown))
+		})
+		It("should requeue the nodePool to resolve its instance types again", func() {
+			cloudProvider.ErrorsForNodePool[nodePool.Name] = cloudprovider.NewNodeClassNotReadyError(fmt.Errorf("nodeClass isn't ready"))
+			fakeClock.Step(5 * time.Minute)
+			result := ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+			Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+
+			delete(cloudProvider.ErrorsForNodePool, nodePool.Name)
+			fakeClock.Step(5 * time.Minute)
+			result = ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+			Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.NodeClassReady).Status).To(Equal(v1.ConditionTrue))
+		})
+		It("should only resolve instance types again once the check period passes or the nodePool changes", func() {
+			cloudProvider.ErrorsForNodePool[nodePool.Name] = fmt.Errorf("failed to resolve instance types")
+			ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.InstanceTypesResolved).Status).To(Equal(v1.ConditionTrue))
+
+			nodePool.Spec.Weight = lo.ToPtr[int32](10)
+			ExpectApplied(ctx, env.Client, nodePool)
+			ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.InstanceTypesResolved).Status).To(Equal(v1.ConditionFalse))
+		})
+		It("should mark InstanceTypesResolved false when the cloudprovider fails to return instance types", func() {
+			cloudProvider.ErrorsForNodePool[nodePool.Name] = fmt.Errorf("failed to resolve instance types")
+			// the instance types were resolved when the nodePool was created, so they aren't resolved again until the check period passes
+			fakeClock.Step(5 * time.Minute)
+			ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+
+			Expect(nodePool.StatusConditions().IsHappy()).To(BeFalse())
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.NodeClassReady).Status).To(Equal(v1.ConditionTrue))
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.InstanceTypesResolved).Status).To(Equal(v1.ConditionFalse))
+		})
+		It("should mark InstanceTypesResolved false when the cloudprovider returns no instance types", func() {
+			cloudProvider.InstanceTypesForNodePool[nodePool.Name] = []*cloudprovider.InstanceType{}
+			// the instance types were resolved when the nodePool was created, so they aren't resolved again until the check period passes
+			fakeClock.Step(5 * time.Minute)
+			ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+
+			Expect(nodePool.StatusConditions().IsHappy()).To(BeFalse())
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.InstanceTypesResolved).Status).To(Equal(v1.ConditionFalse))
+		})
+		It("should mark ValidationSucceeded false when the nodePool fails runtime validation", func() {
+			nodePool.Spec.Template.Spec.Taints = []v1.Taint{{Key: fmt.Sprintf("test.com.test/test-%s", strings.Repeat("a", 250)), Effect: v1.TaintEffectNoSchedule}}
+			ExpectApplied(ctx, env.Client, nodePool)
+			ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+
+			Expect(nodePool.StatusConditions().IsHappy()).To(BeFalse())
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.NodePoolValidationSucceeded).Status).To(Equal(v1.ConditionFalse))
+		})
+		It("should mark LimitsExceeded without affecting readiness", func() {
+			nodePool.Spec.Limits = v1beta1.Limits{v1.ResourceCPU: resource.MustParse("100m")}
+			ExpectApplied(ctx, env.Client, nodePool, node, nodeClaim, node2, nodeClaim2)
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeController, nodeClaimController, []*v1.Node{node, node2}, []*v1beta1.NodeClaim{nodeClaim, nodeClaim2})
+
+			ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+
+			Expect(nodePool.StatusConditions().IsHappy()).To(BeTrue())
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.LimitsExceeded).Status).To(Equal(v1.ConditionTrue))
+		})
+		It("should mark LimitsExceeded when the nodePool has more nodes than its node limit", func() {
+			nodePool.Spec.Limits = v1beta1.Limits{v1beta1.ResourceNodes: resource.MustParse("1")}
+			ExpectApplied(ctx, env.Client, nodePool, node, nodeClaim, node2, nodeClaim2)
+			ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeController, nodeClaimController, []*v1.Node{node, node2}, []*v1beta1.NodeClaim{nodeClaim, nodeClaim2})
+
+			ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.LimitsExceeded).Status).To(Equal(v1.ConditionTrue))
+		})
+		It("should mark Paused without affecting readiness", func() {
+			nodePool.Spec.Paused = []v1beta1.PausedOperation{v1beta1.PausedOperationProvisioning, v1beta1.PausedOperationDisruption}
+			ExpectApplied(ctx, env.Client, nodePool)
+			ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+
+			Expect(nodePool.StatusConditions().IsHappy()).To(BeTrue())
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.Paused).Status).To(Equal(v1.ConditionTrue))
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.Paused).Message).To(Equal("Provisioning, Disruption paused"))
+		})
+		It("should mark Paused false when no operations are paused", func() {
+			ExpectReconcileSucceeded(ctx, nodePoolController, client.ObjectKeyFromObject(nodePool))
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+
+			Expect(ExpectStatusConditionExists(nodePool, v1beta1.Paused).Status).To(Equal(v1.ConditionFalse))
+		})
+	})
 })
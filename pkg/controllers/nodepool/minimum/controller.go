@@ -0,0 +1,154 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minimum
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+	"sigs.k8s.io/karpenter/pkg/cloudprovider"
+	"sigs.k8s.io/karpenter/pkg/controllers/provisioning"
+	"sigs.k8s.io/karpenter/pkg/controllers/provisioning/scheduling"
+	"sigs.k8s.io/karpenter/pkg/controllers/state"
+	"sigs.k8s.io/karpenter/pkg/metrics"
+	operatorcontroller "sigs.k8s.io/karpenter/pkg/operator/controller"
+	"sigs.k8s.io/karpenter/pkg/utils/resources"
+)
+
+var _ operatorcontroller.TypedController[*v1beta1.NodePool] = (*Controller)(nil)
+
+// Controller launches NodeClaims for NodePools that are below their minimums. Since there are no pods that
+// drive these launches, the NodeClaims are created directly from the NodePool template.
+type Controller struct {
+	kubeClient    client.Client
+	cluster       *state.Cluster
+	cloudProvider cloudprovider.CloudProvider
+	provisioner   *provisioning.Provisioner
+}
+
+// NewController is a constructor
+func NewController(kubeClient client.Client, cluster *state.Cluster, cloudProvider cloudprovider.CloudProvider, provisioner *provisioning.Provisioner) operatorcontroller.Controller {
+	return operatorcontroller.Typed[*v1beta1.NodePool](kubeClient, &Controller{
+		kubeClient:    kubeClient,
+		cluster:       cluster,
+		cloudProvider: cloudProvider,
+		provisioner:   provisioner,
+	})
+}
+
+// Reconcile a control loop for the resource
+func (c *Controller) Reconcile(ctx context.Context, nodePool *v1beta1.NodePool) (reconcile.Result, error) {
+	if len(nodePool.Spec.Minimums) == 0 || !nodePool.DeletionTimestamp.IsZero() || nodePool.IsPaused(v1beta1.PausedOperationProvisioning) {
+		return reconcile.Result{}, nil
+	}
+	// We need to ensure that our internal cluster state mechanism is synced before we proceed
+	// Otherwise, we would launch capacity for nodes that already exist but haven't been seen yet
+	if !c.cluster.Synced(ctx) {
+		return reconcile.Result{RequeueAfter: time.Second}, nil
+	}
+	usage, launching := c.usageFor(nodePool.Name)
+	// NodeClaims that haven't launched yet don't report any capacity, so we wait for them to launch before
+	// deciding whether we need more capacity. Otherwise, we would overshoot the minimums.
+	if launching {
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+	unsatisfied := nodePool.Spec.Minimums.UnsatisfiedBy(usage)
+	if unsatisfied == nil {
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+	instanceTypes, err := c.cloudProvider.GetInstanceTypes(ctx, nodePool)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("getting instance types, %w", err)
+	}
+	nodeClaimTemplate := scheduling.NewNodeClaimTemplate(nodePool)
+	nodeClaimTemplate.InstanceTypeOptions = lo.Filter(instanceTypes, func(it *cloudprovider.InstanceType, _ int) bool {
+		return it.Requirements.Intersects(nodeClaimTemplate.Requirements) == nil &&
+			len(it.Offerings.Available().Compatible(nodeClaimTemplate.Requirements)) > 0
+	})
+	if len(nodeClaimTemplate.InstanceTypeOptions) == 0 {
+		return reconcile.Result{}, fmt.Errorf("no instance types satisfy the nodepool requirements")
+	}
+	// Like the scheduler, only launch instance types that fit under what's left of the limits, including the node limit
+	if nodePool.Spec.Limits != nil {
+		remaining := resources.Subtract(v1.ResourceList(nodePool.Spec.Limits), usage)
+		nodeClaimTemplate.InstanceTypeOptions = scheduling.FilterByRemainingResources(nodeClaimTemplate.InstanceTypeOptions, remaining)
+		if len(nodeClaimTemplate.InstanceTypeOptions) == 0 {
+			return reconcile.Result{}, fmt.Errorf("all available instance types exceed limits for nodepool: %q", nodePool.Name)
+		}
+	}
+	logging.FromContext(ctx).With("nodepool", nodePool.Name).Infof("launching nodeclaim to satisfy nodepool minimums, %s", unsatisfied)
+	if _, err = c.provisioner.Create(ctx, &scheduling.NodeClaim{NodeClaimTemplate: *nodeClaimTemplate}, provisioning.WithReason(metrics.MinimumReason)); err != nil {
+		return reconcile.Result{}, fmt.Errorf("creating nodeclaim, %w", err)
+	}
+	return reconcile.Result{Requeue: true}, nil
+}
+
+// usageFor returns the capacity of the nodes in the NodePool, including a count of the nodes for the "nodes" minimum.
+// It also returns whether any of those nodes belongs to a NodeClaim that hasn't launched yet.
+func (c *Controller) usageFor(nodePoolName string) (v1.ResourceList, bool) {
+	var count int64
+	var launching bool
+	usage := v1.ResourceList{}
+	c.cluster.ForEachNode(func(n *state.StateNode) bool {
+		// Nodes that we are planning to delete won't count towards the minimums for long
+		if n.MarkedForDeletion() || n.Labels()[v1beta1.NodePoolLabelKey] != nodePoolName {
+			return true
+		}
+		if len(n.Capacity()) == 0 {
+			launching = true
+		}
+		usage = resources.MergeInto(usage, n.Capacity())
+		count++
+		return true
+	})
+	usage[v1beta1.ResourceNodes] = *resource.NewQuantity(count, resource.DecimalSI)
+	return usage, launching
+}
+
+func (c *Controller) Name() string {
+	return "nodepool.minimum"
+}
+
+func (c *Controller) Builder(_ context.Context, m manager.Manager) operatorcontroller.Builder {
+	return operatorcontroller.Adapt(controllerruntime.
+		NewControllerManagedBy(m).
+		For(&v1beta1.NodePool{}).
+		Watches(
+			&v1beta1.NodeClaim{},
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []reconcile.Request {
+				if name, ok := o.GetLabels()[v1beta1.NodePoolLabelKey]; ok {
+					return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+				}
+				return nil
+			}),
+		).
+		WithOptions(controller.Options{MaxConcurrentReconciles: 10}))
+}
@@ -0,0 +1,220 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minimum_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clock "k8s.io/utils/clock/testing"
+	. "knative.dev/pkg/logging/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/karpenter/pkg/apis"
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+	"sigs.k8s.io/karpenter/pkg/cloudprovider"
+	"sigs.k8s.io/karpenter/pkg/cloudprovider/fake"
+	"sigs.k8s.io/karpenter/pkg/controllers/nodepool/minimum"
+	"sigs.k8s.io/karpenter/pkg/controllers/provisioning"
+	"sigs.k8s.io/karpenter/pkg/controllers/state"
+	"sigs.k8s.io/karpenter/pkg/controllers/state/informer"
+	"sigs.k8s.io/karpenter/pkg/events"
+	"sigs.k8s.io/karpenter/pkg/operator/controller"
+	"sigs.k8s.io/karpenter/pkg/operator/options"
+	"sigs.k8s.io/karpenter/pkg/operator/scheme"
+	"sigs.k8s.io/karpenter/pkg/test"
+	. "sigs.k8s.io/karpenter/pkg/test/expectations"
+)
+
+var ctx context.Context
+var env *test.Environment
+var cluster *state.Cluster
+var fakeClock *clock.FakeClock
+var cloudProvider *fake.CloudProvider
+var prov *provisioning.Provisioner
+var minimumController controller.Controller
+var nodeController controller.Controller
+var nodeClaimController controller.Controller
+
+func TestAPIs(t *testing.T) {
+	ctx = TestContextWithLogger(t)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Minimum")
+}
+
+var _ = BeforeSuite(func() {
+	env = test.NewEnvironment(scheme.Scheme, test.WithCRDs(apis.CRDs...))
+	ctx = options.ToContext(ctx, test.Options())
+	cloudProvider = fake.NewCloudProvider()
+	fakeClock = clock.NewFakeClock(time.Now())
+	cluster = state.NewCluster(fakeClock, env.Client, cloudProvider)
+	nodeController = informer.NewNodeController(env.Client, cluster)
+	nodeClaimController = informer.NewNodeClaimController(env.Client, cluster)
+	prov = provisioning.NewProvisioner(env.Client, events.NewRecorder(&record.FakeRecorder{}), cloudProvider, cluster)
+	minimumController = minimum.NewController(env.Client, cluster, cloudProvider, prov)
+})
+
+var _ = AfterSuite(func() {
+	Expect(env.Stop()).To(Succeed(), "Failed to stop environment")
+})
+
+var _ = AfterEach(func() {
+	ExpectCleanedUp(ctx, env.Client)
+	cloudProvider.Reset()
+	cluster.Reset()
+})
+
+var _ = Describe("Minimum", func() {
+	var nodePool *v1beta1.NodePool
+
+	BeforeEach(func() {
+		nodePool = test.NodePool()
+	})
+	It("should not launch nodeclaims when the nodepool has no minimums", func() {
+		ExpectApplied(ctx, env.Client, nodePool)
+		ExpectReconcileSucceeded(ctx, minimumController, client.ObjectKeyFromObject(nodePool))
+		Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(0))
+	})
+	It("should launch a nodeclaim when the nodepool is below its node minimum", func() {
+		nodePool.Spec.Minimums = v1beta1.Minimums{v1beta1.ResourceNodes: resource.MustParse("1")}
+		ExpectApplied(ctx, env.Client, nodePool)
+		ExpectReconcileSucceeded(ctx, minimumController, client.ObjectKeyFromObject(nodePool))
+
+		nodeClaims := ExpectNodeClaims(ctx, env.Client)
+		Expect(nodeClaims).To(HaveLen(1))
+		Expect(nodeClaims[0].Labels).To(HaveKeyWithValue(v1beta1.NodePoolLabelKey, nodePool.Name))
+		Expect(nodeClaims[0].Annotations).To(HaveKeyWithValue(v1beta1.NodePoolHashAnnotationKey, nodePool.Hash()))
+	})
+	It("should wait for launching nodeclaims before launching more", func() {
+		nodePool.Spec.Minimums = v1beta1.Minimums{v1beta1.ResourceNodes: resource.MustParse("3")}
+		ExpectApplied(ctx, env.Client, nodePool)
+		ExpectReconcileSucceeded(ctx, minimumController, client.ObjectKeyFromObject(nodePool))
+		ExpectReconcileSucceeded(ctx, minimumController, client.ObjectKeyFromObject(nodePool))
+		Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+	})
+	It("should not launch nodeclaims when the nodepool satisfies its minimums", func() {
+		nodePool.Spec.Minimums = v1beta1.Minimums{
+			v1beta1.ResourceNodes: resource.MustParse("1"),
+			v1.ResourceCPU:        resource.MustParse("2"),
+		}
+		nodeClaim, node := test.NodeClaimAndNode(v1beta1.NodeClaim{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1beta1.NodePoolLabelKey: nodePool.Name}},
+			Status: v1beta1.NodeClaimStatus{
+				Capacity: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")},
+			},
+		})
+		ExpectApplied(ctx, env.Client, nodePool, nodeClaim, node)
+		ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeController, nodeClaimController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+		ExpectReconcileSucceeded(ctx, minimumController, client.ObjectKeyFromObject(nodePool))
+		Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+	})
+	It("should launch a nodeclaim when the nodepool is below its resource minimum", func() {
+		nodePool.Spec.Minimums = v1beta1.Minimums{v1.ResourceCPU: resource.MustParse("8")}
+		nodeClaim, node := test.NodeClaimAndNode(v1beta1.NodeClaim{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1beta1.NodePoolLabelKey: nodePool.Name}},
+			Status: v1beta1.NodeClaimStatus{
+				Capacity: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")},
+			},
+		})
+		ExpectApplied(ctx, env.Client, nodePool, nodeClaim, node)
+		ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeController, nodeClaimController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+		ExpectReconcileSucceeded(ctx, minimumController, client.ObjectKeyFromObject(nodePool))
+		Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(2))
+	})
+	It("should not count nodes that are marked for deletion towards the minimums", func() {
+		nodePool.Spec.Minimums = v1beta1.Minimums{v1beta1.ResourceNodes: resource.MustParse("1")}
+		nodeClaim, node := test.NodeClaimAndNode(v1beta1.NodeClaim{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1beta1.NodePoolLabelKey: nodePool.Name}},
+			Status: v1beta1.NodeClaimStatus{
+				Capacity: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")},
+			},
+		})
+		ExpectApplied(ctx, env.Client, nodePool, nodeClaim, node)
+		ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeController, nodeClaimController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+		cluster.MarkForDeletion(nodeClaim.Status.ProviderID)
+
+		ExpectReconcileSucceeded(ctx, minimumController, client.ObjectKeyFromObject(nodePool))
+		Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(2))
+	})
+	It("should not launch nodeclaims when provisioning is paused for the nodepool", func() {
+		nodePool.Spec.Minimums = v1beta1.Minimums{v1beta1.ResourceNodes: resource.MustParse("1")}
+		nodePool.Spec.Paused = []v1beta1.PausedOperation{v1beta1.PausedOperationProvisioning}
+		ExpectApplied(ctx, env.Client, nodePool)
+		ExpectReconcileSucceeded(ctx, minimumController, client.ObjectKeyFromObject(nodePool))
+		Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(0))
+	})
+	It("should not launch nodeclaims past the nodepool limits", func() {
+		nodePool.Spec.Minimums = v1beta1.Minimums{v1.ResourceCPU: resource.MustParse("4")}
+		nodePool.Spec.Limits = v1beta1.Limits{v1beta1.ResourceNodes: resource.MustParse("0")}
+		ExpectApplied(ctx, env.Client, nodePool)
+		ExpectReconcileFailed(ctx, minimumController, client.ObjectKeyFromObject(nodePool))
+		Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(0))
+	})
+	It("should not launch nodeclaims past the node limit when the nodepool already has nodes", func() {
+		nodePool.Spec.Minimums = v1beta1.Minimums{v1.ResourceCPU: resource.MustParse("8")}
+		nodePool.Spec.Limits = v1beta1.Limits{v1beta1.ResourceNodes: resource.MustParse("1")}
+		nodeClaim, node := test.NodeClaimAndNode(v1beta1.NodeClaim{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1beta1.NodePoolLabelKey: nodePool.Name}},
+			Status: v1beta1.NodeClaimStatus{
+				Capacity: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")},
+			},
+		})
+		ExpectApplied(ctx, env.Client, nodePool, nodeClaim, node)
+		ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeController, nodeClaimController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+		ExpectReconcileFailed(ctx, minimumController, client.ObjectKeyFromObject(nodePool))
+		Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+	})
+	It("should only launch instance types that fit under the remaining resource limits", func() {
+		nodePool.Spec.Minimums = v1beta1.Minimums{v1.ResourceCPU: resource.MustParse("8")}
+		nodePool.Spec.Limits = v1beta1.Limits{v1.ResourceCPU: resource.MustParse("10")}
+		nodeClaim, node := test.NodeClaimAndNode(v1beta1.NodeClaim{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1beta1.NodePoolLabelKey: nodePool.Name}},
+			Status: v1beta1.NodeClaimStatus{
+				Capacity: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")},
+			},
+		})
+		ExpectApplied(ctx, env.Client, nodePool, nodeClaim, node)
+		ExpectMakeNodesAndNodeClaimsInitializedAndStateUpdated(ctx, env.Client, nodeController, nodeClaimController, []*v1.Node{node}, []*v1beta1.NodeClaim{nodeClaim})
+
+		ExpectReconcileSucceeded(ctx, minimumController, client.ObjectKeyFromObject(nodePool))
+		nodeClaims := lo.Reject(ExpectNodeClaims(ctx, env.Client), func(nc *v1beta1.NodeClaim, _ int) bool { return nc.Name == nodeClaim.Name })
+		Expect(nodeClaims).To(HaveLen(1))
+		instanceTypes := lo.SliceToMap(lo.Must(cloudProvider.GetInstanceTypes(ctx, nodePool)), func(it *cloudprovider.InstanceType) (string, *cloudprovider.InstanceType) {
+			return it.Name, it
+		})
+		requirement, ok := lo.Find(nodeClaims[0].Spec.Requirements, func(r v1beta1.NodeSelectorRequirementWithMinValues) bool {
+			return r.Key == v1.LabelInstanceTypeStable
+		})
+		Expect(ok).To(BeTrue())
+		Expect(requirement.Values).ToNot(BeEmpty())
+		// 6 cpus are left under the limit once the existing node's 4 are counted
+		for _, name := range requirement.Values {
+			Expect(instanceTypes[name].Capacity.Cpu().Cmp(resource.MustParse("6"))).To(BeNumerically("<=", 0))
+		}
+	})
+})
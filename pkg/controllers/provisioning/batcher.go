@@ -18,59 +18,259 @@ package provisioning
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"github.com/samber/lo"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
 	"sigs.k8s.io/karpenter/pkg/operator/options"
+	"sigs.k8s.io/karpenter/pkg/scheduling"
+)
+
+const (
+	// adaptiveSmallBatchSize is the number of pods that a batch can have for its idle duration to be shortened in
+	// adaptive mode
+	adaptiveSmallBatchSize = 5
+	// adaptiveBurstBatchSize is the number of pods that a batch needs for its durations to be lengthened in adaptive mode
+	adaptiveBurstBatchSize = 100
+	// adaptiveShrinkFactor is how much the idle duration of small batches is divided by in adaptive mode
+	adaptiveShrinkFactor = 4
+	// adaptiveGrowthFactor is how much the durations of burst batches are multiplied by in adaptive mode
+	adaptiveGrowthFactor = 2
 )
 
+// BatchWindow bounds how long a batch lasts
+type BatchWindow struct {
+	IdleDuration time.Duration
+	MaxDuration  time.Duration
+}
+
 // Batcher separates a stream of Trigger() calls into windowed slices. The
 // window is dynamic and will be extended if additional items are added up to a
-// maximum batch duration.
+// maximum batch duration. Pods that request different windows are batched separately,
+// so that a short window doesn't end the batches of pods that requested longer ones.
 type Batcher struct {
 	trigger chan struct{}
+
+	mu sync.Mutex
+	// batches are the open batches keyed by the window that their pods requested, where the zero window is the
+	// configured window
+	batches map[BatchWindow]*batch
+	// backlog is the number of pods that were pending when the last batch was scheduled
+	backlog int
+}
+
+// batch is the set of pods that requested the same window
+type batch struct {
+	pods  sets.Set[types.UID]
+	start time.Time
+	last  time.Time
 }
 
 // NewBatcher is a constructor for the Batcher
 func NewBatcher() *Batcher {
 	return &Batcher{
 		trigger: make(chan struct{}, 1),
+		batches: map[BatchWindow]*batch{},
 	}
 }
 
 // Trigger causes the batcher to start a batching window, or extend the current batching window if it hasn't reached the
 // maximum length.
 func (b *Batcher) Trigger() {
-	// The trigger is idempotently armed. This statement never blocks
-	select {
-	case b.trigger <- struct{}{}:
-	default:
+	b.mu.Lock()
+	b.add(BatchWindow{})
+	b.mu.Unlock()
+	b.arm()
+}
+
+// TriggerPod triggers the batcher for a pending pod. The pod joins the batch of the pods that requested the same
+// window, and a nil window requests the configured window.
+func (b *Batcher) TriggerPod(uid types.UID, window *BatchWindow) {
+	b.mu.Lock()
+	b.add(lo.FromPtr(window)).pods.Insert(uid)
+	b.mu.Unlock()
+	b.arm()
+}
+
+// Waiting returns true if the pod is in a batch whose window hasn't ended yet
+func (b *Batcher) Waiting(uid types.UID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, open := range b.batches {
+		if open.pods.Has(uid) {
+			return true
+		}
 	}
+	return false
+}
+
+// SetBacklog records the number of pods that are pending, so that adaptive batching scales with the pods that are
+// waiting for capacity rather than only with the pods that triggered the current batch.
+func (b *Batcher) SetBacklog(pending int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.backlog = pending
 }
 
 // Wait starts a batching window and continues waiting as long as it continues receiving triggers within
-// the idleDuration, up to the maxDuration
+// the idleDuration, up to the maxDuration. It returns once the window of any batch ends, and the batches whose
+// windows haven't ended stay open.
 func (b *Batcher) Wait(ctx context.Context) bool {
-	select {
-	case <-b.trigger:
-		// start the batching window after the first item is received
-	case <-time.After(1 * time.Second):
-		// If no pods, bail to the outer controller framework to refresh the context
-		return false
+	if !b.open() {
+		select {
+		case <-b.trigger:
+			// start the batching window after the first item is received
+		case <-time.After(1 * time.Second):
+			// If no pods, bail to the outer controller framework to refresh the context
+			return false
+		}
 	}
-	timeout := time.NewTimer(options.FromContext(ctx).BatchMaxDuration)
-	idle := time.NewTimer(options.FromContext(ctx).BatchIdleDuration)
 	for {
+		// the windows can change as pods are added to the batches
+		next, ok := b.nextDeadline(ctx)
+		if !ok {
+			// the trigger was left over from batches that already ended
+			return false
+		}
+		timeout := time.NewTimer(time.Until(next))
 		select {
 		case <-b.trigger:
-			// correct way to reset an active timer per docs
-			if !idle.Stop() {
-				<-idle.C
+			// correct way to stop an active timer per docs
+			if !timeout.Stop() {
+				<-timeout.C
 			}
-			idle.Reset(options.FromContext(ctx).BatchIdleDuration)
 		case <-timeout.C:
+			b.close(ctx)
 			return true
-		case <-idle.C:
-			return true
 		}
 	}
 }
+
+// add returns the batch of the window, starting it if it isn't open, and extends its idle duration
+func (b *Batcher) add(window BatchWindow) *batch {
+	now := time.Now()
+	open, ok := b.batches[window]
+	if !ok {
+		open = &batch{pods: sets.New[types.UID](), start: now}
+		b.batches[window] = open
+	}
+	open.last = now
+	return open
+}
+
+// arm idempotently arms the trigger. This statement never blocks
+func (b *Batcher) arm() {
+	select {
+	case b.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (b *Batcher) open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.batches) > 0
+}
+
+// nextDeadline returns the earliest time that the window of an open batch ends, or false if there are no open batches
+func (b *Batcher) nextDeadline(ctx context.Context) (time.Time, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var next time.Time
+	for window, open := range b.batches {
+		if deadline := b.deadline(ctx, window, open); next.IsZero() || deadline.Before(next) {
+			next = deadline
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// close ends the batches whose windows have ended
+func (b *Batcher) close(ctx context.Context) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	for window, open := range b.batches {
+		if !b.deadline(ctx, window, open).After(now) {
+			delete(b.batches, window)
+		}
+	}
+}
+
+// deadline returns the time that the batch's window ends, which is once its idle duration passes without a trigger or
+// once its maximum duration passes
+func (b *Batcher) deadline(ctx context.Context, window BatchWindow, open *batch) time.Time {
+	resolved := b.windowFor(ctx, window, open)
+	if idle := open.last.Add(resolved.IdleDuration); idle.Before(open.start.Add(resolved.MaxDuration)) {
+		return idle
+	}
+	return open.start.Add(resolved.MaxDuration)
+}
+
+// windowFor returns the window of a batch. Batches never last longer than the configured maximum, and the window is
+// scaled by the number of pending pods when batching is adaptive.
+func (b *Batcher) windowFor(ctx context.Context, requested BatchWindow, open *batch) BatchWindow {
+	window := BatchWindow{IdleDuration: options.FromContext(ctx).BatchIdleDuration, MaxDuration: options.FromContext(ctx).BatchMaxDuration}
+	if requested != (BatchWindow{}) {
+		window = clamp(requested, window.MaxDuration)
+	}
+	if !options.FromContext(ctx).BatchAdaptive {
+		return window
+	}
+	switch pending := max(open.pods.Len(), b.backlog); {
+	case pending <= adaptiveSmallBatchSize:
+		window.IdleDuration /= adaptiveShrinkFactor
+	case pending >= adaptiveBurstBatchSize:
+		window.IdleDuration *= adaptiveGrowthFactor
+		window.MaxDuration *= adaptiveGrowthFactor
+	}
+	return window
+}
+
+// clamp bounds the window by the maximum duration
+func clamp(window BatchWindow, maxDuration time.Duration) BatchWindow {
+	window.MaxDuration = min(window.MaxDuration, maxDuration)
+	window.IdleDuration = min(window.IdleDuration, window.MaxDuration)
+	return window
+}
+
+// BatchWindowFor returns the batching window that the pod requests. Pods request a window through their annotations or
+// through the NodePool that they would most likely schedule to, which is the highest weighted NodePool that they're
+// compatible with. Durations that aren't requested are the configured durations, and no window is longer than the
+// configured maximum duration.
+func BatchWindowFor(ctx context.Context, pod *v1.Pod, nodePoolList *v1beta1.NodePoolList) *BatchWindow {
+	batching := v1beta1.Batching{}
+	nodePoolList.OrderByWeight()
+	if nodePool, ok := lo.Find(nodePoolList.Items, func(np v1beta1.NodePool) bool {
+		return np.DeletionTimestamp.IsZero() && !np.IsPaused(v1beta1.PausedOperationProvisioning) && schedulesTo(pod, &np)
+	}); ok && nodePool.Spec.Batching != nil {
+		batching = *nodePool.Spec.Batching
+	}
+	if d, err := time.ParseDuration(pod.Annotations[v1beta1.BatchIdleDurationAnnotationKey]); err == nil && d > 0 {
+		batching.IdleDuration = &metav1.Duration{Duration: d}
+	}
+	if d, err := time.ParseDuration(pod.Annotations[v1beta1.BatchMaxDurationAnnotationKey]); err == nil && d > 0 {
+		batching.MaxDuration = &metav1.Duration{Duration: d}
+	}
+	window := clamp(BatchWindow{
+		IdleDuration: lo.FromPtrOr(batching.IdleDuration, metav1.Duration{Duration: options.FromContext(ctx).BatchIdleDuration}).Duration,
+		MaxDuration:  lo.FromPtrOr(batching.MaxDuration, metav1.Duration{Duration: options.FromContext(ctx).BatchMaxDuration}).Duration,
+	}, options.FromContext(ctx).BatchMaxDuration)
+	return &window
+}
+
+// schedulesTo returns true if the pod tolerates the NodePool's taints and is compatible with its requirements
+func schedulesTo(pod *v1.Pod, nodePool *v1beta1.NodePool) bool {
+	if err := scheduling.Taints(nodePool.Spec.Template.Spec.Taints).Tolerates(pod); err != nil {
+		return false
+	}
+	requirements := scheduling.NewNodeSelectorRequirementsWithMinValues(nodePool.Spec.Template.Spec.Requirements...)
+	requirements.Add(scheduling.NewLabelRequirements(nodePool.Spec.Template.Labels).Values()...)
+	return requirements.Compatible(scheduling.NewPodRequirements(pod), scheduling.AllowUndefinedWellKnownLabels) == nil
+}
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
 	"sigs.k8s.io/karpenter/pkg/events"
 	operatorcontroller "sigs.k8s.io/karpenter/pkg/operator/controller"
+	"sigs.k8s.io/karpenter/pkg/operator/options"
 	"sigs.k8s.io/karpenter/pkg/utils/pod"
 )
 
@@ -57,11 +58,11 @@ func (*PodController) Name() string {
 }
 
 // Reconcile the resource
-func (c *PodController) Reconcile(_ context.Context, p *v1.Pod) (reconcile.Result, error) {
-	if !pod.IsProvisionable(p) {
+func (c *PodController) Reconcile(ctx context.Context, p *v1.Pod) (reconcile.Result, error) {
+	if !pod.IsProvisionable(p) && !c.isPreProvisionable(ctx, p) {
 		return reconcile.Result{}, nil
 	}
-	c.provisioner.Trigger()
+	c.provisioner.TriggerPod(ctx, p)
 	// Continue to requeue until the pod is no longer provisionable. Pods may
 	// not be scheduled as expected if new pods are created while nodes are
 	// coming online. Even if a provisioning loop is successful, the pod may
@@ -69,6 +70,12 @@ func (c *PodController) Reconcile(_ context.Context, p *v1.Pod) (reconcile.Resul
 	return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
 }
 
+// isPreProvisionable returns true if the pod is held by scheduling gates that we provision capacity for ahead of time.
+// These pods are requeued like pending pods so that we emit an event for them once their capacity is ready.
+func (c *PodController) isPreProvisionable(ctx context.Context, p *v1.Pod) bool {
+	return options.FromContext(ctx).FeatureGates.PreProvisionGatedPods && pod.IsPreProvisionable(p, options.FromContext(ctx).PreProvisionSchedulingGates)
+}
+
 func (*PodController) Builder(_ context.Context, m manager.Manager) operatorcontroller.Builder {
 	return operatorcontroller.Adapt(controllerruntime.
 		NewControllerManagedBy(m).
@@ -122,3 +129,44 @@ func (*NodeController) Builder(_ context.Context, m manager.Manager) operatorcon
 		WithOptions(controller.Options{MaxConcurrentReconciles: 10}),
 	)
 }
+
+var _ operatorcontroller.TypedController[*v1beta1.NodePool] = (*NodePoolController)(nil)
+
+// NodePoolController for the resource
+type NodePoolController struct {
+	kubeClient  client.Client
+	provisioner *Provisioner
+	recorder    events.Recorder
+}
+
+// NewNodePoolController constructs a controller instance
+func NewNodePoolController(kubeClient client.Client, provisioner *Provisioner, recorder events.Recorder) operatorcontroller.Controller {
+	return operatorcontroller.Typed[*v1beta1.NodePool](kubeClient, &NodePoolController{
+		kubeClient:  kubeClient,
+		provisioner: provisioner,
+		recorder:    recorder,
+	})
+}
+
+func (*NodePoolController) Name() string {
+	return "provisioner.trigger.nodepool"
+}
+
+// Reconcile the resource
+func (c *NodePoolController) Reconcile(_ context.Context, np *v1beta1.NodePool) (reconcile.Result, error) {
+	if len(np.Spec.Headroom) == 0 {
+		return reconcile.Result{}, nil
+	}
+	c.provisioner.Trigger()
+	// Continue to requeue while the nodepool has headroom. Pods that schedule to the nodepool's nodes consume
+	// the headroom without ever being pending, so we need to periodically check whether we need more capacity.
+	return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+}
+
+func (*NodePoolController) Builder(_ context.Context, m manager.Manager) operatorcontroller.Builder {
+	return operatorcontroller.Adapt(controllerruntime.
+		NewControllerManagedBy(m).
+		For(&v1beta1.NodePool{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: 10}),
+	)
+}
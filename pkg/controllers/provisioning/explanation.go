@@ -0,0 +1,162 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/samber/lo"
+	"go.uber.org/multierr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+	scheduler "sigs.k8s.io/karpenter/pkg/controllers/provisioning/scheduling"
+	podutil "sigs.k8s.io/karpenter/pkg/utils/pod"
+)
+
+const (
+	// maxRejectedPodsPerReason is the number of pods that are listed for each reason in a NodePool's pod rejections
+	maxRejectedPodsPerReason = 5
+	// maxExplainedNodePools is the number of NodePool rejections that are listed in a pod's explanation annotation
+	maxExplainedNodePools = 10
+	// maxRejectionMessageLength is the length that the message of each NodePool rejection is truncated to in a pod's
+	// explanation annotation. Along with maxExplainedNodePools, it keeps the annotation well within the 256KiB that all of
+	// the pod's annotations are limited to.
+	maxRejectionMessageLength = 1024
+)
+
+// RecordExplanations surfaces why pods couldn't be scheduled. Each pending pod that couldn't be scheduled is annotated
+// with its explanation, which is removed once the pod can be scheduled, and every NodePool's status summarizes the
+// pods that it rejected.
+func (p *Provisioner) RecordExplanations(ctx context.Context, results scheduler.Results) error {
+	pods := map[*v1.Pod]*scheduler.Explanation{}
+	for _, n := range results.NewNodeClaims {
+		for _, po := range n.Pods {
+			pods[po] = nil
+		}
+	}
+	for _, n := range results.ExistingNodes {
+		for _, po := range n.Pods {
+			pods[po] = nil
+		}
+	}
+	for po, explanation := range results.PodExplanations {
+		pods[po] = explanation
+	}
+	// Headroom pods are virtual and pods that are already bound are being rescheduled from deleting nodes, so neither
+	// are pending pods that someone is waiting on
+	pods = lo.OmitBy(pods, func(po *v1.Pod, _ *scheduler.Explanation) bool {
+		return podutil.IsOwnedByNodePool(po) || podutil.IsScheduled(po)
+	})
+	podList := lo.Keys(pods)
+	errs := make([]error, len(podList))
+	workqueue.ParallelizeUntil(ctx, 10, len(podList), func(i int) {
+		errs[i] = p.annotateExplanation(ctx, podList[i], pods[podList[i]])
+	})
+	return multierr.Combine(append(errs, p.updatePodRejections(ctx, pods))...)
+}
+
+// annotateExplanation sets the pod's explanation annotation, removing it if the pod doesn't have an explanation
+func (p *Provisioner) annotateExplanation(ctx context.Context, pod *v1.Pod, explanation *scheduler.Explanation) error {
+	var value string
+	if explanation != nil {
+		raw, err := json.Marshal(truncateExplanation(explanation))
+		if err != nil {
+			return fmt.Errorf("marshaling explanation, %w", err)
+		}
+		value = string(raw)
+	}
+	current, ok := pod.Annotations[v1beta1.ProvisioningExplanationAnnotationKey]
+	if current == value && ok == (explanation != nil) {
+		return nil
+	}
+	stored := pod.DeepCopy()
+	if explanation == nil {
+		delete(pod.Annotations, v1beta1.ProvisioningExplanationAnnotationKey)
+	} else {
+		pod.Annotations = lo.Assign(pod.Annotations, map[string]string{v1beta1.ProvisioningExplanationAnnotationKey: value})
+	}
+	if err := p.kubeClient.Patch(ctx, pod, client.MergeFrom(stored)); err != nil {
+		return client.IgnoreNotFound(fmt.Errorf("patching pod %s, %w", client.ObjectKeyFromObject(pod), err))
+	}
+	return nil
+}
+
+// truncateExplanation bounds the size of the explanation by listing the rejections of the first NodePools and
+// truncating their messages
+func truncateExplanation(explanation *scheduler.Explanation) *scheduler.Explanation {
+	truncated := *explanation
+	truncated.NodePools = lo.Map(lo.Slice(explanation.NodePools, 0, maxExplainedNodePools), func(rejection scheduler.NodePoolRejection, _ int) scheduler.NodePoolRejection {
+		if len(rejection.Message) > maxRejectionMessageLength {
+			rejection.Message = rejection.Message[:maxRejectionMessageLength] + "..."
+		}
+		return rejection
+	})
+	truncated.OmittedNodePools = len(explanation.NodePools) - len(truncated.NodePools)
+	return &truncated
+}
+
+// updatePodRejections summarizes the pods that each NodePool rejected on its status
+func (p *Provisioner) updatePodRejections(ctx context.Context, pods map[*v1.Pod]*scheduler.Explanation) error {
+	// (NodePool name) -> (reason) -> rejected pods
+	rejected := map[string]map[scheduler.RejectionReason][]string{}
+	for po, explanation := range pods {
+		if explanation == nil {
+			continue
+		}
+		for _, rejection := range explanation.NodePools {
+			if _, ok := rejected[rejection.NodePool]; !ok {
+				rejected[rejection.NodePool] = map[scheduler.RejectionReason][]string{}
+			}
+			rejected[rejection.NodePool][rejection.Reason] = append(rejected[rejection.NodePool][rejection.Reason], client.ObjectKeyFromObject(po).String())
+		}
+	}
+	nodePoolList := &v1beta1.NodePoolList{}
+	if err := p.kubeClient.List(ctx, nodePoolList); err != nil {
+		return fmt.Errorf("listing nodepools, %w", err)
+	}
+	var errs error
+	for i := range nodePoolList.Items {
+		nodePool := &nodePoolList.Items[i]
+		var podRejections []v1beta1.PodRejection
+		for reason, podNames := range rejected[nodePool.Name] {
+			sort.Strings(podNames)
+			podRejections = append(podRejections, v1beta1.PodRejection{
+				Reason: string(reason),
+				Count:  int32(len(podNames)),
+				Pods:   lo.Slice(podNames, 0, maxRejectedPodsPerReason),
+			})
+		}
+		sort.Slice(podRejections, func(a, b int) bool { return podRejections[a].Reason < podRejections[b].Reason })
+		// pods are scheduled continuously, so only write the rejections when they change
+		if equality.Semantic.DeepEqual(nodePool.Status.PodRejections, podRejections) {
+			continue
+		}
+		stored := nodePool.DeepCopy()
+		nodePool.Status.PodRejections = podRejections
+		if err := p.kubeClient.Status().Patch(ctx, nodePool, client.MergeFrom(stored)); err != nil {
+			errs = multierr.Append(errs, client.IgnoreNotFound(fmt.Errorf("patching nodepool %s, %w", nodePool.Name, err)))
+		}
+	}
+	return errs
+}
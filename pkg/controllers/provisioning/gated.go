@@ -0,0 +1,54 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioning
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	v1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/karpenter/pkg/operator/options"
+	podutil "sigs.k8s.io/karpenter/pkg/utils/pod"
+)
+
+// GetGatedPods returns the pods that are held by scheduling gates that we provision capacity for ahead of time, so
+// that the capacity is ready by the time that the gates are removed. Nothing is returned unless the
+// PreProvisionGatedPods feature gate is enabled.
+func (p *Provisioner) GetGatedPods(ctx context.Context) ([]*v1.Pod, error) {
+	if !options.FromContext(ctx).FeatureGates.PreProvisionGatedPods {
+		return nil, nil
+	}
+	var podList v1.PodList
+	if err := p.kubeClient.List(ctx, &podList, client.MatchingFields{"spec.nodeName": ""}); err != nil {
+		return nil, fmt.Errorf("listing pods, %w", err)
+	}
+	gates := options.FromContext(ctx).PreProvisionSchedulingGates
+	return lo.FilterMap(podList.Items, func(po v1.Pod, _ int) (*v1.Pod, bool) {
+		if !podutil.IsPreProvisionable(&po, gates) {
+			return nil, false
+		}
+		if err := p.Validate(ctx, &po); err != nil {
+			logging.FromContext(ctx).With("pod", client.ObjectKeyFromObject(&po)).Debugf("ignoring gated pod, %s", err)
+			return nil, false
+		}
+		return &po, true
+	}), nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioning
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+)
+
+// GetHeadroomPods returns the virtual pods that represent the headroom of every NodePool that we can provision for
+func (p *Provisioner) GetHeadroomPods(ctx context.Context) ([]*v1.Pod, error) {
+	nodePoolList := &v1beta1.NodePoolList{}
+	if err := p.kubeClient.List(ctx, nodePoolList); err != nil {
+		return nil, fmt.Errorf("listing nodepools, %w", err)
+	}
+	var pods []*v1.Pod
+	for i := range nodePoolList.Items {
+		nodePool := &nodePoolList.Items[i]
+		if !nodePool.DeletionTimestamp.IsZero() || nodePool.IsPaused(v1beta1.PausedOperationProvisioning) {
+			continue
+		}
+		pods = append(pods, HeadroomPods(nodePool)...)
+	}
+	return pods, nil
+}
+
+// HeadroomPods builds the virtual pods for the NodePool's headroom. The pods are pinned to the NodePool and tolerate
+// its taints so that they can only be satisfied by its capacity. They're owned by the NodePool, which is how the
+// scheduler tells them apart from real pods, and they look unschedulable so that they're treated like pending pods.
+func HeadroomPods(nodePool *v1beta1.NodePool) []*v1.Pod {
+	tolerations := lo.Map(nodePool.Spec.Template.Spec.Taints, func(t v1.Taint, _ int) v1.Toleration {
+		return v1.Toleration{Key: t.Key, Operator: v1.TolerationOpEqual, Value: t.Value, Effect: t.Effect}
+	})
+	var pods []*v1.Pod
+	for i, headroom := range nodePool.Spec.Headroom {
+		for j := int32(0); j < headroom.Count; j++ {
+			name := fmt.Sprintf("%s-headroom-%d-%d", nodePool.Name, i, j)
+			pods = append(pods, &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					UID:  types.UID(fmt.Sprintf("%s-%d-%d", nodePool.UID, i, j)),
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: v1beta1.SchemeGroupVersion.String(),
+						Kind:       "NodePool",
+						Name:       nodePool.Name,
+						UID:        nodePool.UID,
+						Controller: lo.ToPtr(true),
+					}},
+				},
+				Spec: v1.PodSpec{
+					NodeSelector: lo.Assign(headroom.NodeSelector, map[string]string{v1beta1.NodePoolLabelKey: nodePool.Name}),
+					Tolerations:  tolerations,
+					Containers: []v1.Container{{
+						Name:      "headroom",
+						Resources: v1.ResourceRequirements{Requests: headroom.Requests},
+					}},
+				},
+				Status: v1.PodStatus{
+					Phase: v1.PodPending,
+					Conditions: []v1.PodCondition{{
+						Type:   v1.PodScheduled,
+						Status: v1.ConditionFalse,
+						Reason: v1.PodReasonUnschedulable,
+					}},
+				},
+			})
+		}
+	}
+	return pods
+}
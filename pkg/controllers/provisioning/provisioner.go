@@ -28,6 +28,7 @@ import (
 	"go.uber.org/multierr"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/workqueue"
@@ -37,6 +38,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	nodeutil "sigs.k8s.io/karpenter/pkg/utils/node"
+	podutil "sigs.k8s.io/karpenter/pkg/utils/pod"
 
 	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
 	"sigs.k8s.io/karpenter/pkg/operator/controller"
@@ -105,6 +107,15 @@ func (p *Provisioner) Trigger() {
 	p.batcher.Trigger()
 }
 
+// TriggerPod triggers provisioning for a pending pod in the batch of the pods that requested the same batching window
+func (p *Provisioner) TriggerPod(ctx context.Context, pod *v1.Pod) {
+	nodePoolList := &v1beta1.NodePoolList{}
+	if err := p.kubeClient.List(ctx, nodePoolList); err != nil {
+		logging.FromContext(ctx).Errorf("listing nodepools, %s", err)
+	}
+	p.batcher.TriggerPod(pod.UID, BatchWindowFor(ctx, pod, nodePoolList))
+}
+
 func (p *Provisioner) Builder(_ context.Context, mgr manager.Manager) controller.Builder {
 	return controller.NewSingletonManagedBy(mgr)
 }
@@ -127,10 +138,14 @@ func (p *Provisioner) Reconcile(ctx context.Context, _ reconcile.Request) (resul
 	if err != nil {
 		return reconcile.Result{}, err
 	}
-	if len(results.NewNodeClaims) == 0 {
-		return reconcile.Result{}, nil
+	if len(results.NewNodeClaims) > 0 {
+		_, err = p.CreateNodeClaims(ctx, results.NewNodeClaims, WithReason(metrics.ProvisioningReason), RecordPodNomination)
+	}
+	// explanations are informational, so we record them once the capacity is launched rather than delaying it, and we
+	// don't let failing to record them fail provisioning
+	if explainErr := p.RecordExplanations(ctx, results); explainErr != nil {
+		logging.FromContext(ctx).Errorf("recording scheduling explanations, %s", explainErr)
 	}
-	_, err = p.CreateNodeClaims(ctx, results.NewNodeClaims, WithReason(metrics.ProvisioningReason), RecordPodNomination)
 	return reconcile.Result{}, err
 }
 
@@ -208,6 +223,10 @@ func (p *Provisioner) NewScheduler(ctx context.Context, pods []*v1.Pod, stateNod
 			logging.FromContext(ctx).With("nodepool", n.Name).Errorf("nodepool failed validation, %s", err)
 			return false
 		}
+		if n.IsPaused(v1beta1.PausedOperationProvisioning) {
+			p.recorder.Publish(scheduler.NodePoolProvisioningPausedEvent(&n))
+			return false
+		}
 		return n.DeletionTimestamp.IsZero()
 	})
 	if len(nodePoolList.Items) == 0 {
@@ -241,7 +260,7 @@ func (p *Provisioner) NewScheduler(ctx context.Context, pods []*v1.Pod, stateNod
 		for _, instanceType := range instanceTypeOptions {
 			// We need to intersect the instance type requirements with the current nodePool requirements.  This
 			// ensures that something like zones from an instance type don't expand the universe of valid domains.
-			requirements := scheduling.NewNodeSelectorRequirements(nodePool.Spec.Template.Spec.Requirements...)
+			requirements := scheduling.NewNodeSelectorRequirementsWithMinValues(nodePool.Spec.Template.Spec.Requirements...)
 			requirements.Add(scheduling.NewLabelRequirements(nodePool.Spec.Template.Labels).Values()...)
 			requirements.Add(instanceType.Requirements.Values()...)
 
@@ -258,7 +277,7 @@ func (p *Provisioner) NewScheduler(ctx context.Context, pods []*v1.Pod, stateNod
 			}
 		}
 
-		requirements := scheduling.NewNodeSelectorRequirements(nodePool.Spec.Template.Spec.Requirements...)
+		requirements := scheduling.NewNodeSelectorRequirementsWithMinValues(nodePool.Spec.Template.Spec.Requirements...)
 		requirements.Add(scheduling.NewLabelRequirements(nodePool.Spec.Template.Labels).Values()...)
 		for key, requirement := range requirements {
 			if requirement.Operator() == v1.NodeSelectorOpIn {
@@ -306,6 +325,9 @@ func (p *Provisioner) Schedule(ctx context.Context) (scheduler.Results, error) {
 	if err != nil {
 		return scheduler.Results{}, err
 	}
+	p.batcher.SetBacklog(len(pendingPods))
+	// Pods whose batching window is still open are left for their own batch, so a short window doesn't cut it short
+	pendingPods = lo.Reject(pendingPods, func(pod *v1.Pod, _ int) bool { return p.batcher.Waiting(pod.UID) })
 	// Get pods from nodes that are preparing for deletion
 	// We do this after getting the pending pods so that we undershoot if pods are
 	// actively migrating from a node that is being deleted
@@ -314,7 +336,18 @@ func (p *Provisioner) Schedule(ctx context.Context) (scheduler.Results, error) {
 	if err != nil {
 		return scheduler.Results{}, err
 	}
-	pods := append(pendingPods, deletingNodePods...)
+	// Get the pods that are held by scheduling gates so that capacity is ready for them by the time they're released.
+	// They're scheduled after every pod that can schedule now, so they never take capacity away from them.
+	gatedPods, err := p.GetGatedPods(ctx)
+	if err != nil {
+		return scheduler.Results{}, err
+	}
+	// Get the virtual pods for NodePool headroom so that we keep spare capacity available for them
+	headroomPods, err := p.GetHeadroomPods(ctx)
+	if err != nil {
+		return scheduler.Results{}, err
+	}
+	pods := append(append(append(pendingPods, deletingNodePods...), gatedPods...), headroomPods...)
 	// nothing to schedule, so just return success
 	if len(pods) == 0 {
 		return scheduler.Results{}, nil
@@ -340,11 +373,18 @@ func (p *Provisioner) Create(ctx context.Context, n *scheduler.NodeClaim, opts .
 	if err := latest.Spec.Limits.ExceededBy(latest.Status.Resources); err != nil {
 		return "", err
 	}
+	// The nodeclaim we are about to create adds another node to the nodepool, so it has to fit under the node limit.
+	// The nodepool status only counts registered nodes, so we count the nodes and in-flight nodeclaims in cluster state.
+	if err := latest.Spec.Limits.ExceededBy(v1.ResourceList{v1beta1.ResourceNodes: *resource.NewQuantity(p.nodeCount(n.NodePoolName)+1, resource.DecimalSI)}); err != nil {
+		return "", err
+	}
 	nodeClaim := n.ToNodeClaim(latest)
 	if err := p.kubeClient.Create(ctx, nodeClaim); err != nil {
 		return "", err
 	}
-	instanceTypeRequirement, _ := lo.Find(nodeClaim.Spec.Requirements, func(req v1.NodeSelectorRequirement) bool { return req.Key == v1.LabelInstanceTypeStable })
+	instanceTypeRequirement, _ := lo.Find(nodeClaim.Spec.Requirements, func(req v1beta1.NodeSelectorRequirementWithMinValues) bool {
+		return req.Key == v1.LabelInstanceTypeStable
+	})
 	logging.FromContext(ctx).With("nodeclaim", nodeClaim.Name, "requests", nodeClaim.Spec.Resources.Requests, "instance-types", instanceTypeList(instanceTypeRequirement.Values)).Infof("created nodeclaim")
 	metrics.NodeClaimsCreatedCounter.With(prometheus.Labels{
 		metrics.ReasonLabel:   options.Reason,
@@ -357,13 +397,25 @@ func (p *Provisioner) Create(ctx context.Context, n *scheduler.NodeClaim, opts .
 	// internal cache to sync before moving onto another disruption loop.
 	p.cluster.UpdateNodeClaim(nodeClaim)
 	if functional.ResolveOptions(opts...).RecordPodNomination {
-		for _, pod := range n.Pods {
+		for _, pod := range lo.Reject(n.Pods, func(pod *v1.Pod, _ int) bool { return podutil.IsOwnedByNodePool(pod) }) {
 			p.recorder.Publish(scheduler.NominatePodEvent(pod, nil, nodeClaim))
 		}
 	}
 	return nodeClaim.Name, nil
 }
 
+// nodeCount returns the number of nodes and in-flight nodeclaims in the nodepool that aren't being deleted
+func (p *Provisioner) nodeCount(nodePoolName string) int64 {
+	var count int64
+	p.cluster.ForEachNode(func(n *state.StateNode) bool {
+		if !n.MarkedForDeletion() && n.Labels()[v1beta1.NodePoolLabelKey] == nodePoolName {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
 func instanceTypeList(names []string) string {
 	var itSb strings.Builder
 	for i, name := range names {
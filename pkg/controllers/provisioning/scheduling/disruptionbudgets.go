@@ -0,0 +1,93 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DisruptionBudgets tracks how many more pods each PodDisruptionBudget allows to be disrupted, so that we only model
+// the preemptions that the budgets allow. Preempting a pod uses up the budgets that select it for the rest of the
+// scheduling simulation.
+type DisruptionBudgets struct {
+	budgets []*disruptionBudget
+}
+
+type disruptionBudget struct {
+	namespace string
+	selector  labels.Selector
+	allowed   int32
+}
+
+func NewDisruptionBudgets(ctx context.Context, kubeClient client.Client) (*DisruptionBudgets, error) {
+	pdbList := &policyv1.PodDisruptionBudgetList{}
+	if err := kubeClient.List(ctx, pdbList); err != nil {
+		return nil, fmt.Errorf("listing pod disruption budgets, %w", err)
+	}
+	budgets := &DisruptionBudgets{}
+	for i := range pdbList.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdbList.Items[i].Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing selector of pod disruption budget %s, %w", client.ObjectKeyFromObject(&pdbList.Items[i]), err)
+		}
+		budgets.budgets = append(budgets.budgets, &disruptionBudget{
+			namespace: pdbList.Items[i].Namespace,
+			selector:  selector,
+			allowed:   pdbList.Items[i].Status.DisruptionsAllowed,
+		})
+	}
+	return budgets, nil
+}
+
+// Allows returns true if every budget that selects the pod allows another disruption
+func (d *DisruptionBudgets) Allows(pod *v1.Pod) bool {
+	return lo.EveryBy(d.selecting(pod), func(b *disruptionBudget) bool { return b.allowed > 0 })
+}
+
+// AllowsAll returns true if every budget allows all the pods that it selects to be disrupted together
+func (d *DisruptionBudgets) AllowsAll(pods ...*v1.Pod) bool {
+	disruptions := map[*disruptionBudget]int32{}
+	for _, pod := range pods {
+		for _, b := range d.selecting(pod) {
+			if disruptions[b]++; disruptions[b] > b.allowed {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Disrupt uses up a disruption of every budget that selects the pod
+func (d *DisruptionBudgets) Disrupt(pod *v1.Pod) {
+	for _, b := range d.selecting(pod) {
+		b.allowed--
+	}
+}
+
+func (d *DisruptionBudgets) selecting(pod *v1.Pod) []*disruptionBudget {
+	return lo.Filter(d.budgets, func(b *disruptionBudget, _ int) bool {
+		return b.namespace == pod.Namespace && b.selector.Matches(labels.Set(pod.Labels))
+	})
+}
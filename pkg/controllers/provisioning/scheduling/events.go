@@ -49,6 +49,24 @@ func NominatePodEvent(pod *v1.Pod, node *v1.Node, nodeClaim *v1beta1.NodeClaim)
 	}
 }
 
+func PodCapacityReadyEvent(pod *v1.Pod, node *v1.Node, nodeClaim *v1beta1.NodeClaim) events.Event {
+	var info []string
+	if nodeClaim != nil {
+		info = append(info, fmt.Sprintf("nodeclaim/%s", nodeClaim.GetName()))
+	}
+	if node != nil {
+		info = append(info, fmt.Sprintf("node/%s", node.Name))
+	}
+	return events.Event{
+		InvolvedObject: pod,
+		Type:           v1.EventTypeNormal,
+		Reason:         "CapacityReady",
+		Message:        fmt.Sprintf("Capacity is ready for gated pod on: %s", strings.Join(info, ", ")),
+		DedupeValues:   []string{string(pod.UID)},
+		DedupeTimeout:  5 * time.Minute,
+	}
+}
+
 func PodFailedToScheduleEvent(pod *v1.Pod, err error) events.Event {
 	return events.Event{
 		InvolvedObject: pod,
@@ -59,3 +77,14 @@ func PodFailedToScheduleEvent(pod *v1.Pod, err error) events.Event {
 		DedupeTimeout:  5 * time.Minute,
 	}
 }
+
+func NodePoolProvisioningPausedEvent(nodePool *v1beta1.NodePool) events.Event {
+	return events.Event{
+		InvolvedObject: nodePool,
+		Type:           v1.EventTypeNormal,
+		Reason:         "ProvisioningPaused",
+		Message:        "Provisioning is paused for the NodePool",
+		DedupeValues:   []string{string(nodePool.UID)},
+		DedupeTimeout:  5 * time.Minute,
+	}
+}
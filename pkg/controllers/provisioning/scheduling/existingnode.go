@@ -19,12 +19,17 @@ package scheduling
 import (
 	"context"
 	"fmt"
+	"sort"
 
+	"github.com/samber/lo"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/karpenter/pkg/controllers/state"
 	"sigs.k8s.io/karpenter/pkg/scheduling"
+	podutil "sigs.k8s.io/karpenter/pkg/utils/pod"
 	"sigs.k8s.io/karpenter/pkg/utils/resources"
 )
 
@@ -35,9 +40,16 @@ type ExistingNode struct {
 	topology     *Topology
 	requests     v1.ResourceList
 	requirements scheduling.Requirements
+	// preempted tracks the pods bound to the node that we've simulated being preempted and the resources they free up
+	preempted         sets.Set[types.UID]
+	preemptedRequests v1.ResourceList
+	boundPods         []*v1.Pod // lazily populated when we first need the pods that are bound to the node
+	// deviceCapacity is the number of devices that each DRA driver can allocate on the node
+	deviceCapacity map[string]int
+	deviceUsage    *scheduling.DeviceUsage // lazily populated when we first schedule a pod with resource claims
 }
 
-func NewExistingNode(n *state.StateNode, topology *Topology, daemonResources v1.ResourceList) *ExistingNode {
+func NewExistingNode(n *state.StateNode, topology *Topology, daemonResources v1.ResourceList, deviceCapacity map[string]int) *ExistingNode {
 	// The state node passed in here must be a deep copy from cluster state as we modify it
 	// the remaining daemonResources to schedule are the total daemonResources minus what has already scheduled
 	remainingDaemonResources := resources.Subtract(daemonResources, n.DaemonSetRequests())
@@ -51,17 +63,19 @@ func NewExistingNode(n *state.StateNode, topology *Topology, daemonResources v1.
 		}
 	}
 	node := &ExistingNode{
-		StateNode:    n,
-		topology:     topology,
-		requests:     remainingDaemonResources,
-		requirements: scheduling.NewLabelRequirements(n.Labels()),
+		StateNode:      n,
+		topology:       topology,
+		requests:       remainingDaemonResources,
+		requirements:   scheduling.NewLabelRequirements(n.Labels()),
+		preempted:      sets.New[types.UID](),
+		deviceCapacity: deviceCapacity,
 	}
 	node.requirements.Add(scheduling.NewRequirement(v1.LabelHostname, v1.NodeSelectorOpIn, n.HostName()))
 	topology.Register(v1.LabelHostname, n.HostName())
 	return node
 }
 
-func (n *ExistingNode) Add(ctx context.Context, kubeClient client.Client, pod *v1.Pod) error {
+func (n *ExistingNode) Add(ctx context.Context, kubeClient client.Client, pod *v1.Pod, devices *scheduling.DeviceRequests) error {
 	// Check Taints
 	if err := scheduling.Taints(n.Taints()).Tolerates(pod); err != nil {
 		return err
@@ -79,17 +93,27 @@ func (n *ExistingNode) Add(ctx context.Context, kubeClient client.Client, pod *v
 	if err = n.HostPortUsage().Conflicts(pod, hostPorts); err != nil {
 		return fmt.Errorf("checking host port usage, %w", err)
 	}
+	if len(devices.Claims) > 0 {
+		deviceUsage, err := n.getDeviceUsage(ctx, kubeClient)
+		if err != nil {
+			return err
+		}
+		if err = deviceUsage.ExceedsCapacity(devices.Claims); err != nil {
+			return fmt.Errorf("checking device usage, %w", err)
+		}
+	}
 
 	// check resource requests first since that's a pretty likely reason the pod won't schedule on an in-flight
 	// node, which at this point can't be increased in size
 	requests := resources.Merge(n.requests, resources.RequestsForPods(pod))
 
-	if !resources.Fits(requests, n.Available()) {
+	if !resources.Fits(requests, resources.Merge(n.Available(), n.preemptedRequests)) {
 		return fmt.Errorf("exceeds node resources")
 	}
 
 	nodeRequirements := scheduling.NewRequirements(n.requirements.Values()...)
 	podRequirements := scheduling.NewPodRequirements(pod)
+	podRequirements.Add(devices.Requirements.Values()...)
 	// Check NodeClaim Affinity Requirements
 	if err = nodeRequirements.Compatible(podRequirements); err != nil {
 		return err
@@ -101,10 +125,11 @@ func (n *ExistingNode) Add(ctx context.Context, kubeClient client.Client, pod *v
 		// strictPodRequirements is important as it ensures we don't inadvertently restrict the possible pod domains by a
 		// preferred node affinity.  Only required node affinities can actually reduce pod domains.
 		strictPodRequirements = scheduling.NewStrictPodRequirements(pod)
+		strictPodRequirements.Add(devices.Requirements.Values()...)
 	}
 
 	// Check Topology Requirements
-	topologyRequirements, err := n.topology.AddRequirements(strictPodRequirements, nodeRequirements, pod)
+	topologyRequirements, err := n.topology.AddRequirements(strictPodRequirements, nodeRequirements, pod, n.Taints())
 	if err != nil {
 		return err
 	}
@@ -117,8 +142,191 @@ func (n *ExistingNode) Add(ctx context.Context, kubeClient client.Client, pod *v
 	n.Pods = append(n.Pods, pod)
 	n.requests = requests
 	n.requirements = nodeRequirements
-	n.topology.Record(pod, nodeRequirements)
+	n.topology.Record(pod, n.Taints(), nodeRequirements)
 	n.HostPortUsage().Add(pod, hostPorts)
 	n.VolumeUsage().Add(pod, volumes)
+	if n.deviceUsage != nil {
+		n.deviceUsage.Add(devices.Claims)
+	}
 	return nil
 }
+
+// Preempt attempts to add the pod to the node by evicting lower priority pods that are bound to it, returning the
+// pods that would be evicted so that they can be rescheduled. Like kube-scheduler, we start by removing every lower
+// priority pod that the disruption budgets allow us to evict and then reprieve as many of them as still fit, highest
+// priority first. If the budgets don't allow every pod that's left to be evicted together, the pod can't preempt.
+func (n *ExistingNode) Preempt(ctx context.Context, kubeClient client.Client, pod *v1.Pod, devices *scheduling.DeviceRequests, budgets *DisruptionBudgets) ([]*v1.Pod, error) {
+	if !podutil.CanPreempt(pod) {
+		return nil, fmt.Errorf("pod can't preempt")
+	}
+	// gated pods aren't released yet, so we don't evict other pods to make room for them
+	if podutil.IsGated(pod) {
+		return nil, fmt.Errorf("pod is held by scheduling gates")
+	}
+	boundPods, err := n.getBoundPods(ctx, kubeClient)
+	if err != nil {
+		return nil, err
+	}
+	candidates := lo.Filter(boundPods, func(p *v1.Pod, _ int) bool {
+		return podutil.IsReschedulable(p) && !podutil.IsTerminating(p) && !n.preempted.Has(p.UID) &&
+			podutil.Priority(p) < podutil.Priority(pod) && budgets.Allows(p)
+	})
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no lower priority pods to preempt")
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return podutil.Priority(candidates[i]) > podutil.Priority(candidates[j]) })
+
+	usages, err := n.getUsages(ctx, kubeClient, candidates)
+	if err != nil {
+		return nil, err
+	}
+	volumes, err := scheduling.GetVolumes(ctx, kubeClient, pod)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range usages {
+		n.release(u)
+	}
+	// Reprieve the pods that still fit next to the pod before we add it, so that we only commit to the preemption
+	// once we know that the budgets allow it. The pod's requests are held separately from the preempted requests,
+	// since it may request resources that none of the preempted pods do.
+	requests := n.requests
+	preemptor := podUsage{pod: pod, hostPorts: scheduling.GetHostPorts(pod), volumes: volumes, devices: devices.Claims}
+	n.requests = resources.Merge(requests, resources.RequestsForPods(pod))
+	n.reserve(preemptor)
+	var victims []podUsage
+	for _, u := range usages {
+		if n.fits(u) {
+			n.reserve(u)
+			continue
+		}
+		victims = append(victims, u)
+	}
+	n.release(preemptor)
+	n.requests = requests
+	victimPods := lo.Map(victims, func(u podUsage, _ int) *v1.Pod { return u.pod })
+	if !budgets.AllowsAll(victimPods...) {
+		for _, u := range victims {
+			n.reserve(u)
+		}
+		return nil, fmt.Errorf("disruption budgets don't allow the pods to be preempted")
+	}
+	if err = n.Add(ctx, kubeClient, pod, devices); err != nil {
+		for _, u := range victims {
+			n.reserve(u)
+		}
+		return nil, err
+	}
+	for _, u := range victims {
+		n.preempted.Insert(u.pod.UID)
+		budgets.Disrupt(u.pod)
+		// the victim no longer counts towards the topology of the node once it's evicted
+		requirements := scheduling.NewLabelRequirements(n.Labels())
+		requirements.Add(scheduling.NewRequirement(v1.LabelHostname, v1.NodeSelectorOpIn, n.HostName()))
+		n.topology.Unrecord(u.pod, n.Taints(), requirements)
+	}
+	return victimPods, nil
+}
+
+// podUsage is what a pod that's bound to the node uses of the node
+type podUsage struct {
+	pod       *v1.Pod
+	requests  v1.ResourceList
+	hostPorts []scheduling.HostPort
+	volumes   scheduling.Volumes
+	devices   scheduling.DeviceClaims
+}
+
+func (n *ExistingNode) getUsages(ctx context.Context, kubeClient client.Client, pods []*v1.Pod) ([]podUsage, error) {
+	var usages []podUsage
+	for _, p := range pods {
+		volumes, err := scheduling.GetVolumes(ctx, kubeClient, p)
+		if err != nil {
+			return nil, err
+		}
+		devices, err := scheduling.GetDeviceRequests(ctx, kubeClient, p)
+		if err != nil {
+			return nil, err
+		}
+		// make sure that the devices that the pod was allocated are tracked before we release them
+		if len(devices.Claims) > 0 {
+			if _, err = n.getDeviceUsage(ctx, kubeClient); err != nil {
+				return nil, err
+			}
+		}
+		usages = append(usages, podUsage{
+			pod:       p,
+			requests:  resources.RequestsForPods(p),
+			hostPorts: scheduling.GetHostPorts(p),
+			volumes:   volumes,
+			devices:   devices.Claims,
+		})
+	}
+	return usages, nil
+}
+
+// release frees up what the pod uses of the node
+func (n *ExistingNode) release(u podUsage) {
+	n.preemptedRequests = resources.Merge(n.preemptedRequests, u.requests)
+	n.HostPortUsage().DeletePod(client.ObjectKeyFromObject(u.pod))
+	n.VolumeUsage().DeletePod(client.ObjectKeyFromObject(u.pod))
+	if n.deviceUsage != nil {
+		n.deviceUsage.Remove(u.devices)
+	}
+}
+
+// reserve takes back what the pod uses of the node after it was released
+func (n *ExistingNode) reserve(u podUsage) {
+	n.preemptedRequests = resources.Subtract(n.preemptedRequests, u.requests)
+	n.HostPortUsage().Add(u.pod, u.hostPorts)
+	n.VolumeUsage().Add(u.pod, u.volumes)
+	if n.deviceUsage != nil {
+		n.deviceUsage.Add(u.devices)
+	}
+}
+
+// fits returns true if the node has room for the pod to keep using what it uses of the node
+func (n *ExistingNode) fits(u podUsage) bool {
+	if !resources.Fits(n.requests, resources.Merge(n.Available(), resources.Subtract(n.preemptedRequests, u.requests))) {
+		return false
+	}
+	if n.HostPortUsage().Conflicts(u.pod, u.hostPorts) != nil || n.VolumeUsage().ExceedsLimits(u.volumes) != nil {
+		return false
+	}
+	return n.deviceUsage == nil || n.deviceUsage.ExceedsCapacity(u.devices) == nil
+}
+
+func (n *ExistingNode) getBoundPods(ctx context.Context, kubeClient client.Client) ([]*v1.Pod, error) {
+	if n.boundPods == nil {
+		pods, err := n.StateNode.Pods(ctx, kubeClient)
+		if err != nil {
+			return nil, fmt.Errorf("listing pods on node, %w", err)
+		}
+		n.boundPods = lo.Ternary(pods == nil, []*v1.Pod{}, pods)
+	}
+	return n.boundPods, nil
+}
+
+// getDeviceUsage returns the devices that are allocated to the resource claims of the pods that are bound to the node
+func (n *ExistingNode) getDeviceUsage(ctx context.Context, kubeClient client.Client) (*scheduling.DeviceUsage, error) {
+	if n.deviceUsage != nil {
+		return n.deviceUsage, nil
+	}
+	boundPods, err := n.getBoundPods(ctx, kubeClient)
+	if err != nil {
+		return nil, err
+	}
+	deviceUsage := scheduling.NewDeviceUsage(n.deviceCapacity)
+	for _, p := range boundPods {
+		if podutil.IsTerminal(p) || n.preempted.Has(p.UID) {
+			continue
+		}
+		devices, err := scheduling.GetDeviceRequests(ctx, kubeClient, p)
+		if err != nil {
+			return nil, fmt.Errorf("tracking device usage, %w", err)
+		}
+		deviceUsage.Add(devices.Claims)
+	}
+	n.deviceUsage = deviceUsage
+	return deviceUsage, nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/samber/lo"
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/karpenter/pkg/cloudprovider"
+	"sigs.k8s.io/karpenter/pkg/scheduling"
+)
+
+// maxClosestInstanceTypes is the number of instance types that are reported as being closest to fitting a pod
+const maxClosestInstanceTypes = 3
+
+// RejectionReason is why a NodePool couldn't launch capacity for a pod
+type RejectionReason string
+
+const (
+	RejectionReasonTaints                RejectionReason = "Taints"
+	RejectionReasonHostPorts             RejectionReason = "HostPorts"
+	RejectionReasonRequirements          RejectionReason = "Requirements"
+	RejectionReasonTopology              RejectionReason = "Topology"
+	RejectionReasonDevices               RejectionReason = "Devices"
+	RejectionReasonLimits                RejectionReason = "Limits"
+	RejectionReasonInsufficientResources RejectionReason = "InsufficientResources"
+	RejectionReasonDaemonOverhead        RejectionReason = "DaemonOverhead"
+	RejectionReasonNoOfferings           RejectionReason = "NoOfferings"
+	RejectionReasonMinValues             RejectionReason = "MinValues"
+	RejectionReasonNoInstanceType        RejectionReason = "NoInstanceType"
+	RejectionReasonUnknown               RejectionReason = "Unknown"
+)
+
+// Explanation describes why a pod couldn't be scheduled
+type Explanation struct {
+	// NodePools are the NodePools that couldn't launch capacity for the pod, ordered by weight
+	NodePools []NodePoolRejection `json:"nodePools,omitempty"`
+	// OmittedNodePools is the number of NodePools that couldn't launch capacity for the pod but were left out of
+	// NodePools to bound the size of the explanation
+	OmittedNodePools int `json:"omittedNodePools,omitempty"`
+	// Relaxations are the preferences that were relaxed while trying to schedule the pod
+	Relaxations []string `json:"relaxations,omitempty"`
+}
+
+// NodePoolRejection describes why a NodePool couldn't launch capacity for a pod
+type NodePoolRejection struct {
+	NodePool string          `json:"nodePool"`
+	Reason   RejectionReason `json:"reason"`
+	Message  string          `json:"message"`
+	// ClosestInstanceTypes are the instance types that came closest to fitting the pod when it was rejected because
+	// none of the instance types had enough resources
+	ClosestInstanceTypes []string `json:"closestInstanceTypes,omitempty"`
+
+	closest func() []string
+}
+
+// rejectionError is an error that was returned when adding a pod to a NodeClaim, along with the reason that it
+// explains. It keeps the message of the error that it wraps.
+type rejectionError struct {
+	error
+	reason RejectionReason
+	// closest returns the instance types that came closest to fitting the pod. It's evaluated lazily since most
+	// rejections are never explained.
+	closest func() []string
+}
+
+func (e rejectionError) Unwrap() error {
+	return e.error
+}
+
+func newRejection(reason RejectionReason, err error) rejectionError {
+	return rejectionError{error: err, reason: reason}
+}
+
+// newNodePoolRejection explains the error that the NodePool's NodeClaim template returned for a pod
+func newNodePoolRejection(nodePoolName string, err error) NodePoolRejection {
+	rejection := NodePoolRejection{NodePool: nodePoolName, Reason: RejectionReasonUnknown, Message: err.Error()}
+	if r := (rejectionError{}); errors.As(err, &r) {
+		rejection.Reason = r.reason
+		rejection.closest = r.closest
+	}
+	return rejection
+}
+
+// finalize evaluates the parts of the explanation that are only worth computing for pods that failed to schedule
+func (e *Explanation) finalize() {
+	for i := range e.NodePools {
+		if e.NodePools[i].closest != nil {
+			e.NodePools[i].ClosestInstanceTypes = e.NodePools[i].closest()
+			e.NodePools[i].closest = nil
+		}
+	}
+}
+
+// Reason returns the reason that explains why all instance types were filtered out
+func (r filterResults) Reason() RejectionReason {
+	switch {
+	case !r.requirementsMet:
+		return RejectionReasonRequirements
+	case !r.fits:
+		return RejectionReasonInsufficientResources
+	case !r.hasOffering:
+		return RejectionReasonNoOfferings
+	default:
+		return RejectionReasonNoInstanceType
+	}
+}
+
+// closestInstanceTypes returns the names of the instance types that would need the least more resources to fit the
+// requests. Only the instance types that meet the requirements and have a compatible offering are considered.
+func closestInstanceTypes(instanceTypes []*cloudprovider.InstanceType, requirements scheduling.Requirements, requests v1.ResourceList) []string {
+	type candidate struct {
+		name  string
+		ratio float64
+	}
+	var candidates []candidate
+	for _, it := range instanceTypes {
+		if !compatible(it, requirements) || !hasOffering(it, requirements) {
+			continue
+		}
+		candidates = append(candidates, candidate{name: it.Name, ratio: requestRatio(requests, it.Allocatable())})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].ratio != candidates[j].ratio {
+			return candidates[i].ratio < candidates[j].ratio
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	return lo.Map(lo.Slice(candidates, 0, maxClosestInstanceTypes), func(c candidate, _ int) string { return c.name })
+}
+
+// requestRatio is the largest fraction of any allocatable resource that the requests need
+func requestRatio(requests, allocatable v1.ResourceList) float64 {
+	var ratio float64
+	for name, request := range requests {
+		if request.IsZero() {
+			continue
+		}
+		capacity := allocatable[name]
+		if capacity.IsZero() {
+			return math.Inf(1)
+		}
+		ratio = math.Max(ratio, request.AsApproximateFloat64()/capacity.AsApproximateFloat64())
+	}
+	return ratio
+}
@@ -19,10 +19,12 @@ package scheduling_test
 import (
 	"fmt"
 	"math/rand"
+	"strings"
 
 	"github.com/mitchellh/hashstructure/v2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -49,17 +51,17 @@ var _ = Describe("Instance Type Selection", func() {
 			Spec: v1beta1.NodePoolSpec{
 				Template: v1beta1.NodeClaimTemplate{
 					Spec: v1beta1.NodeClaimSpec{
-						Requirements: []v1.NodeSelectorRequirement{
-							{
+						Requirements: []v1beta1.NodeSelectorRequirementWithMinValues{
+							{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 								Key:      v1beta1.CapacityTypeLabelKey,
 								Operator: v1.NodeSelectorOpIn,
 								Values:   []string{v1beta1.CapacityTypeSpot, v1beta1.CapacityTypeOnDemand},
-							},
-							{
+							}},
+							{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 								Key:      v1.LabelArchStable,
 								Operator: v1.NodeSelectorOpIn,
 								Values:   []string{v1beta1.ArchitectureArm64, v1beta1.ArchitectureAmd64},
-							},
+							}},
 						},
 					},
 				},
@@ -114,12 +116,12 @@ var _ = Describe("Instance Type Selection", func() {
 		ExpectInstancesWithLabel(supportedInstanceTypes(cloudProvider.CreateCalls[0]), v1.LabelArchStable, v1beta1.ArchitectureArm64)
 	})
 	It("should schedule on one of the cheapest instances (prov arch = amd64)", func() {
-		nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-			{
+		nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelArchStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.ArchitectureAmd64},
-			},
+			}},
 		}
 		ExpectApplied(ctx, env.Client, nodePool)
 		pod := test.UnschedulablePod()
@@ -129,12 +131,12 @@ var _ = Describe("Instance Type Selection", func() {
 		ExpectInstancesWithLabel(supportedInstanceTypes(cloudProvider.CreateCalls[0]), v1.LabelArchStable, v1beta1.ArchitectureAmd64)
 	})
 	It("should schedule on one of the cheapest instances (prov arch = arm64)", func() {
-		nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-			{
+		nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelArchStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.ArchitectureArm64},
-			},
+			}},
 		}
 		ExpectApplied(ctx, env.Client, nodePool)
 		pod := test.UnschedulablePod()
@@ -144,12 +146,12 @@ var _ = Describe("Instance Type Selection", func() {
 		ExpectInstancesWithLabel(supportedInstanceTypes(cloudProvider.CreateCalls[0]), v1.LabelArchStable, v1beta1.ArchitectureArm64)
 	})
 	It("should schedule on one of the cheapest instances (prov os = windows)", func() {
-		nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-			{
+		nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelOSStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{string(v1.Windows)},
-			},
+			}},
 		}
 		ExpectApplied(ctx, env.Client, nodePool)
 		pod := test.UnschedulablePod()
@@ -172,12 +174,12 @@ var _ = Describe("Instance Type Selection", func() {
 		ExpectInstancesWithLabel(supportedInstanceTypes(cloudProvider.CreateCalls[0]), v1.LabelOSStable, string(v1.Windows))
 	})
 	It("should schedule on one of the cheapest instances (prov os = windows)", func() {
-		nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-			{
+		nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelOSStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{string(v1.Windows)},
-			},
+			}},
 		}
 		ExpectApplied(ctx, env.Client, nodePool)
 		pod := test.UnschedulablePod()
@@ -213,12 +215,12 @@ var _ = Describe("Instance Type Selection", func() {
 		ExpectInstancesWithLabel(supportedInstanceTypes(cloudProvider.CreateCalls[0]), v1.LabelOSStable, string(v1.Linux))
 	})
 	It("should schedule on one of the cheapest instances (prov zone = test-zone-2)", func() {
-		nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-			{
+		nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelTopologyZone,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{"test-zone-2"},
-			},
+			}},
 		}
 		ExpectApplied(ctx, env.Client, nodePool)
 		pod := test.UnschedulablePod()
@@ -241,12 +243,12 @@ var _ = Describe("Instance Type Selection", func() {
 		ExpectInstancesWithLabel(supportedInstanceTypes(cloudProvider.CreateCalls[0]), v1.LabelTopologyZone, "test-zone-2")
 	})
 	It("should schedule on one of the cheapest instances (prov ct = spot)", func() {
-		nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-			{
+		nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1beta1.CapacityTypeLabelKey,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.CapacityTypeSpot},
-			},
+			}},
 		}
 		ExpectApplied(ctx, env.Client, nodePool)
 		pod := test.UnschedulablePod()
@@ -269,17 +271,17 @@ var _ = Describe("Instance Type Selection", func() {
 		ExpectInstancesWithLabel(supportedInstanceTypes(cloudProvider.CreateCalls[0]), v1beta1.CapacityTypeLabelKey, v1beta1.CapacityTypeSpot)
 	})
 	It("should schedule on one of the cheapest instances (prov ct = ondemand, prov zone = test-zone-1)", func() {
-		nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-			{
+		nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1beta1.CapacityTypeLabelKey,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.CapacityTypeOnDemand},
-			},
-			{
+			}},
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelTopologyZone,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{"test-zone-1"},
-			},
+			}},
 		}
 		ExpectApplied(ctx, env.Client, nodePool)
 		pod := test.UnschedulablePod()
@@ -308,12 +310,12 @@ var _ = Describe("Instance Type Selection", func() {
 		ExpectInstancesWithOffering(supportedInstanceTypes(cloudProvider.CreateCalls[0]), v1beta1.CapacityTypeSpot, "test-zone-1")
 	})
 	It("should schedule on one of the cheapest instances (prov ct = spot, pod zone = test-zone-2)", func() {
-		nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-			{
+		nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1beta1.CapacityTypeLabelKey,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.CapacityTypeSpot},
-			},
+			}},
 		}
 		ExpectApplied(ctx, env.Client, nodePool)
 		pod := test.UnschedulablePod(
@@ -328,27 +330,27 @@ var _ = Describe("Instance Type Selection", func() {
 		ExpectInstancesWithOffering(supportedInstanceTypes(cloudProvider.CreateCalls[0]), v1beta1.CapacityTypeSpot, "test-zone-2")
 	})
 	It("should schedule on one of the cheapest instances (prov ct = ondemand/test-zone-1/arm64/windows)", func() {
-		nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-			{
+		nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelArchStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.ArchitectureArm64},
-			},
-			{
+			}},
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelOSStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{string(v1.Windows)},
-			},
-			{
+			}},
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1beta1.CapacityTypeLabelKey,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.CapacityTypeOnDemand},
-			},
-			{
+			}},
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelTopologyZone,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{"test-zone-1"},
-			},
+			}},
 		}
 		ExpectApplied(ctx, env.Client, nodePool)
 		pod := test.UnschedulablePod()
@@ -360,17 +362,17 @@ var _ = Describe("Instance Type Selection", func() {
 		ExpectInstancesWithLabel(supportedInstanceTypes(cloudProvider.CreateCalls[0]), v1.LabelArchStable, "arm64")
 	})
 	It("should schedule on one of the cheapest instances (prov = spot/test-zone-2, pod = amd64/linux)", func() {
-		nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-			{
+		nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelArchStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.ArchitectureAmd64},
-			},
-			{
+			}},
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelOSStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{string(v1.Linux)},
-			},
+			}},
 		}
 		ExpectApplied(ctx, env.Client, nodePool)
 		pod := test.UnschedulablePod(
@@ -485,12 +487,12 @@ var _ = Describe("Instance Type Selection", func() {
 			return true
 		})
 
-		nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-			{
+		nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelArchStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.ArchitectureArm64},
-			},
+			}},
 		}
 		Expect(len(cloudProvider.InstanceTypes)).To(BeNumerically(">", 0))
 		ExpectApplied(ctx, env.Client, nodePool)
@@ -589,12 +591,12 @@ var _ = Describe("Instance Type Selection", func() {
 				},
 			}),
 		}
-		nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-			{
+		nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+			{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1beta1.CapacityTypeLabelKey,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{"on-demand"},
-			},
+			}},
 		}
 
 		ExpectApplied(ctx, env.Client, nodePool)
@@ -603,4 +605,141 @@ var _ = Describe("Instance Type Selection", func() {
 		node := ExpectScheduled(ctx, env.Client, pod)
 		Expect(node.Labels[v1.LabelInstanceTypeStable]).To(Equal("test-instance1"))
 	})
+	Context("Scheduling Strategy", func() {
+		It("should schedule on the largest instance types with the FewestNodes strategy", func() {
+			nodePool.Spec.SchedulingStrategy = v1beta1.SchedulingStrategyFewestNodes
+			ExpectApplied(ctx, env.Client, nodePool)
+			pod := test.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels[v1.LabelInstanceTypeStable]).To(HavePrefix("64-cpu-"))
+		})
+		It("should schedule on the instance types with the lowest price per vCPU with the PricePerVCPU strategy", func() {
+			nodePool.Spec.SchedulingStrategy = v1beta1.SchedulingStrategyPricePerVCPU
+			ExpectApplied(ctx, env.Client, nodePool)
+			pod := test.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			// the cheapest of the instance types whose price per vCPU is close to the lowest
+			Expect(node.Labels[v1.LabelInstanceTypeStable]).To(HavePrefix("16-cpu-1-mem-"))
+		})
+		It("should schedule on the instance types that pods utilize the most with the BestFit strategy", func() {
+			cloudProvider.InstanceTypes = []*cloudprovider.InstanceType{
+				fake.NewInstanceType(fake.InstanceTypeOptions{
+					Name: "best-fit",
+					Resources: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("4"),
+						v1.ResourceMemory: resource.MustParse("8Gi"),
+					},
+					Offerings: []cloudprovider.Offering{
+						{CapacityType: v1beta1.CapacityTypeOnDemand, Zone: "test-zone-1", Price: 1.0, Available: true},
+					},
+				}),
+				fake.NewInstanceType(fake.InstanceTypeOptions{
+					Name: "cheapest",
+					Resources: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("16"),
+						v1.ResourceMemory: resource.MustParse("32Gi"),
+					},
+					Offerings: []cloudprovider.Offering{
+						{CapacityType: v1beta1.CapacityTypeOnDemand, Zone: "test-zone-1", Price: 0.5, Available: true},
+					},
+				}),
+			}
+			nodePool.Spec.SchedulingStrategy = v1beta1.SchedulingStrategyBestFit
+			ExpectApplied(ctx, env.Client, nodePool)
+			pod := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("3"), v1.ResourceMemory: resource.MustParse("6Gi")},
+			}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels[v1.LabelInstanceTypeStable]).To(Equal("best-fit"))
+		})
+		It("should schedule on the cheapest instance types without a strategy", func() {
+			cloudProvider.InstanceTypes = []*cloudprovider.InstanceType{
+				fake.NewInstanceType(fake.InstanceTypeOptions{
+					Name: "best-fit",
+					Resources: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("4"),
+						v1.ResourceMemory: resource.MustParse("8Gi"),
+					},
+					Offerings: []cloudprovider.Offering{
+						{CapacityType: v1beta1.CapacityTypeOnDemand, Zone: "test-zone-1", Price: 1.0, Available: true},
+					},
+				}),
+				fake.NewInstanceType(fake.InstanceTypeOptions{
+					Name: "cheapest",
+					Resources: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("16"),
+						v1.ResourceMemory: resource.MustParse("32Gi"),
+					},
+					Offerings: []cloudprovider.Offering{
+						{CapacityType: v1beta1.CapacityTypeOnDemand, Zone: "test-zone-1", Price: 0.5, Available: true},
+					},
+				}),
+			}
+			ExpectApplied(ctx, env.Client, nodePool)
+			pod := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("3"), v1.ResourceMemory: resource.MustParse("6Gi")},
+			}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels[v1.LabelInstanceTypeStable]).To(Equal("cheapest"))
+		})
+		DescribeTable("should pack pods onto in-flight NodeClaims by the strategy",
+			func(strategy v1beta1.SchedulingStrategy, packedWith int) {
+				cloudProvider.InstanceTypes = []*cloudprovider.InstanceType{
+					fake.NewInstanceType(fake.InstanceTypeOptions{
+						Name: "small",
+						Resources: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("4"),
+							v1.ResourceMemory: resource.MustParse("8Gi"),
+						},
+						Offerings: []cloudprovider.Offering{
+							{CapacityType: v1beta1.CapacityTypeOnDemand, Zone: "test-zone-1", Price: 1.0, Available: true},
+						},
+					}),
+				}
+				nodePool.Spec.SchedulingStrategy = strategy
+				ExpectApplied(ctx, env.Client, nodePool)
+				// the first two pods fill most of a NodeClaim and the third needs another, which leaves one NodeClaim with
+				// two pods and one with a single pod that the smallest pod fits on either of
+				pods := test.UnschedulablePods(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1.5")},
+				}}, 3)
+				small := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("0.5")},
+				}})
+				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, append(pods, small)...)
+				Expect(cloudProvider.CreateCalls).To(HaveLen(2))
+				node := ExpectScheduled(ctx, env.Client, small)
+				Expect(lo.CountBy(pods, func(p *v1.Pod) bool { return ExpectScheduled(ctx, env.Client, p).Name == node.Name })).To(Equal(packedWith))
+			},
+			// LowestPrice spreads the pod onto the emptier NodeClaim
+			Entry("LowestPrice", v1beta1.SchedulingStrategyLowestPrice, 1),
+			// FewestNodes packs the pod onto the fuller NodeClaim
+			Entry("FewestNodes", v1beta1.SchedulingStrategyFewestNodes, 2),
+		)
+		It("should launch enough instance types to satisfy minValues with a strategy", func() {
+			nodePool.Spec.SchedulingStrategy = v1beta1.SchedulingStrategyFewestNodes
+			nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+				{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1beta1.CapacityTypeLabelKey, Operator: v1.NodeSelectorOpIn, Values: []string{v1beta1.CapacityTypeOnDemand}}},
+				{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelArchStable, Operator: v1.NodeSelectorOpIn, Values: []string{v1beta1.ArchitectureAmd64}}},
+				{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelOSStable, Operator: v1.NodeSelectorOpIn, Values: []string{string(v1.Linux)}}},
+				{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}},
+				{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: fake.IntegerInstanceLabelKey, Operator: v1.NodeSelectorOpExists}, MinValues: lo.ToPtr(2)},
+			}
+			ExpectApplied(ctx, env.Client, nodePool)
+			pod := test.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(cloudProvider.CreateCalls).To(HaveLen(1))
+			requirement, ok := lo.Find(cloudProvider.CreateCalls[0].Spec.Requirements, func(r v1beta1.NodeSelectorRequirementWithMinValues) bool {
+				return r.Key == v1.LabelInstanceTypeStable
+			})
+			Expect(ok).To(BeTrue())
+			// the largest instance types only have one vCPU count, so smaller instance types are needed for minValues
+			Expect(lo.Uniq(lo.Map(requirement.Values, func(name string, _ int) string { return strings.Split(name, "-")[0] }))).To(ConsistOf("64", "32"))
+		})
+	})
 })
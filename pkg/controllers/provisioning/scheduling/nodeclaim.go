@@ -21,8 +21,10 @@ import (
 	"strings"
 	"sync/atomic"
 
+	"github.com/samber/lo"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
 	"sigs.k8s.io/karpenter/pkg/cloudprovider"
@@ -38,11 +40,26 @@ type NodeClaim struct {
 	Pods            []*v1.Pod
 	topology        *Topology
 	hostPortUsage   *scheduling.HostPortUsage
+	deviceClaims    scheduling.DeviceClaims
 	daemonResources v1.ResourceList
+	// incompatiblePodGroups are the groups of pods that can't ever be added to the NodeClaim
+	incompatiblePodGroups sets.Set[uint64]
+	// score is how the strategy ranks the NodeClaim, which only changes when a pod is added to it
+	score float64
 }
 
 var nodeID int64
 
+// incompatibleError is returned when a pod is incompatible with a NodeClaim before its topology is considered. NodeClaims
+// only become more constrained as pods are added to them, so the pod won't ever be compatible with the NodeClaim.
+type incompatibleError struct {
+	error
+}
+
+func (e incompatibleError) Unwrap() error {
+	return e.error
+}
+
 func NewNodeClaim(nodeClaimTemplate *NodeClaimTemplate, topology *Topology, daemonResources v1.ResourceList, instanceTypes []*cloudprovider.InstanceType) *NodeClaim {
 	// Copy the template, and add hostname
 	hostname := fmt.Sprintf("hostname-placeholder-%04d", atomic.AddInt64(&nodeID, 1))
@@ -55,31 +72,34 @@ func NewNodeClaim(nodeClaimTemplate *NodeClaimTemplate, topology *Topology, daem
 	template.Spec.Resources.Requests = daemonResources
 
 	return &NodeClaim{
-		NodeClaimTemplate: template,
-		hostPortUsage:     scheduling.NewHostPortUsage(),
-		topology:          topology,
-		daemonResources:   daemonResources,
+		NodeClaimTemplate:     template,
+		hostPortUsage:         scheduling.NewHostPortUsage(),
+		deviceClaims:          scheduling.DeviceClaims{},
+		topology:              topology,
+		daemonResources:       daemonResources,
+		incompatiblePodGroups: sets.New[uint64](),
 	}
 }
 
-func (n *NodeClaim) Add(pod *v1.Pod) error {
+func (n *NodeClaim) Add(pod *v1.Pod, devices *scheduling.DeviceRequests) error {
 	// Check Taints
 	if err := scheduling.Taints(n.Spec.Taints).Tolerates(pod); err != nil {
-		return err
+		return incompatibleError{newRejection(RejectionReasonTaints, err)}
 	}
 
 	// exposed host ports on the node
 	hostPorts := scheduling.GetHostPorts(pod)
 	if err := n.hostPortUsage.Conflicts(pod, hostPorts); err != nil {
-		return fmt.Errorf("checking host port usage, %w", err)
+		return incompatibleError{newRejection(RejectionReasonHostPorts, fmt.Errorf("checking host port usage, %w", err))}
 	}
 
 	nodeClaimRequirements := scheduling.NewRequirements(n.Requirements.Values()...)
 	podRequirements := scheduling.NewPodRequirements(pod)
+	podRequirements.Add(devices.Requirements.Values()...)
 
 	// Check NodeClaim Affinity Requirements
 	if err := nodeClaimRequirements.Compatible(podRequirements, scheduling.AllowUndefinedWellKnownLabels); err != nil {
-		return fmt.Errorf("incompatible requirements, %w", err)
+		return incompatibleError{newRejection(RejectionReasonRequirements, fmt.Errorf("incompatible requirements, %w", err))}
 	}
 	nodeClaimRequirements.Add(podRequirements.Values()...)
 
@@ -88,24 +108,52 @@ func (n *NodeClaim) Add(pod *v1.Pod) error {
 		// strictPodRequirements is important as it ensures we don't inadvertently restrict the possible pod domains by a
 		// preferred node affinity.  Only required node affinities can actually reduce pod domains.
 		strictPodRequirements = scheduling.NewStrictPodRequirements(pod)
+		strictPodRequirements.Add(devices.Requirements.Values()...)
 	}
 	// Check Topology Requirements
-	topologyRequirements, err := n.topology.AddRequirements(strictPodRequirements, nodeClaimRequirements, pod, scheduling.AllowUndefinedWellKnownLabels)
+	topologyRequirements, err := n.topology.AddRequirements(strictPodRequirements, nodeClaimRequirements, pod, n.Spec.Taints, scheduling.AllowUndefinedWellKnownLabels)
 	if err != nil {
-		return err
+		return newRejection(RejectionReasonTopology, err)
 	}
 	if err = nodeClaimRequirements.Compatible(topologyRequirements, scheduling.AllowUndefinedWellKnownLabels); err != nil {
-		return err
+		return newRejection(RejectionReasonTopology, err)
 	}
 	nodeClaimRequirements.Add(topologyRequirements.Values()...)
 
+	// Check that the instance types have the devices for every claim on the node
+	instanceTypes := n.InstanceTypeOptions
+	deviceClaims := n.deviceClaims.Union(devices.Claims)
+	if len(devices.Claims) > 0 {
+		instanceTypes = lo.Filter(instanceTypes, func(it *cloudprovider.InstanceType, _ int) bool {
+			return deviceClaims.ExceedsCapacity(it.Devices.Capacity()) == nil
+		})
+		if len(instanceTypes) == 0 {
+			return newRejection(RejectionReasonDevices, fmt.Errorf("no instance type has enough devices to allocate resource claims"))
+		}
+	}
+
 	// Check instance type combinations
 	requests := resources.Merge(n.Spec.Resources.Requests, resources.RequestsForPods(pod))
-	filtered := filterInstanceTypesByRequirements(n.InstanceTypeOptions, nodeClaimRequirements, requests)
+	filtered := n.instanceTypeFilter.Filter(instanceTypes, nodeClaimRequirements, requests)
 	if len(filtered.remaining) == 0 {
 		// log the total resources being requested (daemonset + the pod)
 		cumulativeResources := resources.Merge(n.daemonResources, resources.RequestsForPods(pod))
-		return fmt.Errorf("no instance type satisfied resources %s and requirements %s (%s)", resources.String(cumulativeResources), nodeClaimRequirements, filtered.FailureReason())
+		rejection := newRejection(filtered.Reason(), fmt.Errorf("no instance type satisfied resources %s and requirements %s (%s)", resources.String(cumulativeResources), nodeClaimRequirements, filtered.FailureReason()))
+		if rejection.reason == RejectionReasonInsufficientResources {
+			// the pod would have fit on its own if it weren't for the daemonsets that run on every node
+			if len(n.Pods) == 0 && len(n.instanceTypeFilter.Filter(instanceTypes, nodeClaimRequirements, resources.RequestsForPods(pod)).remaining) > 0 {
+				rejection.reason = RejectionReasonDaemonOverhead
+			}
+			rejection.closest = func() []string { return closestInstanceTypes(instanceTypes, nodeClaimRequirements, requests) }
+		}
+		return rejection
+	}
+	// Refuse to narrow the instance types below the flexibility required by minValues. We check against the instance
+	// types that would actually be launched, since only those that the strategy selects are passed through to the NodeClaim.
+	if nodeClaimRequirements.HasMinValues() {
+		if _, err := n.Strategy.Select(filtered.remaining, nodeClaimRequirements, requests).SatisfiesMinValues(nodeClaimRequirements); err != nil {
+			return newRejection(RejectionReasonMinValues, fmt.Errorf("incompatible with minValues, %w", err))
+		}
 	}
 
 	// Update node
@@ -113,8 +161,10 @@ func (n *NodeClaim) Add(pod *v1.Pod) error {
 	n.InstanceTypeOptions = filtered.remaining
 	n.Spec.Resources.Requests = requests
 	n.Requirements = nodeClaimRequirements
-	n.topology.Record(pod, nodeClaimRequirements, scheduling.AllowUndefinedWellKnownLabels)
+	n.topology.Record(pod, n.Spec.Taints, nodeClaimRequirements, scheduling.AllowUndefinedWellKnownLabels)
 	n.hostPortUsage.Add(pod, hostPorts)
+	n.deviceClaims = deviceClaims
+	n.score = n.Strategy.Score(n)
 	return nil
 }
 
@@ -221,8 +271,33 @@ func (r filterResults) FailureReason() string {
 	return "no instance type met the requirements/resources/offering tuple"
 }
 
+// instanceTypeFilter filters instance types by requirements and requests. Whether an instance type is compatible
+// with requirements and has an offering for them is memoized, since pods with the same constraints produce the same
+// requirements over and over as they're bin-packed.
+type instanceTypeFilter struct {
+	compatibility map[uint64]map[*cloudprovider.InstanceType]instanceTypeCompatibility
+	allocatable   map[*cloudprovider.InstanceType]instanceTypeAllocatable
+}
+
+type instanceTypeCompatibility struct {
+	compatible  bool
+	hasOffering bool
+}
+
+type instanceTypeAllocatable struct {
+	resources v1.ResourceList
+	negative  bool
+}
+
+func newInstanceTypeFilter() *instanceTypeFilter {
+	return &instanceTypeFilter{
+		compatibility: map[uint64]map[*cloudprovider.InstanceType]instanceTypeCompatibility{},
+		allocatable:   map[*cloudprovider.InstanceType]instanceTypeAllocatable{},
+	}
+}
+
 //nolint:gocyclo
-func filterInstanceTypesByRequirements(instanceTypes []*cloudprovider.InstanceType, requirements scheduling.Requirements, requests v1.ResourceList) filterResults {
+func (f *instanceTypeFilter) Filter(instanceTypes []*cloudprovider.InstanceType, requirements scheduling.Requirements, requests v1.ResourceList) filterResults {
 	results := filterResults{
 		requests:        requests,
 		requirementsMet: false,
@@ -233,12 +308,36 @@ func filterInstanceTypesByRequirements(instanceTypes []*cloudprovider.InstanceTy
 		requirementsAndOffering: false,
 		fitsAndOffering:         false,
 	}
-	for _, it := range instanceTypes {
+	// instance types don't have hostnames, so the placeholder hostname of each NodeClaim doesn't affect compatibility
+	key := requirements.Hash(v1.LabelHostname)
+	memoized, ok := f.compatibility[key]
+	if !ok {
+		memoized = map[*cloudprovider.InstanceType]instanceTypeCompatibility{}
+		f.compatibility[key] = memoized
+	}
+	compatibilities := make([]instanceTypeCompatibility, len(instanceTypes))
+	for i, it := range instanceTypes {
+		c, ok := memoized[it]
+		if !ok {
+			c = instanceTypeCompatibility{compatible: compatible(it, requirements), hasOffering: hasOffering(it, requirements)}
+			memoized[it] = c
+		}
+		compatibilities[i] = c
+		// we only check if the instance type fits once we know that it's compatible, since that's the common case when
+		// instance types remain
+		if c.compatible && c.hasOffering && f.fits(it, requests) {
+			results.remaining = append(results.remaining, it)
+		}
+	}
+	if len(results.remaining) > 0 {
+		return results
+	}
+	for i, it := range instanceTypes {
 		// the tradeoff to not short circuiting on the filtering is that we can report much better error messages
 		// about why scheduling failed
-		itCompat := compatible(it, requirements)
-		itFits := fits(it, requests)
-		itHasOffering := hasOffering(it, requirements)
+		itCompat := compatibilities[i].compatible
+		itFits := f.fits(it, requests)
+		itHasOffering := compatibilities[i].hasOffering
 
 		// track if any single instance type met a single criteria
 		results.requirementsMet = results.requirementsMet || itCompat
@@ -249,24 +348,35 @@ func filterInstanceTypesByRequirements(instanceTypes []*cloudprovider.InstanceTy
 		results.requirementsAndFits = results.requirementsAndFits || (itCompat && itFits && !itHasOffering)
 		results.requirementsAndOffering = results.requirementsAndOffering || (itCompat && itHasOffering && !itFits)
 		results.fitsAndOffering = results.fitsAndOffering || (itFits && itHasOffering && !itCompat)
+	}
+	return results
+}
 
-		// and if it met all criteria, we keep the instance type and continue filtering.  We now won't be reporting
-		// any errors.
-		if itCompat && itFits && itHasOffering {
-			results.remaining = append(results.remaining, it)
+// fits returns true if the requests fit in the instance type's allocatable resources. It's equivalent to
+// resources.Fits, but only checks whether the allocatable resources are negative once per instance type.
+func (f *instanceTypeFilter) fits(instanceType *cloudprovider.InstanceType, requests v1.ResourceList) bool {
+	allocatable, ok := f.allocatable[instanceType]
+	if !ok {
+		allocatable = instanceTypeAllocatable{resources: instanceType.Allocatable()}
+		// If any of the allocatable resource values are negative then nothing will ever fit
+		allocatable.negative = !resources.Fits(nil, allocatable.resources)
+		f.allocatable[instanceType] = allocatable
+	}
+	if allocatable.negative {
+		return false
+	}
+	for resourceName, quantity := range requests {
+		if resources.Cmp(quantity, allocatable.resources[resourceName]) > 0 {
+			return false
 		}
 	}
-	return results
+	return true
 }
 
 func compatible(instanceType *cloudprovider.InstanceType, requirements scheduling.Requirements) bool {
 	return instanceType.Requirements.Intersects(requirements) == nil
 }
 
-func fits(instanceType *cloudprovider.InstanceType, requests v1.ResourceList) bool {
-	return resources.Fits(requests, instanceType.Allocatable())
-}
-
 func hasOffering(instanceType *cloudprovider.InstanceType, requirements scheduling.Requirements) bool {
 	for _, offering := range instanceType.Offerings.Available() {
 		if (!requirements.Has(v1.LabelTopologyZone) || requirements.Get(v1.LabelTopologyZone).Has(offering.Zone)) &&
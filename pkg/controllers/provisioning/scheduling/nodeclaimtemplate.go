@@ -29,6 +29,9 @@ import (
 	"sigs.k8s.io/karpenter/pkg/scheduling"
 )
 
+// MaxInstanceTypes is the maximum number of instance types that are passed through to a launched NodeClaim
+const MaxInstanceTypes = 100
+
 // NodeClaimTemplate encapsulates the fields required to create a node and mirrors
 // the fields in NodePool. These structs are maintained separately in order
 // for fields like Requirements to be able to be stored more efficiently.
@@ -38,6 +41,11 @@ type NodeClaimTemplate struct {
 	NodePoolName        string
 	InstanceTypeOptions cloudprovider.InstanceTypes
 	Requirements        scheduling.Requirements
+	Strategy            Strategy
+	// ReservationID is the capacity reservation that the NodeClaim will launch into, if any
+	ReservationID string
+
+	instanceTypeFilter *instanceTypeFilter
 }
 
 func NewNodeClaimTemplate(nodePool *v1beta1.NodePool) *NodeClaimTemplate {
@@ -45,16 +53,20 @@ func NewNodeClaimTemplate(nodePool *v1beta1.NodePool) *NodeClaimTemplate {
 		NodeClaimTemplate: nodePool.Spec.Template,
 		NodePoolName:      nodePool.Name,
 		Requirements:      scheduling.NewRequirements(),
+		Strategy:          NewStrategy(nodePool.Spec.SchedulingStrategy),
+
+		instanceTypeFilter: newInstanceTypeFilter(),
 	}
 	nct.Labels = lo.Assign(nct.Labels, map[string]string{v1beta1.NodePoolLabelKey: nodePool.Name})
-	nct.Requirements.Add(scheduling.NewNodeSelectorRequirements(nct.Spec.Requirements...).Values()...)
+	nct.Requirements.Add(scheduling.NewNodeSelectorRequirementsWithMinValues(nct.Spec.Requirements...).Values()...)
 	nct.Requirements.Add(scheduling.NewLabelRequirements(nct.Labels).Values()...)
 	return nct
 }
 
 func (i *NodeClaimTemplate) ToNodeClaim(nodePool *v1beta1.NodePool) *v1beta1.NodeClaim {
-	// Order the instance types by price and only take the first 100 of them to decrease the instance type size in the requirements
-	instanceTypes := lo.Slice(i.InstanceTypeOptions.OrderByPrice(i.Requirements), 0, 100)
+	// Only pass through the instance types that the NodePool's strategy selects, which are at most 100 of them to decrease
+	// the instance type size in the requirements. Scheduling has already ensured that they satisfy any minValues in the requirements.
+	instanceTypes := i.Strategy.Select(i.InstanceTypeOptions, i.Requirements, i.Spec.Resources.Requests)
 	i.Requirements.Add(scheduling.NewRequirement(v1.LabelInstanceTypeStable, v1.NodeSelectorOpIn, lo.Map(instanceTypes, func(i *cloudprovider.InstanceType, _ int) string {
 		return i.Name
 	})...))
@@ -77,5 +89,8 @@ func (i *NodeClaimTemplate) ToNodeClaim(nodePool *v1beta1.NodePool) *v1beta1.Nod
 		Spec: i.Spec,
 	}
 	nc.Spec.Requirements = i.Requirements.NodeSelectorRequirements()
+	if i.ReservationID != "" {
+		nc.Annotations[v1beta1.ReservationIDAnnotationKey] = i.ReservationID
+	}
 	return nc
 }
@@ -0,0 +1,72 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"github.com/mitchellh/hashstructure/v2"
+	"github.com/samber/lo"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/karpenter/pkg/scheduling"
+	"sigs.k8s.io/karpenter/pkg/utils/resources"
+)
+
+// podGroup identifies pods with identical scheduling constraints. Pods in the same group are interchangeable when
+// they're bin-packed, so once a pod can't ever be added to a NodeClaim, neither can any other pod in its group.
+type podGroup struct {
+	key uint64
+	// topologyDependent is true if the pods have topology spread or pod affinity constraints. The domains that these
+	// constraints allow change as other pods are scheduled, so a NodeClaim that the pods are incompatible with due to
+	// their topology may become compatible later.
+	topologyDependent bool
+}
+
+// newPodGroup returns the group of the pod, or nil if the pod can't be grouped with other pods. Pods with resource
+// claims aren't grouped since every pod can claim different devices.
+func newPodGroup(p *v1.Pod) *podGroup {
+	if len(p.Spec.ResourceClaims) > 0 {
+		return nil
+	}
+	key, err := hashstructure.Hash(struct {
+		Namespace                 string
+		Labels                    map[string]string
+		NodeSelector              map[string]string
+		Affinity                  *v1.Affinity
+		Tolerations               []v1.Toleration
+		TopologySpreadConstraints []v1.TopologySpreadConstraint
+		Requests                  map[v1.ResourceName]string
+		HostPorts                 []string
+	}{
+		Namespace:                 p.Namespace,
+		Labels:                    p.Labels,
+		NodeSelector:              p.Spec.NodeSelector,
+		Affinity:                  p.Spec.Affinity,
+		Tolerations:               p.Spec.Tolerations,
+		TopologySpreadConstraints: p.Spec.TopologySpreadConstraints,
+		// quantities don't have exported fields to hash, so we hash their string representation
+		Requests:  lo.MapValues(resources.RequestsForPods(p), func(q resource.Quantity, _ v1.ResourceName) string { return q.String() }),
+		HostPorts: lo.Map(scheduling.GetHostPorts(p), func(hp scheduling.HostPort, _ int) string { return hp.String() }),
+	}, hashstructure.FormatV2, nil)
+	if err != nil {
+		return nil
+	}
+	return &podGroup{
+		key:               key,
+		topologyDependent: len(p.Spec.TopologySpreadConstraints) > 0 || (p.Spec.Affinity != nil && p.Spec.Affinity.PodAffinity != nil),
+	}
+}
@@ -35,7 +35,9 @@ type Preferences struct {
 	ToleratePreferNoSchedule bool
 }
 
-func (p *Preferences) Relax(ctx context.Context, pod *v1.Pod) bool {
+// Relax removes the first of the pod's soft constraints that can be relaxed, returning a description of the relaxation
+// or nil if there was nothing left to relax
+func (p *Preferences) Relax(ctx context.Context, pod *v1.Pod) *string {
 	ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With("pod", client.ObjectKeyFromObject(pod)))
 	relaxations := []func(*v1.Pod) *string{
 		p.removeRequiredNodeAffinityTerm,
@@ -51,10 +53,10 @@ func (p *Preferences) Relax(ctx context.Context, pod *v1.Pod) bool {
 	for _, relaxFunc := range relaxations {
 		if reason := relaxFunc(pod); reason != nil {
 			logging.FromContext(ctx).Debugf("relaxing soft constraints for pod since it previously failed to schedule, %s", ptr.StringValue(reason))
-			return true
+			return reason
 		}
 	}
-	return false
+	return nil
 }
 
 func (p *Preferences) removePreferredNodeAffinityTerm(pod *v1.Pod) *string {
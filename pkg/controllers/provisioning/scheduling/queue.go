@@ -22,6 +22,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	"sigs.k8s.io/karpenter/pkg/utils/pod"
 	"sigs.k8s.io/karpenter/pkg/utils/resources"
 )
 
@@ -33,9 +34,11 @@ type Queue struct {
 	lastLen map[types.UID]int
 }
 
-// NewQueue constructs a new queue given the input pods, sorting them to optimize for bin-packing into nodes.
+// NewQueue constructs a new queue given the input pods, sorting them so that higher priority pods are scheduled first
+// and pods of the same priority are ordered to optimize for bin-packing into nodes. Pods that are held by scheduling
+// gates are scheduled last.
 func NewQueue(pods ...*v1.Pod) *Queue {
-	sort.Slice(pods, byCPUAndMemoryDescending(pods))
+	sort.Slice(pods, byPriorityThenCPUAndMemoryDescending(pods))
 	return &Queue{
 		pods:    pods,
 		lastLen: map[types.UID]int{},
@@ -73,13 +76,30 @@ func (q *Queue) List() []*v1.Pod {
 	return q.pods
 }
 
-func byCPUAndMemoryDescending(pods []*v1.Pod) func(i int, j int) bool {
+func byPriorityThenCPUAndMemoryDescending(pods []*v1.Pod) func(i int, j int) bool {
+	// computing requests is relatively expensive, so we only do it once per pod rather than for every comparison
+	requests := make(map[*v1.Pod]v1.ResourceList, len(pods))
+	for _, p := range pods {
+		requests[p] = resources.RequestsForPods(p)
+	}
 	return func(i, j int) bool {
 		lhsPod := pods[i]
 		rhsPod := pods[j]
 
-		lhs := resources.RequestsForPods(lhsPod)
-		rhs := resources.RequestsForPods(rhsPod)
+		// pods that are held by scheduling gates can't schedule yet, so we only provision for them after every pod
+		// that can
+		if lhsGated, rhsGated := pod.IsGated(lhsPod), pod.IsGated(rhsPod); lhsGated != rhsGated {
+			return rhsGated
+		}
+
+		// kube-scheduler schedules higher priority pods first, and they can preempt lower priority pods, so they
+		// get first pick of the capacity
+		if lhsPriority, rhsPriority := pod.Priority(lhsPod), pod.Priority(rhsPod); lhsPriority != rhsPriority {
+			return lhsPriority > rhsPriority
+		}
+
+		lhs := requests[lhsPod]
+		rhs := requests[rhsPod]
 
 		cpuCmp := resources.Cmp(lhs[v1.ResourceCPU], rhs[v1.ResourceCPU])
 		if cpuCmp < 0 {
@@ -0,0 +1,123 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"sort"
+
+	"github.com/samber/lo"
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+	"sigs.k8s.io/karpenter/pkg/cloudprovider"
+	"sigs.k8s.io/karpenter/pkg/controllers/state"
+	"sigs.k8s.io/karpenter/pkg/scheduling"
+)
+
+// Reservations tracks how many more instances can be launched into each capacity reservation as NodeClaims are
+// planned, so that we never plan to launch more instances into a reservation than it has room for
+type Reservations struct {
+	// reserved are the available reserved offerings of each instance type that has any
+	reserved  map[*cloudprovider.InstanceType]cloudprovider.Offerings
+	remaining map[string]int
+}
+
+func NewReservations(instanceTypes map[string][]*cloudprovider.InstanceType, stateNodes []*state.StateNode) *Reservations {
+	r := &Reservations{reserved: map[*cloudprovider.InstanceType]cloudprovider.Offerings{}, remaining: map[string]int{}}
+	for _, its := range instanceTypes {
+		for _, it := range its {
+			offerings := it.Offerings.Available().Reserved()
+			if len(offerings) == 0 {
+				continue
+			}
+			r.reserved[it] = offerings
+			for _, o := range offerings {
+				// NodePools can share instance types, so we only count each reservation once
+				if _, ok := r.remaining[o.ReservationID]; !ok {
+					r.remaining[o.ReservationID] = o.ReservationCapacity
+				}
+			}
+		}
+	}
+	// The capacity of a reservation only accounts for the instances that have launched into it, so the NodeClaims that
+	// we planned to launch into it that haven't launched yet still take up room in it
+	for _, node := range stateNodes {
+		if node.NodeClaim == nil || node.NodeClaim.StatusConditions().GetCondition(v1beta1.Launched).IsTrue() {
+			continue
+		}
+		if id, ok := node.NodeClaim.Annotations[v1beta1.ReservationIDAnnotationKey]; ok {
+			if _, ok := r.remaining[id]; ok {
+				r.remaining[id]--
+			}
+		}
+	}
+	return r
+}
+
+// Offerings returns the reserved offerings with room left that a NodeClaim with the requirements and instance types
+// could launch into, cheapest first. Only the first offering seen for each reservation is returned.
+func (r *Reservations) Offerings(requirements scheduling.Requirements, instanceTypes []*cloudprovider.InstanceType) cloudprovider.Offerings {
+	if len(r.reserved) == 0 {
+		return nil
+	}
+	var offerings cloudprovider.Offerings
+	for _, it := range instanceTypes {
+		for _, o := range r.reserved[it].Compatible(requirements) {
+			if r.remaining[o.ReservationID] > 0 && !lo.ContainsBy(offerings, func(existing cloudprovider.Offering) bool { return existing.ReservationID == o.ReservationID }) {
+				offerings = append(offerings, o)
+			}
+		}
+	}
+	sort.SliceStable(offerings, func(i, j int) bool {
+		if offerings[i].Price != offerings[j].Price {
+			return offerings[i].Price < offerings[j].Price
+		}
+		return offerings[i].ReservationID < offerings[j].ReservationID
+	})
+	return offerings
+}
+
+// Pin restricts the NodeClaim to launch into the reservation of the offering
+func (r *Reservations) Pin(nodeClaim *NodeClaim, offering cloudprovider.Offering) {
+	nodeClaim.Requirements.Add(
+		scheduling.NewRequirement(v1beta1.CapacityTypeLabelKey, v1.NodeSelectorOpIn, v1beta1.CapacityTypeReserved),
+		scheduling.NewRequirement(v1.LabelTopologyZone, v1.NodeSelectorOpIn, offering.Zone),
+	)
+	nodeClaim.InstanceTypeOptions = lo.Filter(nodeClaim.InstanceTypeOptions, func(it *cloudprovider.InstanceType, _ int) bool {
+		return lo.ContainsBy(it.Offerings.Available(), func(o cloudprovider.Offering) bool { return o.ReservationID == offering.ReservationID })
+	})
+	nodeClaim.ReservationID = offering.ReservationID
+}
+
+// Exclude keeps a NodeClaim that isn't pinned to a reservation from launching into one, since we can't tell how many
+// instances it would take out of the reservation
+func (r *Reservations) Exclude(nodeClaim *NodeClaim) {
+	if len(r.reserved) == 0 || !lo.ContainsBy(nodeClaim.InstanceTypeOptions, func(it *cloudprovider.InstanceType) bool {
+		return len(r.reserved[it].Compatible(nodeClaim.Requirements)) > 0
+	}) {
+		return
+	}
+	nodeClaim.Requirements.Add(scheduling.NewRequirement(v1beta1.CapacityTypeLabelKey, v1.NodeSelectorOpNotIn, v1beta1.CapacityTypeReserved))
+	nodeClaim.InstanceTypeOptions = cloudprovider.InstanceTypes(nodeClaim.InstanceTypeOptions).Compatible(nodeClaim.Requirements)
+}
+
+// Reserve records that the NodeClaim will launch into its reservation
+func (r *Reservations) Reserve(nodeClaim *NodeClaim) {
+	if nodeClaim.ReservationID != "" {
+		r.remaining[nodeClaim.ReservationID]--
+	}
+}
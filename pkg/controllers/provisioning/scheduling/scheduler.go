@@ -19,6 +19,7 @@ package scheduling
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/samber/lo"
 	"go.uber.org/multierr"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"knative.dev/pkg/logging"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -74,11 +76,20 @@ func NewScheduler(ctx context.Context, kubeClient client.Client, nodeClaimTempla
 		opts:               opts,
 		preferences:        &Preferences{ToleratePreferNoSchedule: toleratePreferNoSchedule},
 		remainingResources: map[string]v1.ResourceList{},
+		deviceRequests:     map[*v1.Pod]*scheduling.DeviceRequests{},
+		podGroups:          map[*v1.Pod]*podGroup{},
+		explanations:       map[*v1.Pod]*Explanation{},
+		reservations:       NewReservations(instanceTypes, stateNodes),
 	}
 	for _, nodePool := range nodePools {
 		s.remainingResources[nodePool.Name] = v1.ResourceList(nodePool.Spec.Limits)
 	}
 	s.calculateExistingNodeClaims(stateNodes, daemonSetPods)
+	if !opts.SimulationMode {
+		if err := s.reserveNominatedCapacity(ctx); err != nil {
+			logging.FromContext(ctx).Errorf("reserving capacity for preempting pods, %s", err)
+		}
+	}
 	return s
 }
 
@@ -96,6 +107,12 @@ type Scheduler struct {
 	recorder           events.Recorder
 	opts               SchedulerOptions
 	kubeClient         client.Client
+	preempted          []*v1.Pod                              // pods that were preempted from existing nodes and still need to be rescheduled
+	deviceRequests     map[*v1.Pod]*scheduling.DeviceRequests // (Pod) -> devices requested by the pod's resource claims
+	podGroups          map[*v1.Pod]*podGroup                  // (Pod) -> group of pods with identical scheduling constraints
+	explanations       map[*v1.Pod]*Explanation               // (Pod) -> why the pod couldn't be scheduled when it was last tried
+	reservations       *Reservations
+	disruptionBudgets  *DisruptionBudgets // lazily populated when we first model preemption
 }
 
 // Results contains the results of the scheduling operation
@@ -103,6 +120,8 @@ type Results struct {
 	NewNodeClaims []*NodeClaim
 	ExistingNodes []*ExistingNode
 	PodErrors     map[*v1.Pod]error
+	// PodExplanations describe why each of the pods in PodErrors couldn't be scheduled
+	PodExplanations map[*v1.Pod]*Explanation
 }
 
 // AllNonPendingPodsScheduled returns true if all pods scheduled.
@@ -156,13 +175,25 @@ func (s *Scheduler) Solve(ctx context.Context, pods []*v1.Pod) Results {
 
 		// Schedule to existing nodes or create a new node
 		if errors[pod] = s.add(ctx, pod); errors[pod] == nil {
+			// pods that were preempted to make room for this one need somewhere else to go
+			for _, p := range s.preempted {
+				if err := s.topology.Update(ctx, p); err != nil {
+					logging.FromContext(ctx).Errorf("updating topology, %s", err)
+				}
+				q.Push(p, true)
+			}
+			s.preempted = nil
 			continue
 		}
 
 		// If unsuccessful, relax the pod and recompute topology
-		relaxed := s.preferences.Relax(ctx, pod)
+		relaxation := s.preferences.Relax(ctx, pod)
+		relaxed := relaxation != nil
 		q.Push(pod, relaxed)
 		if relaxed {
+			s.getExplanation(pod).Relaxations = append(s.getExplanation(pod).Relaxations, *relaxation)
+			// relaxing changes the pod's constraints, so it may no longer belong to the same group
+			delete(s.podGroups, pod)
 			if err := s.topology.Update(ctx, pod); err != nil {
 				logging.FromContext(ctx).Errorf("updating topology, %s", err)
 			}
@@ -179,28 +210,43 @@ func (s *Scheduler) Solve(ctx context.Context, pods []*v1.Pod) Results {
 	for k, v := range errors {
 		if v == nil {
 			delete(errors, k)
+			continue
 		}
+		s.getExplanation(k).finalize()
 	}
 	return Results{
-		NewNodeClaims: s.newNodeClaims,
-		ExistingNodes: s.existingNodes,
-		PodErrors:     errors,
+		NewNodeClaims:   s.newNodeClaims,
+		ExistingNodes:   s.existingNodes,
+		PodErrors:       errors,
+		PodExplanations: lo.PickByKeys(s.explanations, lo.Keys(errors)),
 	}
 }
 
 func (s *Scheduler) recordSchedulingResults(ctx context.Context, pods []*v1.Pod, failedToSchedule []*v1.Pod, errors map[*v1.Pod]error, schedulingDuration time.Duration) {
-	// Report failures and nominations
-	for _, pod := range failedToSchedule {
-		logging.FromContext(ctx).With("pod", client.ObjectKeyFromObject(pod)).Errorf("Could not schedule pod, %s", errors[pod])
-		s.recorder.Publish(PodFailedToScheduleEvent(pod, errors[pod]))
+	// Report failures and nominations. Headroom pods are virtual, so we don't publish events for them or nominate
+	// nodes on their behalf.
+	for _, p := range failedToSchedule {
+		if pod.IsOwnedByNodePool(p) {
+			logging.FromContext(ctx).With("pod", p.Name).Debugf("could not schedule headroom pod, %s", errors[p])
+			continue
+		}
+		logging.FromContext(ctx).With("pod", client.ObjectKeyFromObject(p)).Errorf("Could not schedule pod, %s", errors[p])
+		s.recorder.Publish(PodFailedToScheduleEvent(p, errors[p]))
 	}
 
 	for _, existing := range s.existingNodes {
-		if len(existing.Pods) > 0 {
+		nominated := lo.Reject(existing.Pods, func(p *v1.Pod, _ int) bool { return pod.IsOwnedByNodePool(p) })
+		if len(nominated) > 0 {
 			s.cluster.NominateNodeForPod(ctx, existing.ProviderID())
 		}
-		for _, pod := range existing.Pods {
-			s.recorder.Publish(NominatePodEvent(pod, existing.Node, existing.NodeClaim))
+		for _, p := range nominated {
+			// gated pods are waiting on their capacity rather than on the kube-scheduler, so we let whatever is
+			// holding them know once the node that they'd schedule to is ready
+			if pod.IsGated(p) && existing.Initialized() {
+				s.recorder.Publish(PodCapacityReadyEvent(p, existing.Node, existing.NodeClaim))
+				continue
+			}
+			s.recorder.Publish(NominatePodEvent(p, existing.Node, existing.NodeClaim))
 		}
 	}
 
@@ -236,44 +282,84 @@ func (s *Scheduler) recordSchedulingResults(ctx context.Context, pods []*v1.Pod,
 }
 
 func (s *Scheduler) add(ctx context.Context, pod *v1.Pod) error {
+	devices, err := s.getDeviceRequests(ctx, pod)
+	if err != nil {
+		return err
+	}
 	// first try to schedule against an in-flight real node
 	for _, node := range s.existingNodes {
-		if err := node.Add(ctx, s.kubeClient, pod); err == nil {
+		if err := node.Add(ctx, s.kubeClient, pod, devices); err == nil {
 			return nil
 		}
 	}
 
+	// kube-scheduler will preempt lower priority pods on an existing node long before any node that we launch is
+	// ready, so we model that instead of launching capacity for the pod. We don't model preemption when simulating
+	// consolidation since we don't want to disrupt pods beyond the candidates, and we don't preempt pods that their
+	// disruption budgets protect.
+	if !s.opts.SimulationMode {
+		if budgets, err := s.getDisruptionBudgets(ctx); err != nil {
+			logging.FromContext(ctx).Errorf("modeling preemption, %s", err)
+		} else {
+			for _, node := range s.existingNodes {
+				victims, err := node.Preempt(ctx, s.kubeClient, pod, devices, budgets)
+				if err != nil {
+					continue
+				}
+				logging.FromContext(ctx).With("pod", client.ObjectKeyFromObject(pod), "node", node.Name(), "preempted", len(victims)).Debugf("pod will preempt lower priority pods")
+				s.preempted = append(s.preempted, victims...)
+				return nil
+			}
+		}
+	}
+
 	// Consider using https://pkg.go.dev/container/heap
-	sort.Slice(s.newNodeClaims, func(a, b int) bool { return len(s.newNodeClaims[a].Pods) < len(s.newNodeClaims[b].Pods) })
+	sort.Slice(s.newNodeClaims, func(a, b int) bool { return s.newNodeClaims[a].score < s.newNodeClaims[b].score })
 
 	// Pick existing node that we are about to create
+	group := s.getPodGroup(pod)
 	for _, nodeClaim := range s.newNodeClaims {
-		if err := nodeClaim.Add(pod); err == nil {
+		if group != nil && nodeClaim.incompatiblePodGroups.Has(group.key) {
+			continue
+		}
+		err := nodeClaim.Add(pod, devices)
+		if err == nil {
 			return nil
 		}
+		// Unless the pod's topology could allow it later, a pod that doesn't fit on the NodeClaim never will, and neither
+		// will any pod with the same constraints. Remembering this keeps large batches of identical pods from retrying
+		// every NodeClaim.
+		if incompatible := (incompatibleError{}); group != nil && (!group.topologyDependent || errors.As(err, &incompatible)) {
+			nodeClaim.incompatiblePodGroups.Insert(group.key)
+		}
 	}
 
 	// Create new node
 	var errs error
+	explanation := s.getExplanation(pod)
+	explanation.NodePools = nil
 	for _, nodeClaimTemplate := range s.nodeClaimTemplates {
 		instanceTypes := s.instanceTypes[nodeClaimTemplate.NodePoolName]
 		// if limits have been applied to the nodepool, ensure we filter instance types to avoid violating those limits
 		if remaining, ok := s.remainingResources[nodeClaimTemplate.NodePoolName]; ok {
-			instanceTypes = filterByRemainingResources(s.instanceTypes[nodeClaimTemplate.NodePoolName], remaining)
+			instanceTypes = FilterByRemainingResources(s.instanceTypes[nodeClaimTemplate.NodePoolName], remaining)
 			if len(instanceTypes) == 0 {
-				errs = multierr.Append(errs, fmt.Errorf("all available instance types exceed limits for nodepool: %q", nodeClaimTemplate.NodePoolName))
+				err := fmt.Errorf("all available instance types exceed limits for nodepool: %q", nodeClaimTemplate.NodePoolName)
+				errs = multierr.Append(errs, err)
+				explanation.NodePools = append(explanation.NodePools, newNodePoolRejection(nodeClaimTemplate.NodePoolName, newRejection(RejectionReasonLimits, err)))
 				continue
 			} else if len(s.instanceTypes[nodeClaimTemplate.NodePoolName]) != len(instanceTypes) && !s.opts.SimulationMode {
 				logging.FromContext(ctx).With("nodepool", nodeClaimTemplate.NodePoolName).Debugf("%d out of %d instance types were excluded because they would breach limits",
 					len(s.instanceTypes[nodeClaimTemplate.NodePoolName])-len(instanceTypes), len(s.instanceTypes[nodeClaimTemplate.NodePoolName]))
 			}
 		}
-		nodeClaim := NewNodeClaim(nodeClaimTemplate, s.topology, s.daemonOverhead[nodeClaimTemplate], instanceTypes)
-		if err := nodeClaim.Add(pod); err != nil {
+		nodeClaim, err := s.newNodeClaimFor(pod, devices, nodeClaimTemplate, instanceTypes)
+		if err != nil {
 			errs = multierr.Append(errs, fmt.Errorf("incompatible with nodepool %q, daemonset overhead=%s, %w",
 				nodeClaimTemplate.NodePoolName,
 				resources.String(s.daemonOverhead[nodeClaimTemplate]),
 				err))
+			explanation.NodePools = append(explanation.NodePools, newNodePoolRejection(nodeClaimTemplate.NodePoolName, err))
 			continue
 		}
 		// we will launch this nodeClaim and need to track its maximum possible resource usage against our remaining resources
@@ -284,6 +370,35 @@ func (s *Scheduler) add(ctx context.Context, pod *v1.Pod) error {
 	return errs
 }
 
+// newNodeClaimFor creates a NodeClaim from the template that the pod is added to. NodeClaims that launch into capacity
+// reservations with room left are preferred, since that capacity has already been paid for.
+func (s *Scheduler) newNodeClaimFor(pod *v1.Pod, devices *scheduling.DeviceRequests, nodeClaimTemplate *NodeClaimTemplate, instanceTypes []*cloudprovider.InstanceType) (*NodeClaim, error) {
+	for _, offering := range s.reservations.Offerings(nodeClaimTemplate.Requirements, instanceTypes) {
+		nodeClaim := NewNodeClaim(nodeClaimTemplate, s.topology, s.daemonOverhead[nodeClaimTemplate], instanceTypes)
+		s.reservations.Pin(nodeClaim, offering)
+		if err := nodeClaim.Add(pod, devices); err == nil {
+			s.reservations.Reserve(nodeClaim)
+			return nodeClaim, nil
+		}
+	}
+	nodeClaim := NewNodeClaim(nodeClaimTemplate, s.topology, s.daemonOverhead[nodeClaimTemplate], instanceTypes)
+	s.reservations.Exclude(nodeClaim)
+	if err := nodeClaim.Add(pod, devices); err != nil {
+		return nil, err
+	}
+	return nodeClaim, nil
+}
+
+// getExplanation returns the explanation of why the pod couldn't be scheduled, which is built up as the pod is tried
+func (s *Scheduler) getExplanation(pod *v1.Pod) *Explanation {
+	explanation, ok := s.explanations[pod]
+	if !ok {
+		explanation = &Explanation{}
+		s.explanations[pod] = explanation
+	}
+	return explanation
+}
+
 func (s *Scheduler) calculateExistingNodeClaims(stateNodes []*state.StateNode, daemonSetPods []*v1.Pod) {
 	// create our existing nodes
 	for _, node := range stateNodes {
@@ -298,13 +413,13 @@ func (s *Scheduler) calculateExistingNodeClaims(stateNodes []*state.StateNode, d
 			}
 			daemons = append(daemons, p)
 		}
-		s.existingNodes = append(s.existingNodes, NewExistingNode(node, s.topology, resources.RequestsForPods(daemons...)))
+		s.existingNodes = append(s.existingNodes, NewExistingNode(node, s.topology, resources.RequestsForPods(daemons...), s.deviceCapacity(node)))
 
 		// We don't use the status field and instead recompute the remaining resources to ensure we have a consistent view
 		// of the cluster during scheduling.  Depending on how node creation falls out, this will also work for cases where
 		// we don't create NodeClaim resources.
 		if _, ok := s.remainingResources[node.Labels()[v1beta1.NodePoolLabelKey]]; ok {
-			s.remainingResources[node.Labels()[v1beta1.NodePoolLabelKey]] = resources.Subtract(s.remainingResources[node.Labels()[v1beta1.NodePoolLabelKey]], node.Capacity())
+			s.remainingResources[node.Labels()[v1beta1.NodePoolLabelKey]] = subtractNode(s.remainingResources[node.Labels()[v1beta1.NodePoolLabelKey]], node.Capacity())
 		}
 	}
 	// Order the existing nodes for scheduling with initialized nodes first
@@ -321,6 +436,80 @@ func (s *Scheduler) calculateExistingNodeClaims(stateNodes []*state.StateNode, d
 	})
 }
 
+// reserveNominatedCapacity holds back the capacity of the existing nodes that kube-scheduler nominated preempting pods
+// to. Their victims are being evicted to make room for them, so the capacity that's freed up isn't available to the
+// pods that we schedule, and we don't preempt pods again for the same capacity. Nominated pods haven't been bound yet,
+// so we only list the pods that aren't bound to a node.
+func (s *Scheduler) reserveNominatedCapacity(ctx context.Context) error {
+	podList := &v1.PodList{}
+	if err := s.kubeClient.List(ctx, podList, client.MatchingFields{"spec.nodeName": ""}); err != nil {
+		return fmt.Errorf("listing pods, %w", err)
+	}
+	var nodes map[string]*ExistingNode
+	for i := range podList.Items {
+		p := &podList.Items[i]
+		if p.Status.NominatedNodeName == "" || !pod.IsPreempting(p) || pod.IsScheduled(p) || pod.IsTerminal(p) {
+			continue
+		}
+		if nodes == nil {
+			nodes = lo.SliceToMap(s.existingNodes, func(n *ExistingNode) (string, *ExistingNode) { return n.Name(), n })
+		}
+		node, ok := nodes[p.Status.NominatedNodeName]
+		if !ok {
+			continue
+		}
+		node.requests = resources.Merge(node.requests, resources.RequestsForPods(p))
+		node.HostPortUsage().Add(p, scheduling.GetHostPorts(p))
+	}
+	return nil
+}
+
+// getDeviceRequests resolves the devices that are requested by the pod's resource claims. Pods are added many times
+// while we relax their preferences, so we only resolve them once.
+func (s *Scheduler) getDeviceRequests(ctx context.Context, pod *v1.Pod) (*scheduling.DeviceRequests, error) {
+	if devices, ok := s.deviceRequests[pod]; ok {
+		return devices, nil
+	}
+	devices, err := scheduling.GetDeviceRequests(ctx, s.kubeClient, pod)
+	if err != nil {
+		return nil, fmt.Errorf("resolving resource claims, %w", err)
+	}
+	s.deviceRequests[pod] = devices
+	return devices, nil
+}
+
+func (s *Scheduler) getDisruptionBudgets(ctx context.Context) (*DisruptionBudgets, error) {
+	if s.disruptionBudgets == nil {
+		budgets, err := NewDisruptionBudgets(ctx, s.kubeClient)
+		if err != nil {
+			return nil, err
+		}
+		s.disruptionBudgets = budgets
+	}
+	return s.disruptionBudgets, nil
+}
+
+func (s *Scheduler) getPodGroup(pod *v1.Pod) *podGroup {
+	if group, ok := s.podGroups[pod]; ok {
+		return group
+	}
+	group := newPodGroup(pod)
+	s.podGroups[pod] = group
+	return group
+}
+
+// deviceCapacity returns the devices that can be allocated on the node, which we know from its instance type. We
+// don't know about the devices on nodes that we didn't launch, so we don't schedule pods that need new devices to them.
+func (s *Scheduler) deviceCapacity(node *state.StateNode) map[string]int {
+	instanceType, ok := lo.Find(s.instanceTypes[node.Labels()[v1beta1.NodePoolLabelKey]], func(it *cloudprovider.InstanceType) bool {
+		return it.Name == node.Labels()[v1.LabelInstanceTypeStable]
+	})
+	if !ok {
+		return map[string]int{}
+	}
+	return instanceType.Devices.Capacity()
+}
+
 func getDaemonOverhead(nodeClaimTemplates []*NodeClaimTemplate, daemonSetPods []*v1.Pod) map[*NodeClaimTemplate]v1.ResourceList {
 	overhead := map[*NodeClaimTemplate]v1.ResourceList{}
 
@@ -353,25 +542,28 @@ func subtractMax(remaining v1.ResourceList, instanceTypes []*cloudprovider.Insta
 	for _, it := range instanceTypes {
 		allInstanceResources = append(allInstanceResources, it.Capacity)
 	}
+	return subtractNode(remaining, resources.MaxResources(allInstanceResources...))
+}
+
+// subtractNode returns the remaining resources after launching a node with the given capacity
+func subtractNode(remaining v1.ResourceList, capacity v1.ResourceList) v1.ResourceList {
 	result := v1.ResourceList{}
-	itResources := resources.MaxResources(allInstanceResources...)
 	for k, v := range remaining {
 		cp := v.DeepCopy()
-		cp.Sub(itResources[k])
+		cp.Sub(consumedBy(capacity, k))
 		result[k] = cp
 	}
 	return result
 }
 
-// filterByRemainingResources is used to filter out instance types that if launched would exceed the nodepool limits
-func filterByRemainingResources(instanceTypes []*cloudprovider.InstanceType, remaining v1.ResourceList) []*cloudprovider.InstanceType {
+// FilterByRemainingResources is used to filter out instance types that if launched would exceed the nodepool limits
+func FilterByRemainingResources(instanceTypes []*cloudprovider.InstanceType, remaining v1.ResourceList) []*cloudprovider.InstanceType {
 	var filtered []*cloudprovider.InstanceType
 	for _, it := range instanceTypes {
-		itResources := it.Capacity
 		viableInstance := true
 		for resourceName, remainingQuantity := range remaining {
 			// if the instance capacity is greater than the remaining quantity for this resource
-			if resources.Cmp(itResources[resourceName], remainingQuantity) > 0 {
+			if resources.Cmp(consumedBy(it.Capacity, resourceName), remainingQuantity) > 0 {
 				viableInstance = false
 			}
 		}
@@ -381,3 +573,12 @@ func filterByRemainingResources(instanceTypes []*cloudprovider.InstanceType, rem
 	}
 	return filtered
 }
+
+// consumedBy returns the quantity of a limited resource that a node with the given capacity uses up. Every node
+// uses a single unit of the "nodes" limit.
+func consumedBy(capacity v1.ResourceList, resourceName v1.ResourceName) resource.Quantity {
+	if resourceName == v1beta1.ResourceNodes {
+		return *resource.NewQuantity(1, resource.DecimalSI)
+	}
+	return capacity[resourceName]
+}
@@ -0,0 +1,161 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"math"
+	"sort"
+
+	"github.com/samber/lo"
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
+	"sigs.k8s.io/karpenter/pkg/cloudprovider"
+	"sigs.k8s.io/karpenter/pkg/scheduling"
+)
+
+// selectionTolerance is how far from the most preferred instance type that an instance type's score can be while
+// still being launched. Instance types of the same size from different families score slightly differently due to
+// overhead, and we want to give the cloud provider the flexibility to launch any of them.
+const selectionTolerance = 0.1
+
+// Strategy decides how the scheduler bin-packs pods onto the NodeClaims that it creates for a NodePool, and which of
+// their instance type options are launched
+type Strategy interface {
+	// Score ranks an in-flight NodeClaim. A pod is added to the in-flight NodeClaim with the lowest score that it fits on.
+	Score(nodeClaim *NodeClaim) float64
+	// Select returns the instance types, in order of preference, that should be launched for a NodeClaim with the
+	// requirements and requests. The cloud provider launches the cheapest of them.
+	Select(instanceTypes cloudprovider.InstanceTypes, requirements scheduling.Requirements, requests v1.ResourceList) cloudprovider.InstanceTypes
+}
+
+func NewStrategy(strategy v1beta1.SchedulingStrategy) Strategy {
+	switch strategy {
+	case v1beta1.SchedulingStrategyBestFit:
+		return BestFit{}
+	case v1beta1.SchedulingStrategyFewestNodes:
+		return FewestNodes{}
+	case v1beta1.SchedulingStrategyPricePerVCPU:
+		return PricePerVCPU{}
+	default:
+		return LowestPrice{}
+	}
+}
+
+// LowestPrice spreads pods across the in-flight NodeClaims and launches the cheapest instance types that fit them
+type LowestPrice struct{}
+
+func (LowestPrice) Score(nodeClaim *NodeClaim) float64 {
+	return float64(len(nodeClaim.Pods))
+}
+
+func (LowestPrice) Select(instanceTypes cloudprovider.InstanceTypes, requirements scheduling.Requirements, _ v1.ResourceList) cloudprovider.InstanceTypes {
+	return lo.Slice(instanceTypes.OrderByPrice(requirements), 0, MaxInstanceTypes)
+}
+
+// BestFit packs pods onto the in-flight NodeClaims that they utilize the most and launches the instance types that
+// the pods leave the least unused
+type BestFit struct{}
+
+func (BestFit) Score(nodeClaim *NodeClaim) float64 {
+	return -lo.Max(lo.Map(nodeClaim.InstanceTypeOptions, func(it *cloudprovider.InstanceType, _ int) float64 {
+		return utilization(nodeClaim.Spec.Resources.Requests, it.Allocatable())
+	}))
+}
+
+func (BestFit) Select(instanceTypes cloudprovider.InstanceTypes, requirements scheduling.Requirements, requests v1.ResourceList) cloudprovider.InstanceTypes {
+	return selectPreferred(instanceTypes, requirements, func(it *cloudprovider.InstanceType) float64 {
+		return utilization(requests, it.Allocatable())
+	})
+}
+
+// FewestNodes packs pods onto the fullest in-flight NodeClaims and launches the largest instance types, which
+// minimizes the number of nodes and the overhead of the daemons that run on each of them
+type FewestNodes struct{}
+
+func (FewestNodes) Score(nodeClaim *NodeClaim) float64 {
+	return -float64(len(nodeClaim.Pods))
+}
+
+func (FewestNodes) Select(instanceTypes cloudprovider.InstanceTypes, requirements scheduling.Requirements, _ v1.ResourceList) cloudprovider.InstanceTypes {
+	return selectPreferred(instanceTypes, requirements, func(it *cloudprovider.InstanceType) float64 {
+		return vcpus(it)
+	})
+}
+
+// PricePerVCPU spreads pods across the in-flight NodeClaims and launches the instance types with the lowest price
+// per vCPU, regardless of their size
+type PricePerVCPU struct{}
+
+func (PricePerVCPU) Score(nodeClaim *NodeClaim) float64 {
+	return float64(len(nodeClaim.Pods))
+}
+
+func (PricePerVCPU) Select(instanceTypes cloudprovider.InstanceTypes, requirements scheduling.Requirements, _ v1.ResourceList) cloudprovider.InstanceTypes {
+	return selectPreferred(instanceTypes, requirements, func(it *cloudprovider.InstanceType) float64 {
+		// score by vCPUs per unit of price so that higher scores are preferred
+		return vcpus(it) / price(it, requirements)
+	})
+}
+
+// selectPreferred orders the instance types by score, highest first, and keeps those that score within the selection
+// tolerance of the best of them. More instance types are kept if they're needed to satisfy minValues.
+func selectPreferred(instanceTypes cloudprovider.InstanceTypes, requirements scheduling.Requirements, score func(*cloudprovider.InstanceType) float64) cloudprovider.InstanceTypes {
+	if len(instanceTypes) == 0 {
+		return instanceTypes
+	}
+	scores := lo.SliceToMap(instanceTypes, func(it *cloudprovider.InstanceType) (*cloudprovider.InstanceType, float64) { return it, score(it) })
+	ordered := append(cloudprovider.InstanceTypes{}, instanceTypes...).OrderByPrice(requirements)
+	// stable so that instance types with the same score remain ordered by price
+	sort.SliceStable(ordered, func(i, j int) bool { return scores[ordered[i]] > scores[ordered[j]] })
+	best := scores[ordered[0]]
+	count := lo.CountBy(ordered, func(it *cloudprovider.InstanceType) bool { return scores[it] >= best-math.Abs(best)*selectionTolerance })
+	minNeeded, _ := ordered.SatisfiesMinValues(requirements)
+	return lo.Slice(ordered, 0, lo.Clamp(lo.Max([]int{count, minNeeded}), 1, MaxInstanceTypes))
+}
+
+// utilization is the average fraction of the allocatable cpu and memory that the requests use
+func utilization(requests, allocatable v1.ResourceList) float64 {
+	var total float64
+	var count int
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		capacity := allocatable[name]
+		if capacity.IsZero() {
+			continue
+		}
+		request := requests[name]
+		total += request.AsApproximateFloat64() / capacity.AsApproximateFloat64()
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / float64(count)
+}
+
+// price is the price of the cheapest offering of the instance type that's compatible with the requirements
+func price(it *cloudprovider.InstanceType, requirements scheduling.Requirements) float64 {
+	offerings := it.Offerings.Available().Compatible(requirements)
+	if len(offerings) == 0 {
+		return math.MaxFloat64
+	}
+	return offerings.Cheapest().Price
+}
+
+func vcpus(it *cloudprovider.InstanceType) float64 {
+	return it.Capacity.Cpu().AsApproximateFloat64()
+}
@@ -28,6 +28,9 @@ import (
 	"github.com/samber/lo"
 	v1 "k8s.io/api/core/v1"
 	nodev1 "k8s.io/api/node/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -118,12 +121,12 @@ var _ = Context("NodePool", func() {
 			Spec: v1beta1.NodePoolSpec{
 				Template: v1beta1.NodeClaimTemplate{
 					Spec: v1beta1.NodeClaimSpec{
-						Requirements: []v1.NodeSelectorRequirement{
-							{
+						Requirements: []v1beta1.NodeSelectorRequirementWithMinValues{
+							{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 								Key:      v1beta1.CapacityTypeLabelKey,
 								Operator: v1.NodeSelectorOpIn,
 								Values:   []string{v1beta1.CapacityTypeSpot, v1beta1.CapacityTypeOnDemand},
-							},
+							}},
 						},
 					},
 				},
@@ -184,8 +187,8 @@ var _ = Context("NodePool", func() {
 		})
 		Context("Well Known Labels", func() {
 			It("should use NodePool constraints", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-2"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-2"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod()
 				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
@@ -193,8 +196,8 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).To(HaveKeyWithValue(v1.LabelTopologyZone, "test-zone-2"))
 			})
 			It("should use node selectors", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1", "test-zone-2"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1", "test-zone-2"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeSelector: map[string]string{v1.LabelTopologyZone: "test-zone-2"}},
@@ -212,8 +215,8 @@ var _ = Context("NodePool", func() {
 				ExpectNotScheduled(ctx, env.Client, pod)
 			})
 			It("should not schedule the pod if nodeselector unknown", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeSelector: map[string]string{v1.LabelTopologyZone: "unknown"}},
@@ -222,8 +225,8 @@ var _ = Context("NodePool", func() {
 				ExpectNotScheduled(ctx, env.Client, pod)
 			})
 			It("should not schedule if node selector outside of NodePool constraints", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeSelector: map[string]string{v1.LabelTopologyZone: "test-zone-2"}},
@@ -243,9 +246,9 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).To(HaveKeyWithValue(v1.LabelTopologyZone, "test-zone-3"))
 			})
 			It("should schedule compatible requirements with Operator=Gt", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{{
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 					Key: fake.IntegerInstanceLabelKey, Operator: v1.NodeSelectorOpGt, Values: []string{"8"},
-				}}
+				}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod()
 				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
@@ -253,9 +256,9 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).To(HaveKeyWithValue(fake.IntegerInstanceLabelKey, "16"))
 			})
 			It("should schedule compatible requirements with Operator=Lt", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{{
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 					Key: fake.IntegerInstanceLabelKey, Operator: v1.NodeSelectorOpLt, Values: []string{"8"},
-				}}
+				}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod()
 				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
@@ -411,9 +414,9 @@ var _ = Context("NodePool", func() {
 				}
 			})
 			It("should schedule pods that have node selectors with label in restricted domains exceptions list", func() {
-				var requirements []v1.NodeSelectorRequirement
+				var requirements []v1beta1.NodeSelectorRequirementWithMinValues
 				for domain := range v1beta1.LabelDomainExceptions {
-					requirements = append(requirements, v1.NodeSelectorRequirement{Key: domain + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}})
+					requirements = append(requirements, v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: domain + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}})
 				}
 				nodePool.Spec.Template.Spec.Requirements = requirements
 				ExpectApplied(ctx, env.Client, nodePool)
@@ -425,9 +428,9 @@ var _ = Context("NodePool", func() {
 				}
 			})
 			It("should schedule pods that have node selectors with label in subdomain from restricted domains exceptions list", func() {
-				var requirements []v1.NodeSelectorRequirement
+				var requirements []v1beta1.NodeSelectorRequirementWithMinValues
 				for domain := range v1beta1.LabelDomainExceptions {
-					requirements = append(requirements, v1.NodeSelectorRequirement{Key: "subdomain." + domain + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}})
+					requirements = append(requirements, v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "subdomain." + domain + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}})
 				}
 				nodePool.Spec.Template.Spec.Requirements = requirements
 				ExpectApplied(ctx, env.Client, nodePool)
@@ -498,8 +501,8 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).ToNot(HaveKey("test-key"))
 			})
 			It("should schedule unconstrained pods that don't have matching node selectors", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod()
 				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
@@ -507,8 +510,8 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).To(HaveKeyWithValue("test-key", "test-value"))
 			})
 			It("should schedule pods that have node selectors with matching value and In operator", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeRequirements: []v1.NodeSelectorRequirement{
@@ -519,8 +522,8 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).To(HaveKeyWithValue("test-key", "test-value"))
 			})
 			It("should not schedule pods that have node selectors with matching value and NotIn operator", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeRequirements: []v1.NodeSelectorRequirement{
@@ -530,8 +533,8 @@ var _ = Context("NodePool", func() {
 				ExpectNotScheduled(ctx, env.Client, pod)
 			})
 			It("should schedule the pod with Exists operator and defined key", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeRequirements: []v1.NodeSelectorRequirement{
@@ -542,8 +545,8 @@ var _ = Context("NodePool", func() {
 				ExpectScheduled(ctx, env.Client, pod)
 			})
 			It("should not schedule the pod with DoesNotExists operator and defined key", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeRequirements: []v1.NodeSelectorRequirement{
@@ -554,8 +557,8 @@ var _ = Context("NodePool", func() {
 				ExpectNotScheduled(ctx, env.Client, pod)
 			})
 			It("should not schedule pods that have node selectors with different value and In operator", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeRequirements: []v1.NodeSelectorRequirement{
@@ -565,8 +568,8 @@ var _ = Context("NodePool", func() {
 				ExpectNotScheduled(ctx, env.Client, pod)
 			})
 			It("should schedule pods that have node selectors with different value and NotIn operator", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeRequirements: []v1.NodeSelectorRequirement{
@@ -577,8 +580,8 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).To(HaveKeyWithValue("test-key", "test-value"))
 			})
 			It("should schedule compatible pods to the same node", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value", "another-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value", "another-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pods := []*v1.Pod{
 					test.UnschedulablePod(
@@ -597,8 +600,8 @@ var _ = Context("NodePool", func() {
 				Expect(node1.Name).To(Equal(node2.Name))
 			})
 			It("should schedule incompatible pods to the different node", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value", "another-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value", "another-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pods := []*v1.Pod{
 					test.UnschedulablePod(
@@ -630,8 +633,8 @@ var _ = Context("NodePool", func() {
 		})
 		Context("Well Known Labels", func() {
 			It("should use NodePool constraints", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-2"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-2"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod()
 				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
@@ -639,8 +642,8 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).To(HaveKeyWithValue(v1.LabelTopologyZone, "test-zone-2"))
 			})
 			It("should use node selectors", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1", "test-zone-2"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1", "test-zone-2"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeSelector: map[string]string{v1.LabelTopologyZone: "test-zone-2"}},
@@ -658,8 +661,8 @@ var _ = Context("NodePool", func() {
 				ExpectNotScheduled(ctx, env.Client, pod)
 			})
 			It("should not schedule the pod if nodeselector unknown", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeSelector: map[string]string{v1.LabelTopologyZone: "unknown"}},
@@ -668,8 +671,8 @@ var _ = Context("NodePool", func() {
 				ExpectNotScheduled(ctx, env.Client, pod)
 			})
 			It("should not schedule if node selector outside of NodePool constraints", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeSelector: map[string]string{v1.LabelTopologyZone: "test-zone-2"}},
@@ -689,9 +692,9 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).To(HaveKeyWithValue(v1.LabelTopologyZone, "test-zone-3"))
 			})
 			It("should schedule compatible requirements with Operator=Gt", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{{
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 					Key: fake.IntegerInstanceLabelKey, Operator: v1.NodeSelectorOpGt, Values: []string{"8"},
-				}}
+				}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod()
 				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
@@ -699,9 +702,9 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).To(HaveKeyWithValue(fake.IntegerInstanceLabelKey, "16"))
 			})
 			It("should schedule compatible requirements with Operator=Lt", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{{
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 					Key: fake.IntegerInstanceLabelKey, Operator: v1.NodeSelectorOpLt, Values: []string{"8"},
-				}}
+				}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod()
 				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
@@ -857,9 +860,9 @@ var _ = Context("NodePool", func() {
 				}
 			})
 			It("should schedule pods that have node selectors with label in restricted domains exceptions list", func() {
-				var requirements []v1.NodeSelectorRequirement
+				var requirements []v1beta1.NodeSelectorRequirementWithMinValues
 				for domain := range v1beta1.LabelDomainExceptions {
-					requirements = append(requirements, v1.NodeSelectorRequirement{Key: domain + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}})
+					requirements = append(requirements, v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: domain + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}})
 				}
 				nodePool.Spec.Template.Spec.Requirements = requirements
 				ExpectApplied(ctx, env.Client, nodePool)
@@ -871,9 +874,9 @@ var _ = Context("NodePool", func() {
 				}
 			})
 			It("should schedule pods that have node selectors with label in subdomain from restricted domains exceptions list", func() {
-				var requirements []v1.NodeSelectorRequirement
+				var requirements []v1beta1.NodeSelectorRequirementWithMinValues
 				for domain := range v1beta1.LabelDomainExceptions {
-					requirements = append(requirements, v1.NodeSelectorRequirement{Key: "subdomain." + domain + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}})
+					requirements = append(requirements, v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "subdomain." + domain + "/test", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}})
 				}
 				nodePool.Spec.Template.Spec.Requirements = requirements
 				ExpectApplied(ctx, env.Client, nodePool)
@@ -944,8 +947,8 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).ToNot(HaveKey("test-key"))
 			})
 			It("should schedule unconstrained pods that don't have matching node selectors", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod()
 				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
@@ -953,8 +956,8 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).To(HaveKeyWithValue("test-key", "test-value"))
 			})
 			It("should schedule pods that have node selectors with matching value and In operator", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeRequirements: []v1.NodeSelectorRequirement{
@@ -965,8 +968,8 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).To(HaveKeyWithValue("test-key", "test-value"))
 			})
 			It("should not schedule pods that have node selectors with matching value and NotIn operator", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeRequirements: []v1.NodeSelectorRequirement{
@@ -976,8 +979,8 @@ var _ = Context("NodePool", func() {
 				ExpectNotScheduled(ctx, env.Client, pod)
 			})
 			It("should schedule the pod with Exists operator and defined key", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeRequirements: []v1.NodeSelectorRequirement{
@@ -988,8 +991,8 @@ var _ = Context("NodePool", func() {
 				ExpectScheduled(ctx, env.Client, pod)
 			})
 			It("should not schedule the pod with DoesNotExists operator and defined key", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeRequirements: []v1.NodeSelectorRequirement{
@@ -1000,8 +1003,8 @@ var _ = Context("NodePool", func() {
 				ExpectNotScheduled(ctx, env.Client, pod)
 			})
 			It("should not schedule pods that have node selectors with different value and In operator", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeRequirements: []v1.NodeSelectorRequirement{
@@ -1011,8 +1014,8 @@ var _ = Context("NodePool", func() {
 				ExpectNotScheduled(ctx, env.Client, pod)
 			})
 			It("should schedule pods that have node selectors with different value and NotIn operator", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pod := test.UnschedulablePod(
 					test.PodOptions{NodeRequirements: []v1.NodeSelectorRequirement{
@@ -1023,8 +1026,8 @@ var _ = Context("NodePool", func() {
 				Expect(node.Labels).To(HaveKeyWithValue("test-key", "test-value"))
 			})
 			It("should schedule compatible pods to the same node", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value", "another-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value", "another-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pods := []*v1.Pod{
 					test.UnschedulablePod(
@@ -1043,8 +1046,8 @@ var _ = Context("NodePool", func() {
 				Expect(node1.Name).To(Equal(node2.Name))
 			})
 			It("should schedule incompatible pods to the different node", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value", "another-value"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value", "another-value"}}}}
 				ExpectApplied(ctx, env.Client, nodePool)
 				pods := []*v1.Pod{
 					test.UnschedulablePod(
@@ -1081,9 +1084,9 @@ var _ = Context("NodePool", func() {
 	Describe("Preferential Fallback", func() {
 		Context("Required", func() {
 			It("should not relax the final term", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}},
-					{Key: v1.LabelInstanceTypeStable, Operator: v1.NodeSelectorOpIn, Values: []string{"default-instance-type"}},
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}},
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelInstanceTypeStable, Operator: v1.NodeSelectorOpIn, Values: []string{"default-instance-type"}}},
 				}
 				pod := test.UnschedulablePod()
 				pod.Spec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{
@@ -1140,8 +1143,8 @@ var _ = Context("NodePool", func() {
 				ExpectScheduled(ctx, env.Client, pod)
 			})
 			It("should relax to use lighter weights", func() {
-				nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{
-					{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1", "test-zone-2"}}}
+				nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1", "test-zone-2"}}}}
 				pod := test.UnschedulablePod()
 				pod.Spec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{PreferredDuringSchedulingIgnoredDuringExecution: []v1.PreferredSchedulingTerm{
 					{
@@ -1212,11 +1215,11 @@ var _ = Context("NodePool", func() {
 			ExpectNotScheduled(ctx, env.Client, pod)
 		})
 		It("should launch pods with different archs on different instances", func() {
-			nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{{
+			nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelArchStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.ArchitectureArm64, v1beta1.ArchitectureAmd64},
-			}}
+			}}}
 			nodeNames := sets.NewString()
 			ExpectApplied(ctx, env.Client, nodePool)
 			pods := []*v1.Pod{
@@ -1235,11 +1238,11 @@ var _ = Context("NodePool", func() {
 			Expect(nodeNames.Len()).To(Equal(2))
 		})
 		It("should exclude instance types that are not supported by the pod constraints (node affinity/instance type)", func() {
-			nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{{
+			nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelArchStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.ArchitectureAmd64},
-			}}
+			}}}
 			ExpectApplied(ctx, env.Client, nodePool)
 			pod := test.UnschedulablePod(test.PodOptions{
 				NodeRequirements: []v1.NodeSelectorRequirement{
@@ -1254,11 +1257,11 @@ var _ = Context("NodePool", func() {
 			ExpectNotScheduled(ctx, env.Client, pod)
 		})
 		It("should exclude instance types that are not supported by the pod constraints (node affinity/operating system)", func() {
-			nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{{
+			nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelArchStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.ArchitectureAmd64},
-			}}
+			}}}
 			ExpectApplied(ctx, env.Client, nodePool)
 			pod := test.UnschedulablePod(test.PodOptions{
 				NodeRequirements: []v1.NodeSelectorRequirement{
@@ -1274,11 +1277,11 @@ var _ = Context("NodePool", func() {
 			ExpectNotScheduled(ctx, env.Client, pod)
 		})
 		It("should exclude instance types that are not supported by the provider constraints (arch)", func() {
-			nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{{
+			nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelArchStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.ArchitectureAmd64},
-			}}
+			}}}
 			ExpectApplied(ctx, env.Client, nodePool)
 			pod := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
 				Limits: map[v1.ResourceName]resource.Quantity{v1.ResourceCPU: resource.MustParse("14")}}})
@@ -1287,11 +1290,11 @@ var _ = Context("NodePool", func() {
 			ExpectNotScheduled(ctx, env.Client, pod)
 		})
 		It("should launch pods with different operating systems on different instances", func() {
-			nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{{
+			nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelArchStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.ArchitectureArm64, v1beta1.ArchitectureAmd64},
-			}}
+			}}}
 			nodeNames := sets.NewString()
 			ExpectApplied(ctx, env.Client, nodePool)
 			pods := []*v1.Pod{
@@ -1310,11 +1313,11 @@ var _ = Context("NodePool", func() {
 			Expect(nodeNames.Len()).To(Equal(2))
 		})
 		It("should launch pods with different instance type node selectors on different instances", func() {
-			nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{{
+			nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelArchStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.ArchitectureArm64, v1beta1.ArchitectureAmd64},
-			}}
+			}}}
 			nodeNames := sets.NewString()
 			ExpectApplied(ctx, env.Client, nodePool)
 			pods := []*v1.Pod{
@@ -1333,11 +1336,11 @@ var _ = Context("NodePool", func() {
 			Expect(nodeNames.Len()).To(Equal(2))
 		})
 		It("should launch pods with different zone selectors on different instances", func() {
-			nodePool.Spec.Template.Spec.Requirements = []v1.NodeSelectorRequirement{{
+			nodePool.Spec.Template.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 				Key:      v1.LabelArchStable,
 				Operator: v1.NodeSelectorOpIn,
 				Values:   []string{v1beta1.ArchitectureArm64, v1beta1.ArchitectureAmd64},
-			}}
+			}}}
 			nodeNames := sets.NewString()
 			ExpectApplied(ctx, env.Client, nodePool)
 			pods := []*v1.Pod{
@@ -1459,6 +1462,91 @@ var _ = Context("NodePool", func() {
 		})
 	})
 
+	Describe("MinValues", func() {
+		BeforeEach(func() {
+			cloudProvider.InstanceTypes = lo.Map([]int{2, 4, 8, 16, 32}, func(cpu int, _ int) *cloudprovider.InstanceType {
+				return fake.NewInstanceType(fake.InstanceTypeOptions{
+					Name: fmt.Sprintf("instance-type-%d-cpu", cpu),
+					Resources: v1.ResourceList{
+						v1.ResourceCPU:  resource.MustParse(fmt.Sprint(cpu)),
+						v1.ResourcePods: resource.MustParse("10"),
+					},
+				})
+			})
+			nodePool.Spec.Template.Spec.Requirements = append(nodePool.Spec.Template.Spec.Requirements, v1beta1.NodeSelectorRequirementWithMinValues{
+				NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelInstanceTypeStable, Operator: v1.NodeSelectorOpExists},
+				MinValues:               lo.ToPtr(3),
+			})
+		})
+		It("should launch a nodeclaim with at least minValues instance types", func() {
+			ExpectApplied(ctx, env.Client, nodePool)
+			pod := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+
+			Expect(cloudProvider.CreateCalls).To(HaveLen(1))
+			requirement, ok := lo.Find(cloudProvider.CreateCalls[0].Spec.Requirements, func(r v1beta1.NodeSelectorRequirementWithMinValues) bool {
+				return r.Key == v1.LabelInstanceTypeStable
+			})
+			Expect(ok).To(BeTrue())
+			Expect(len(requirement.Values)).To(BeNumerically(">=", 3))
+			Expect(requirement.MinValues).To(Equal(lo.ToPtr(3)))
+		})
+		It("should not pack pods onto a nodeclaim if it would narrow the instance types below minValues", func() {
+			ExpectApplied(ctx, env.Client, nodePool)
+			// Each pod fits on at least three instance types on its own, but together they only fit on two
+			pods := []*v1.Pod{
+				test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("6")}}}),
+				test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("3")}}}),
+			}
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pods...)
+			Expect(ExpectScheduled(ctx, env.Client, pods[0]).Name).ToNot(Equal(ExpectScheduled(ctx, env.Client, pods[1]).Name))
+			Expect(cloudProvider.CreateCalls).To(HaveLen(2))
+		})
+		It("should pack pods onto a single nodeclaim without minValues", func() {
+			nodePool.Spec.Template.Spec.Requirements = lo.Reject(nodePool.Spec.Template.Spec.Requirements, func(r v1beta1.NodeSelectorRequirementWithMinValues, _ int) bool {
+				return r.MinValues != nil
+			})
+			ExpectApplied(ctx, env.Client, nodePool)
+			pods := []*v1.Pod{
+				test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("6")}}}),
+				test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("3")}}}),
+			}
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pods...)
+			Expect(ExpectScheduled(ctx, env.Client, pods[0]).Name).To(Equal(ExpectScheduled(ctx, env.Client, pods[1]).Name))
+		})
+		It("should not schedule a pod that can only fit on fewer than minValues instance types", func() {
+			ExpectApplied(ctx, env.Client, nodePool)
+			pod := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("12")}}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectNotScheduled(ctx, env.Client, pod)
+		})
+		It("should fall back to a lower weighted nodepool when minValues can't be satisfied", func() {
+			nodePool.Spec.Weight = lo.ToPtr[int32](100)
+			fallback := test.NodePool()
+			ExpectApplied(ctx, env.Client, nodePool, fallback)
+			pod := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("12")}}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels).To(HaveKeyWithValue(v1beta1.NodePoolLabelKey, fallback.Name))
+		})
+		It("should respect minValues for keys other than the instance type", func() {
+			nodePool.Spec.Template.Spec.Requirements = append(nodePool.Spec.Template.Spec.Requirements, v1beta1.NodeSelectorRequirementWithMinValues{
+				NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: fake.IntegerInstanceLabelKey, Operator: v1.NodeSelectorOpIn, Values: []string{"2", "4", "8", "16", "32"}},
+				MinValues:               lo.ToPtr(5),
+			})
+			ExpectApplied(ctx, env.Client, nodePool)
+			pod := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+
+			// A pod that would rule out the smallest instance type can no longer satisfy minValues
+			pod = test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("3")}}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectNotScheduled(ctx, env.Client, pod)
+		})
+	})
+
 	Describe("Binpacking", func() {
 		It("should schedule a small pod on the smallest instance", func() {
 			ExpectApplied(ctx, env.Client, nodePool)
@@ -1765,7 +1853,7 @@ var _ = Context("NodePool", func() {
 			// large is the cheapest, so we should pick it, but the other two types are also valid options
 			Expect(node.Labels[v1.LabelInstanceTypeStable]).To(Equal("large"))
 			// all three options should be passed to the cloud provider
-			possibleInstanceType := sets.NewString(pscheduling.NewNodeSelectorRequirements(cloudProvider.CreateCalls[0].Spec.Requirements...).Get(v1.LabelInstanceTypeStable).Values()...)
+			possibleInstanceType := sets.NewString(pscheduling.NewNodeSelectorRequirementsWithMinValues(cloudProvider.CreateCalls[0].Spec.Requirements...).Get(v1.LabelInstanceTypeStable).Values()...)
 			Expect(possibleInstanceType).To(Equal(sets.NewString("small", "medium", "large")))
 		})
 	})
@@ -2559,6 +2647,331 @@ var _ = Context("NodePool", func() {
 		})
 	})
 
+	Describe("Dynamic Resource Allocation", func() {
+		var resourceClass *resourcev1alpha2.ResourceClass
+		BeforeEach(func() {
+			cloudProvider.InstanceTypes = []*cloudprovider.InstanceType{
+				fake.NewInstanceType(fake.InstanceTypeOptions{Name: "no-devices"}),
+				fake.NewInstanceType(fake.InstanceTypeOptions{
+					Name:    "single-device",
+					Devices: cloudprovider.Devices{{Driver: fake.DeviceDriver, Count: 1}},
+				}),
+				fake.NewInstanceType(fake.InstanceTypeOptions{
+					Name: "double-device",
+					Resources: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("8"),
+						v1.ResourceMemory: resource.MustParse("8Gi"),
+					},
+					Devices: cloudprovider.Devices{{Driver: fake.DeviceDriver, Count: 2}},
+				}),
+			}
+			resourceClass = &resourcev1alpha2.ResourceClass{ObjectMeta: test.ObjectMeta(), DriverName: fake.DeviceDriver}
+			ExpectApplied(ctx, env.Client, resourceClass, nodePool)
+		})
+		podWithClaims := func(claimNames ...string) *v1.Pod {
+			pod := test.UnschedulablePod()
+			for i := range claimNames {
+				pod.Spec.ResourceClaims = append(pod.Spec.ResourceClaims, v1.PodResourceClaim{
+					Name:   fmt.Sprintf("device-%d", i),
+					Source: v1.ClaimSource{ResourceClaimName: lo.ToPtr(claimNames[i])},
+				})
+			}
+			return pod
+		}
+		resourceClaim := func() *resourcev1alpha2.ResourceClaim {
+			claim := &resourcev1alpha2.ResourceClaim{
+				ObjectMeta: test.ObjectMeta(),
+				Spec:       resourcev1alpha2.ResourceClaimSpec{ResourceClassName: resourceClass.Name},
+			}
+			ExpectApplied(ctx, env.Client, claim)
+			return claim
+		}
+		It("should launch an instance type with a device for the pod's resource claim", func() {
+			pod := podWithClaims(resourceClaim().Name)
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels[v1.LabelInstanceTypeStable]).To(Equal("single-device"))
+		})
+		It("should model resource claims that haven't been created from their template yet", func() {
+			template := &resourcev1alpha2.ResourceClaimTemplate{
+				ObjectMeta: test.ObjectMeta(),
+				Spec: resourcev1alpha2.ResourceClaimTemplateSpec{
+					Spec: resourcev1alpha2.ResourceClaimSpec{ResourceClassName: resourceClass.Name},
+				},
+			}
+			ExpectApplied(ctx, env.Client, template)
+			pod := test.UnschedulablePod()
+			pod.Spec.ResourceClaims = []v1.PodResourceClaim{{
+				Name:   "device",
+				Source: v1.ClaimSource{ResourceClaimTemplateName: lo.ToPtr(template.Name)},
+			}}
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels[v1.LabelInstanceTypeStable]).To(Equal("single-device"))
+		})
+		It("should launch an instance type with enough devices for every claim on the node", func() {
+			pods := []*v1.Pod{podWithClaims(resourceClaim().Name), podWithClaims(resourceClaim().Name)}
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pods...)
+			node := ExpectScheduled(ctx, env.Client, pods[0])
+			Expect(node.Labels[v1.LabelInstanceTypeStable]).To(Equal("double-device"))
+			Expect(ExpectScheduled(ctx, env.Client, pods[1]).Name).To(Equal(node.Name))
+		})
+		It("should only need a single device for a claim that is shared between pods", func() {
+			claim := resourceClaim()
+			pods := []*v1.Pod{podWithClaims(claim.Name), podWithClaims(claim.Name)}
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pods...)
+			node := ExpectScheduled(ctx, env.Client, pods[0])
+			Expect(node.Labels[v1.LabelInstanceTypeStable]).To(Equal("single-device"))
+			Expect(ExpectScheduled(ctx, env.Client, pods[1]).Name).To(Equal(node.Name))
+		})
+		It("should not schedule a pod that needs more devices than any instance type has", func() {
+			pod := podWithClaims(resourceClaim().Name, resourceClaim().Name, resourceClaim().Name)
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectNotScheduled(ctx, env.Client, pod)
+		})
+		It("should schedule a pod to a node that is constrained by the resource class", func() {
+			resourceClass.SuitableNodes = &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{{
+				MatchExpressions: []v1.NodeSelectorRequirement{{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-2"}}},
+			}}}
+			ExpectApplied(ctx, env.Client, resourceClass)
+			pod := podWithClaims(resourceClaim().Name)
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels).To(HaveKeyWithValue(v1.LabelTopologyZone, "test-zone-2"))
+		})
+		It("should not schedule a pod with a resource claim to an existing node that doesn't have devices", func() {
+			node := test.Node(test.NodeOptions{
+				Allocatable: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("10"),
+					v1.ResourceMemory: resource.MustParse("10Gi"),
+					v1.ResourcePods:   resource.MustParse("110"),
+				},
+			})
+			ExpectApplied(ctx, env.Client, node)
+			ExpectMakeNodesInitialized(ctx, env.Client, node)
+			ExpectReconcileSucceeded(ctx, nodeStateController, client.ObjectKeyFromObject(node))
+
+			pod := podWithClaims(resourceClaim().Name)
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			scheduledNode := ExpectScheduled(ctx, env.Client, pod)
+			Expect(scheduledNode.Name).ToNot(Equal(node.Name))
+			Expect(scheduledNode.Labels[v1.LabelInstanceTypeStable]).To(Equal("single-device"))
+		})
+	})
+
+	Describe("Priority and Preemption", func() {
+		var node *v1.Node
+		var lowPriorityPod *v1.Pod
+		BeforeEach(func() {
+			ExpectApplied(ctx, env.Client,
+				&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "low"}, Value: 10},
+				&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "high"}, Value: 1000},
+				&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "high-non-preempting"}, Value: 1000, PreemptionPolicy: lo.ToPtr(v1.PreemptNever)},
+			)
+			node = test.Node(test.NodeOptions{
+				Allocatable: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("10"),
+					v1.ResourceMemory: resource.MustParse("10Gi"),
+					v1.ResourcePods:   resource.MustParse("110"),
+				},
+			})
+			ExpectApplied(ctx, env.Client, node)
+			ExpectMakeNodesInitialized(ctx, env.Client, node)
+			ExpectReconcileSucceeded(ctx, nodeStateController, client.ObjectKeyFromObject(node))
+
+			// a low priority pod fills up the existing node
+			lowPriorityPod = test.Pod(test.PodOptions{
+				NodeName:          node.Name,
+				PriorityClassName: "low",
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")},
+				},
+			})
+			ExpectApplied(ctx, env.Client, lowPriorityPod)
+			ExpectReconcileSucceeded(ctx, podStateController, client.ObjectKeyFromObject(lowPriorityPod))
+			ExpectApplied(ctx, env.Client, nodePool)
+		})
+		It("should schedule higher priority pods first", func() {
+			large := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")},
+			}})
+			large.Spec.Priority = lo.ToPtr[int32](10)
+			small := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			}})
+			small.Spec.Priority = lo.ToPtr[int32](1000)
+			q := scheduling.NewQueue(large, small)
+			p, ok := q.Pop()
+			Expect(ok).To(BeTrue())
+			Expect(p.UID).To(Equal(small.UID))
+		})
+		It("should preempt lower priority pods on an existing node instead of launching a node for the pod", func() {
+			pod := test.UnschedulablePod(test.PodOptions{
+				PriorityClassName: "high",
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")},
+				},
+			})
+			bindings := ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			Expect(ExpectScheduled(ctx, env.Client, pod).Name).To(Equal(node.Name))
+			// we launch a node for the pod that is displaced
+			Expect(cloudProvider.CreateCalls).To(HaveLen(1))
+			Expect(bindings.Get(lowPriorityPod)).ToNot(BeNil())
+			Expect(bindings.Get(lowPriorityPod).Node.Name).ToNot(Equal(node.Name))
+		})
+		It("should not preempt pods when the pod's preemption policy is Never", func() {
+			pod := test.UnschedulablePod(test.PodOptions{
+				PriorityClassName: "high-non-preempting",
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")},
+				},
+			})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			Expect(ExpectScheduled(ctx, env.Client, pod).Name).ToNot(Equal(node.Name))
+			Expect(cloudProvider.CreateCalls).To(HaveLen(1))
+		})
+		It("should not preempt pods with an equal or higher priority", func() {
+			pod := test.UnschedulablePod(test.PodOptions{
+				PriorityClassName: "low",
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")},
+				},
+			})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			Expect(ExpectScheduled(ctx, env.Client, pod).Name).ToNot(Equal(node.Name))
+			Expect(cloudProvider.CreateCalls).To(HaveLen(1))
+		})
+		It("should only preempt as many pods as are needed to fit the pod", func() {
+			// fill the rest of the node with another low priority pod that doesn't need to be preempted
+			other := test.Pod(test.PodOptions{
+				NodeName:          node.Name,
+				PriorityClassName: "low",
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+				},
+			})
+			ExpectApplied(ctx, env.Client, other)
+			ExpectReconcileSucceeded(ctx, podStateController, client.ObjectKeyFromObject(other))
+
+			pod := test.UnschedulablePod(test.PodOptions{
+				PriorityClassName: "high",
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")},
+				},
+			})
+			bindings := ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			Expect(ExpectScheduled(ctx, env.Client, pod).Name).To(Equal(node.Name))
+			Expect(bindings.Get(lowPriorityPod)).ToNot(BeNil())
+			Expect(bindings.Get(other)).To(BeNil())
+		})
+		It("should not count preempted pods towards topology spread on the node that they're preempted from", func() {
+			node.Labels[v1.LabelTopologyZone] = "test-zone-1"
+			ExpectApplied(ctx, env.Client, node)
+			ExpectReconcileSucceeded(ctx, nodeStateController, client.ObjectKeyFromObject(node))
+			lowPriorityPod.Labels = map[string]string{"app": "spread"}
+			ExpectApplied(ctx, env.Client, lowPriorityPod)
+			ExpectReconcileSucceeded(ctx, podStateController, client.ObjectKeyFromObject(lowPriorityPod))
+
+			pod := test.UnschedulablePod(test.PodOptions{
+				PriorityClassName: "high",
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")},
+				},
+			})
+			// the spread is counted across every zone, so the pod can only schedule to zone-1 if the preempted pod no
+			// longer counts there
+			spread := test.UnschedulablePod(test.PodOptions{
+				ObjectMeta:        metav1.ObjectMeta{Labels: map[string]string{"app": "spread"}},
+				PriorityClassName: "low",
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")},
+				},
+				NodeRequirements: []v1.NodeSelectorRequirement{{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}},
+				TopologySpreadConstraints: []v1.TopologySpreadConstraint{{
+					TopologyKey:        v1.LabelTopologyZone,
+					WhenUnsatisfiable:  v1.DoNotSchedule,
+					LabelSelector:      &metav1.LabelSelector{MatchLabels: map[string]string{"app": "spread"}},
+					MaxSkew:            1,
+					NodeAffinityPolicy: lo.ToPtr(v1.NodeInclusionPolicyIgnore),
+				}},
+			})
+			bindings := ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod, spread)
+			Expect(ExpectScheduled(ctx, env.Client, pod).Name).To(Equal(node.Name))
+			Expect(ExpectScheduled(ctx, env.Client, spread).Labels).To(HaveKeyWithValue(v1.LabelTopologyZone, "test-zone-1"))
+			Expect(bindings.Get(lowPriorityPod)).ToNot(BeNil())
+		})
+		It("should not preempt pods that their disruption budgets protect", func() {
+			lowPriorityPod.Labels = map[string]string{"app": "protected"}
+			ExpectApplied(ctx, env.Client, lowPriorityPod)
+			ExpectReconcileSucceeded(ctx, podStateController, client.ObjectKeyFromObject(lowPriorityPod))
+			ExpectApplied(ctx, env.Client, test.PodDisruptionBudget(test.PDBOptions{
+				Labels: map[string]string{"app": "protected"},
+				Status: &policyv1.PodDisruptionBudgetStatus{ObservedGeneration: 1, DisruptionsAllowed: 0},
+			}))
+
+			pod := test.UnschedulablePod(test.PodOptions{
+				PriorityClassName: "high",
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")},
+				},
+			})
+			bindings := ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			Expect(ExpectScheduled(ctx, env.Client, pod).Name).ToNot(Equal(node.Name))
+			Expect(bindings.Get(lowPriorityPod)).To(BeNil())
+		})
+		It("should not preempt more pods than their disruption budgets allow together", func() {
+			lowPriorityPod.Labels = map[string]string{"app": "protected"}
+			ExpectApplied(ctx, env.Client, lowPriorityPod)
+			ExpectReconcileSucceeded(ctx, podStateController, client.ObjectKeyFromObject(lowPriorityPod))
+			other := test.Pod(test.PodOptions{
+				ObjectMeta:        metav1.ObjectMeta{Labels: map[string]string{"app": "protected"}},
+				NodeName:          node.Name,
+				PriorityClassName: "low",
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1.5")},
+				},
+			})
+			ExpectApplied(ctx, env.Client, other)
+			ExpectReconcileSucceeded(ctx, podStateController, client.ObjectKeyFromObject(other))
+			// the budget allows either pod to be evicted, but not both of them
+			ExpectApplied(ctx, env.Client, test.PodDisruptionBudget(test.PDBOptions{
+				Labels: map[string]string{"app": "protected"},
+				Status: &policyv1.PodDisruptionBudgetStatus{ObservedGeneration: 1, DisruptionsAllowed: 1},
+			}))
+
+			// the pod only fits on the node if both pods are evicted
+			pod := test.UnschedulablePod(test.PodOptions{
+				PriorityClassName: "high",
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("9")},
+				},
+			})
+			bindings := ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			Expect(ExpectScheduled(ctx, env.Client, pod).Name).ToNot(Equal(node.Name))
+			Expect(bindings.Get(lowPriorityPod)).To(BeNil())
+			Expect(bindings.Get(other)).To(BeNil())
+		})
+		It("should hold back the capacity that preempting pods are nominated to", func() {
+			preempting := test.UnschedulablePod(test.PodOptions{
+				PriorityClassName: "high",
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+				},
+			})
+			preempting.Status.NominatedNodeName = node.Name
+			ExpectApplied(ctx, env.Client, preempting)
+
+			// the pod would fit in the capacity that's left on the node if the preempting pod wasn't taking it
+			pod := test.UnschedulablePod(test.PodOptions{
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1.5")},
+				},
+			})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			Expect(ExpectScheduled(ctx, env.Client, pod).Name).ToNot(Equal(node.Name))
+		})
+	})
+
 	Describe("No Pre-Binding", func() {
 		It("should not bind pods to nodes", func() {
 			opts := test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
@@ -3647,6 +4060,16 @@ func ExpectMaxSkew(ctx context.Context, c client.Client, namespace string, const
 	return Expect(maxCount - minCount)
 }
 
+// ExpectZones returns the zones of the nodes that the pods scheduled to
+func ExpectZones(ctx context.Context, c client.Client, pods ...*v1.Pod) sets.Set[string] {
+	GinkgoHelper()
+	zones := sets.New[string]()
+	for _, pod := range pods {
+		zones.Insert(ExpectScheduled(ctx, c, pod).Labels[v1.LabelTopologyZone])
+	}
+	return zones
+}
+
 func ExpectDeleteAllUnscheduledPods(ctx2 context.Context, c client.Client) {
 	var pods v1.PodList
 	Expect(c.List(ctx2, &pods)).To(Succeed())
@@ -3660,7 +4083,7 @@ func ExpectDeleteAllUnscheduledPods(ctx2 context.Context, c client.Client) {
 // Functions below this line are used for the instance type selection testing
 // -----------
 func supportedInstanceTypes(nodeClaim *v1beta1.NodeClaim) (res []*cloudprovider.InstanceType) {
-	reqs := pscheduling.NewNodeSelectorRequirements(nodeClaim.Spec.Requirements...)
+	reqs := pscheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaim.Spec.Requirements...)
 	return lo.Filter(cloudProvider.InstanceTypes, func(i *cloudprovider.InstanceType, _ int) bool {
 		return reqs.Get(v1.LabelInstanceTypeStable).Has(i.Name)
 	})
@@ -57,6 +57,10 @@ type Topology struct {
 	// moving pods to prevent them from being double counted.
 	excludedPods sets.Set[string]
 	cluster      *state.Cluster
+	// podsByNamespace and nodesByName cache the pods and nodes that topology groups are counted against, so that we
+	// don't list the same pods for every topology group that we count
+	podsByNamespace map[string][]v1.Pod
+	nodesByName     map[string]*v1.Node
 }
 
 func NewTopology(ctx context.Context, kubeClient client.Client, cluster *state.Cluster, domains map[string]sets.Set[string], pods []*v1.Pod) (*Topology, error) {
@@ -67,6 +71,8 @@ func NewTopology(ctx context.Context, kubeClient client.Client, cluster *state.C
 		topologies:        map[uint64]*TopologyGroup{},
 		inverseTopologies: map[uint64]*TopologyGroup{},
 		excludedPods:      sets.New[string](),
+		podsByNamespace:   map[string][]v1.Pod{},
+		nodesByName:       map[string]*v1.Node{},
 	}
 
 	// these are the pods that we intend to schedule, so if they are currently in the cluster we shouldn't count them for
@@ -135,11 +141,11 @@ func (t *Topology) Update(ctx context.Context, p *v1.Pod) error {
 	return nil
 }
 
-// Record records the topology changes given that pod p schedule on a node with the given requirements
-func (t *Topology) Record(p *v1.Pod, requirements scheduling.Requirements, compatabilityOptions ...functional.Option[scheduling.CompatibilityOptions]) {
+// Record records the topology changes given that pod p schedule on a node with the given taints and requirements
+func (t *Topology) Record(p *v1.Pod, taints []v1.Taint, requirements scheduling.Requirements, compatabilityOptions ...functional.Option[scheduling.CompatibilityOptions]) {
 	// once we've committed to a domain, we record the usage in every topology that cares about it
 	for _, tc := range t.topologies {
-		if tc.Counts(p, requirements, compatabilityOptions...) {
+		if tc.Counts(p, taints, requirements, compatabilityOptions...) {
 			domains := requirements.Get(tc.Key)
 			if tc.Type == TopologyTypePodAntiAffinity {
 				// for anti-affinity topologies we need to block out all possible domains that the pod could land in
@@ -161,13 +167,38 @@ func (t *Topology) Record(p *v1.Pod, requirements scheduling.Requirements, compa
 	}
 }
 
+// Unrecord removes a pod that is bound to a node with the given taints and requirements from the topology counts, e.g.
+// because we simulated it being preempted. The pod isn't counted by topologies that are created later either.
+func (t *Topology) Unrecord(p *v1.Pod, taints []v1.Taint, requirements scheduling.Requirements) {
+	if t.excludedPods.Has(string(p.UID)) {
+		return
+	}
+	t.excludedPods.Insert(string(p.UID))
+	if IgnoredForTopology(p) {
+		return
+	}
+	for _, tc := range t.topologies {
+		if domains := requirements.Get(tc.Key); tc.Counts(p, taints, requirements) && domains.Len() == 1 {
+			tc.Unrecord(domains.Values()[0])
+		}
+	}
+	for _, tc := range t.inverseTopologies {
+		if tc.IsOwnedBy(p.UID) {
+			if domains := requirements.Get(tc.Key); domains.Len() == 1 {
+				tc.Unrecord(domains.Values()[0])
+			}
+			tc.RemoveOwner(p.UID)
+		}
+	}
+}
+
 // AddRequirements tightens the input requirements by adding additional requirements that are being enforced by topology spreads
 // affinities, anti-affinities or inverse anti-affinities.  The nodeHostname is the hostname that we are currently considering
 // placing the pod on.  It returns these newly tightened requirements, or an error in the case of a set of requirements that
 // cannot be satisfied.
-func (t *Topology) AddRequirements(podRequirements, nodeRequirements scheduling.Requirements, p *v1.Pod, compatabilityOptions ...functional.Option[scheduling.CompatibilityOptions]) (scheduling.Requirements, error) {
+func (t *Topology) AddRequirements(podRequirements, nodeRequirements scheduling.Requirements, p *v1.Pod, taints []v1.Taint, compatabilityOptions ...functional.Option[scheduling.CompatibilityOptions]) (scheduling.Requirements, error) {
 	requirements := scheduling.NewRequirements(nodeRequirements.Values()...)
-	for _, topology := range t.getMatchingTopologies(p, nodeRequirements, compatabilityOptions...) {
+	for _, topology := range t.getMatchingTopologies(p, taints, nodeRequirements, compatabilityOptions...) {
 		podDomains := scheduling.NewRequirement(topology.Key, v1.NodeSelectorOpExists)
 		if podRequirements.Has(topology.Key) {
 			podDomains = podRequirements.Get(topology.Key)
@@ -233,7 +264,7 @@ func (t *Topology) updateInverseAntiAffinity(ctx context.Context, pod *v1.Pod, d
 			return err
 		}
 
-		tg := NewTopologyGroup(TopologyTypePodAntiAffinity, term.TopologyKey, pod, namespaces, term.LabelSelector, math.MaxInt32, nil, t.domains[term.TopologyKey])
+		tg := NewTopologyGroup(TopologyTypePodAntiAffinity, term.TopologyKey, pod, namespaces, term.LabelSelector, math.MaxInt32, nil, nil, nil, t.domains[term.TopologyKey])
 
 		hash := tg.Hash()
 		if existing, ok := t.inverseTopologies[hash]; !ok {
@@ -254,37 +285,41 @@ func (t *Topology) updateInverseAntiAffinity(ctx context.Context, pod *v1.Pod, d
 //
 //nolint:gocyclo
 func (t *Topology) countDomains(ctx context.Context, tg *TopologyGroup) error {
-	podList := &v1.PodList{}
-
 	// collect the pods from all the specified namespaces (don't see a way to query multiple namespaces
 	// simultaneously)
-	var pods []v1.Pod
+	selector := TopologyListOptions("", tg.selector).LabelSelector
+	var pods []*v1.Pod
 	for _, ns := range tg.namespaces.UnsortedList() {
-		if err := t.kubeClient.List(ctx, podList, TopologyListOptions(ns, tg.selector)); err != nil {
-			return fmt.Errorf("listing pods, %w", err)
+		namespacePods, err := t.getPods(ctx, ns)
+		if err != nil {
+			return err
+		}
+		for i := range namespacePods {
+			if selector.Matches(labels.Set(namespacePods[i].Labels)) {
+				pods = append(pods, &namespacePods[i])
+			}
 		}
-		pods = append(pods, podList.Items...)
 	}
 
-	for i, p := range pods {
-		if IgnoredForTopology(&pods[i]) {
+	for _, p := range pods {
+		if IgnoredForTopology(p) {
 			continue
 		}
 		// pod is excluded for counting purposes
 		if t.excludedPods.Has(string(p.UID)) {
 			continue
 		}
-		node := &v1.Node{}
-		if err := t.kubeClient.Get(ctx, types.NamespacedName{Name: p.Spec.NodeName}, node); err != nil {
-			// Pods that cannot be evicted can be leaked in the API Server after
-			// a Node is removed. Since pod bindings are immutable, these pods
-			// cannot be recovered, and will be deleted by the pod lifecycle
-			// garbage collector. These pods are not running, and should not
-			// impact future topology calculations.
-			if errors.IsNotFound(err) {
-				continue
-			}
-			return fmt.Errorf("getting node %s, %w", p.Spec.NodeName, err)
+		node, err := t.getNode(ctx, p.Spec.NodeName)
+		if err != nil {
+			return err
+		}
+		// Pods that cannot be evicted can be leaked in the API Server after
+		// a Node is removed. Since pod bindings are immutable, these pods
+		// cannot be recovered, and will be deleted by the pod lifecycle
+		// garbage collector. These pods are not running, and should not
+		// impact future topology calculations.
+		if node == nil {
+			continue
 		}
 		domain, ok := node.Labels[tg.Key]
 		// Kubelet sets the hostname label, but the node may not be ready yet so there is no label.  We fall back and just
@@ -308,14 +343,63 @@ func (t *Topology) countDomains(ctx context.Context, tg *TopologyGroup) error {
 	return nil
 }
 
+// getPods returns the pods in the namespace, listing them the first time that they're needed
+func (t *Topology) getPods(ctx context.Context, namespace string) ([]v1.Pod, error) {
+	if pods, ok := t.podsByNamespace[namespace]; ok {
+		return pods, nil
+	}
+	podList := &v1.PodList{}
+	if err := t.kubeClient.List(ctx, podList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("listing pods, %w", err)
+	}
+	t.podsByNamespace[namespace] = podList.Items
+	return podList.Items, nil
+}
+
+// getNode returns the node with the name, or nil if it doesn't exist
+func (t *Topology) getNode(ctx context.Context, name string) (*v1.Node, error) {
+	if node, ok := t.nodesByName[name]; ok {
+		return node, nil
+	}
+	node := &v1.Node{}
+	if err := t.kubeClient.Get(ctx, types.NamespacedName{Name: name}, node); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("getting node %s, %w", name, err)
+		}
+		node = nil
+	}
+	t.nodesByName[name] = node
+	return node, nil
+}
+
 func (t *Topology) newForTopologies(p *v1.Pod) []*TopologyGroup {
 	var topologyGroups []*TopologyGroup
 	for _, cs := range p.Spec.TopologySpreadConstraints {
-		topologyGroups = append(topologyGroups, NewTopologyGroup(TopologyTypeSpread, cs.TopologyKey, p, sets.New(p.Namespace), cs.LabelSelector, cs.MaxSkew, cs.MinDomains, t.domains[cs.TopologyKey]))
+		topologyGroups = append(topologyGroups, NewTopologyGroup(TopologyTypeSpread, cs.TopologyKey, p, sets.New(p.Namespace), spreadLabelSelector(p, cs), cs.MaxSkew, cs.MinDomains, cs.NodeTaintsPolicy, cs.NodeAffinityPolicy, t.domains[cs.TopologyKey]))
 	}
 	return topologyGroups
 }
 
+// spreadLabelSelector returns the label selector of the topology spread constraint, narrowed by the pod's values for
+// each of the constraint's matchLabelKeys. Like kube-scheduler, keys that the pod doesn't have a label for are ignored.
+// This lets a rolling update spread the pods of each ReplicaSet independently when keyed by pod-template-hash.
+func spreadLabelSelector(p *v1.Pod, cs v1.TopologySpreadConstraint) *metav1.LabelSelector {
+	if cs.LabelSelector == nil || len(cs.MatchLabelKeys) == 0 {
+		return cs.LabelSelector
+	}
+	selector := cs.LabelSelector.DeepCopy()
+	for _, key := range cs.MatchLabelKeys {
+		if value, ok := p.Labels[key]; ok {
+			selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+				Key:      key,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{value},
+			})
+		}
+	}
+	return selector
+}
+
 // newForAffinities returns a list of topology groups that have been constructed based on the input pod and required/preferred affinity terms
 func (t *Topology) newForAffinities(ctx context.Context, p *v1.Pod) ([]*TopologyGroup, error) {
 	var topologyGroups []*TopologyGroup
@@ -348,7 +432,7 @@ func (t *Topology) newForAffinities(ctx context.Context, p *v1.Pod) ([]*Topology
 			if err != nil {
 				return nil, err
 			}
-			topologyGroups = append(topologyGroups, NewTopologyGroup(topologyType, term.TopologyKey, p, namespaces, term.LabelSelector, math.MaxInt32, nil, t.domains[term.TopologyKey]))
+			topologyGroups = append(topologyGroups, NewTopologyGroup(topologyType, term.TopologyKey, p, namespaces, term.LabelSelector, math.MaxInt32, nil, nil, nil, t.domains[term.TopologyKey]))
 		}
 	}
 	return topologyGroups, nil
@@ -381,7 +465,7 @@ func (t *Topology) buildNamespaceList(ctx context.Context, namespace string, nam
 
 // getMatchingTopologies returns a sorted list of topologies that either control the scheduling of pod p, or for which
 // the topology selects pod p and the scheduling of p affects the count per topology domain
-func (t *Topology) getMatchingTopologies(p *v1.Pod, requirements scheduling.Requirements, compatabilityOptions ...functional.Option[scheduling.CompatibilityOptions]) []*TopologyGroup {
+func (t *Topology) getMatchingTopologies(p *v1.Pod, taints []v1.Taint, requirements scheduling.Requirements, compatabilityOptions ...functional.Option[scheduling.CompatibilityOptions]) []*TopologyGroup {
 	var matchingTopologies []*TopologyGroup
 	for _, tc := range t.topologies {
 		if tc.IsOwnedBy(p.UID) {
@@ -389,7 +473,7 @@ func (t *Topology) getMatchingTopologies(p *v1.Pod, requirements scheduling.Requ
 		}
 	}
 	for _, tc := range t.inverseTopologies {
-		if tc.Counts(p, requirements, compatabilityOptions...) {
+		if tc.Counts(p, taints, requirements, compatabilityOptions...) {
 			matchingTopologies = append(matchingTopologies, tc)
 		}
 	}
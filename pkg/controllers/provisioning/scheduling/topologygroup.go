@@ -62,27 +62,34 @@ type TopologyGroup struct {
 	namespaces sets.Set[string]
 	selector   *metav1.LabelSelector
 	nodeFilter TopologyNodeFilter
+	// podSelector is the parsed selector, which we keep since it's checked for every pod we try to schedule
+	podSelector labels.Selector
 	// Index
 	owners       map[types.UID]struct{} // Pods that have this topology as a scheduling rule
 	domains      map[string]int32       // TODO(ellistarn) explore replacing with a minheap
 	emptyDomains sets.Set[string]       // domains for which we know that no pod exists
 }
 
-func NewTopologyGroup(topologyType TopologyType, topologyKey string, pod *v1.Pod, namespaces sets.Set[string], labelSelector *metav1.LabelSelector, maxSkew int32, minDomains *int32, domains sets.Set[string]) *TopologyGroup {
+func NewTopologyGroup(topologyType TopologyType, topologyKey string, pod *v1.Pod, namespaces sets.Set[string], labelSelector *metav1.LabelSelector, maxSkew int32, minDomains *int32, taintPolicy *v1.NodeInclusionPolicy, affinityPolicy *v1.NodeInclusionPolicy, domains sets.Set[string]) *TopologyGroup {
 	domainCounts := map[string]int32{}
 	for domain := range domains {
 		domainCounts[domain] = 0
 	}
-	// the nil *TopologyNodeFilter always passes which is what we need for affinity/anti-affinity
+	// the zero-value TopologyNodeFilter always passes which is what we need for affinity/anti-affinity
 	var nodeSelector TopologyNodeFilter
 	if topologyType == TopologyTypeSpread {
-		nodeSelector = MakeTopologyNodeFilter(pod)
+		nodeSelector = MakeTopologyNodeFilter(pod, taintPolicy, affinityPolicy)
+	}
+	podSelector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		podSelector = labels.Nothing()
 	}
 	return &TopologyGroup{
 		Type:         topologyType,
 		Key:          topologyKey,
 		namespaces:   namespaces,
 		selector:     labelSelector,
+		podSelector:  podSelector,
 		nodeFilter:   nodeSelector,
 		maxSkew:      maxSkew,
 		domains:      domainCounts,
@@ -112,10 +119,22 @@ func (t *TopologyGroup) Record(domains ...string) {
 	}
 }
 
+// Unrecord removes a pod that was recorded in the domains
+func (t *TopologyGroup) Unrecord(domains ...string) {
+	for _, domain := range domains {
+		if t.domains[domain] == 0 {
+			continue
+		}
+		if t.domains[domain]--; t.domains[domain] == 0 {
+			t.emptyDomains.Insert(domain)
+		}
+	}
+}
+
 // Counts returns true if the pod would count for the topology, given that it schedule to a node with the provided
-// requirements
-func (t *TopologyGroup) Counts(pod *v1.Pod, requirements scheduling.Requirements, compatabilityOptions ...functional.Option[scheduling.CompatibilityOptions]) bool {
-	return t.selects(pod) && t.nodeFilter.MatchesRequirements(requirements, compatabilityOptions...)
+// taints and requirements
+func (t *TopologyGroup) Counts(pod *v1.Pod, taints []v1.Taint, requirements scheduling.Requirements, compatabilityOptions ...functional.Option[scheduling.CompatibilityOptions]) bool {
+	return t.selects(pod) && t.nodeFilter.MatchesRequirements(requirements, taints, compatabilityOptions...)
 }
 
 // Register ensures that the topology is aware of the given domain names.
@@ -165,8 +184,13 @@ func (t *TopologyGroup) Hash() uint64 {
 // If there are multiple eligible domains, we return any random domain that satisfies the `maxSkew` configuration.
 // If there are no eligible domains, we return a `DoesNotExist` requirement, implying that we could not satisfy the topologySpread requirement.
 func (t *TopologyGroup) nextDomainTopologySpread(pod *v1.Pod, podDomains, nodeDomains *scheduling.Requirement) *scheduling.Requirement {
-	// min count is calculated across all domains
-	min := t.domainMinCount(podDomains)
+	// min count is calculated across all domains that the pod's node affinity allows, or every domain if the
+	// constraint ignores node affinity
+	minDomains := podDomains
+	if t.nodeFilter.AffinityPolicy == v1.NodeInclusionPolicyIgnore {
+		minDomains = scheduling.NewRequirement(podDomains.Key, v1.NodeSelectorOpExists)
+	}
+	min := t.domainMinCount(minDomains)
 	selfSelecting := t.selects(pod)
 
 	minDomain := ""
@@ -266,9 +290,5 @@ func (t *TopologyGroup) nextDomainAntiAffinity(domains *scheduling.Requirement)
 
 // selects returns true if the given pod is selected by this topology
 func (t *TopologyGroup) selects(pod *v1.Pod) bool {
-	selector, err := metav1.LabelSelectorAsSelector(t.selector)
-	if err != nil {
-		selector = labels.Nothing()
-	}
-	return t.namespaces.Has(pod.Namespace) && selector.Matches(labels.Set(pod.Labels))
+	return t.namespaces.Has(pod.Namespace) && t.podSelector.Matches(labels.Set(pod.Labels))
 }
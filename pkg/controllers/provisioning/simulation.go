@@ -0,0 +1,211 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/samber/lo"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	scheduler "sigs.k8s.io/karpenter/pkg/controllers/provisioning/scheduling"
+	"sigs.k8s.io/karpenter/pkg/controllers/state"
+	"sigs.k8s.io/karpenter/pkg/operator/controller"
+	"sigs.k8s.io/karpenter/pkg/operator/options"
+)
+
+// maxSimulationRequestBytes bounds the size of the pods that can be submitted in a single simulation
+const maxSimulationRequestBytes = 10 << 20
+
+var ErrClusterStateNotSynced = errors.New("cluster state is not synced")
+
+// SimulationRequest is the set of pods to simulate scheduling for
+type SimulationRequest struct {
+	Pods []v1.Pod `json:"pods"`
+}
+
+// SimulationResults describes what the provisioner would do if the simulated pods were pending
+type SimulationResults struct {
+	ExistingNodes []SimulatedExistingNode `json:"existingNodes,omitempty"`
+	NodeClaims    []SimulatedNodeClaim    `json:"nodeClaims,omitempty"`
+	// PodErrors are the reasons that pods couldn't schedule, keyed by namespace/name
+	PodErrors map[string]string `json:"podErrors,omitempty"`
+}
+
+// SimulatedExistingNode is an existing node that pods would schedule to
+type SimulatedExistingNode struct {
+	Name string   `json:"name"`
+	Pods []string `json:"pods"`
+}
+
+// SimulatedNodeClaim is a NodeClaim that would be launched for pods
+type SimulatedNodeClaim struct {
+	NodePool string   `json:"nodePool"`
+	Pods     []string `json:"pods"`
+	// InstanceTypes are the instance type options for the NodeClaim, ordered by price
+	InstanceTypes []SimulatedInstanceType `json:"instanceTypes"`
+	// Price is the estimated hourly price of the NodeClaim, which is the price of the cheapest instance type option
+	Price float64 `json:"price"`
+}
+
+type SimulatedInstanceType struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+// SimulationServer serves what-if scheduling simulations that answer where pods would schedule against the current
+// cluster state and what would be launched for them, without launching anything.
+type SimulationServer struct {
+	provisioner *Provisioner
+	cluster     *state.Cluster
+}
+
+func NewSimulationServer(provisioner *Provisioner, cluster *state.Cluster) *SimulationServer {
+	return &SimulationServer{
+		provisioner: provisioner,
+		cluster:     cluster,
+	}
+}
+
+func (s *SimulationServer) Name() string {
+	return "provisioner.simulation"
+}
+
+func (s *SimulationServer) Builder(_ context.Context, m manager.Manager) controller.Builder {
+	return controller.NewSingletonManagedBy(m)
+}
+
+// Reconcile serves simulations until the operator shuts down. It's run as a leader elected singleton since cluster
+// state is only populated on the leader.
+func (s *SimulationServer) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	port := options.FromContext(ctx).SimulationPort
+	if port == 0 {
+		<-ctx.Done()
+		return reconcile.Result{}, nil
+	}
+	server := &http.Server{
+		Addr:              net.JoinHostPort(options.FromContext(ctx).SimulationAddress, strconv.Itoa(port)),
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(_ net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		if err := server.Shutdown(context.Background()); err != nil {
+			logging.FromContext(ctx).Errorf("shutting down simulation server, %s", err)
+		}
+	}()
+	logging.FromContext(ctx).With("address", server.Addr).Infof("serving scheduling simulations")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return reconcile.Result{}, fmt.Errorf("serving scheduling simulations, %w", err)
+	}
+	return reconcile.Result{}, nil
+}
+
+func (s *SimulationServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	request := &SimulationRequest{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSimulationRequestBytes)).Decode(request); err != nil {
+		http.Error(w, fmt.Sprintf("decoding simulation request, %s", err), http.StatusBadRequest)
+		return
+	}
+	results, err := s.Simulate(r.Context(), lo.ToSlicePtr(request.Pods))
+	if err != nil {
+		status := lo.Ternary(errors.Is(err, ErrClusterStateNotSynced) || errors.Is(err, ErrNodePoolsNotFound), http.StatusServiceUnavailable, http.StatusInternalServerError)
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(results); err != nil {
+		logging.FromContext(r.Context()).Errorf("encoding simulation results, %s", err)
+	}
+}
+
+// Simulate returns what the provisioner would do if the pods were pending. Pods are scheduled against the existing
+// nodes in cluster state as they are now, without considering other pending pods.
+func (s *SimulationServer) Simulate(ctx context.Context, pods []*v1.Pod) (*SimulationResults, error) {
+	if !s.cluster.Synced(ctx) {
+		return nil, ErrClusterStateNotSynced
+	}
+	// Pods are usually submitted as they'd be templated by a workload, so we fill in the metadata that the
+	// api-server would otherwise populate
+	for i, p := range pods {
+		if p.Namespace == "" {
+			p.Namespace = v1.NamespaceDefault
+		}
+		if p.Name == "" {
+			p.Name = fmt.Sprintf("%s%d", lo.Ternary(p.GenerateName == "", "simulated-pod-", p.GenerateName), i)
+		}
+		if p.UID == "" {
+			p.UID = uuid.NewUUID()
+		}
+		p.Spec.NodeName = ""
+	}
+	sched, err := s.provisioner.NewScheduler(ctx, pods, s.cluster.Nodes().Active(), scheduler.SchedulerOptions{SimulationMode: true})
+	if err != nil {
+		return nil, fmt.Errorf("creating scheduler, %w", err)
+	}
+	return NewSimulationResults(sched.Solve(ctx, pods)), nil
+}
+
+// NewSimulationResults summarizes the results of a scheduling simulation
+func NewSimulationResults(results scheduler.Results) *SimulationResults {
+	podName := func(p *v1.Pod, _ int) string { return client.ObjectKeyFromObject(p).String() }
+	simulation := &SimulationResults{PodErrors: map[string]string{}}
+	for _, n := range results.ExistingNodes {
+		if len(n.Pods) == 0 {
+			continue
+		}
+		simulation.ExistingNodes = append(simulation.ExistingNodes, SimulatedExistingNode{Name: n.Name(), Pods: lo.Map(n.Pods, podName)})
+	}
+	for _, nc := range results.NewNodeClaims {
+		nodeClaim := SimulatedNodeClaim{NodePool: nc.NodePoolName, Pods: lo.Map(nc.Pods, podName)}
+		// only the instance types that the NodePool's strategy selects would be launched
+		for _, it := range nc.Strategy.Select(nc.InstanceTypeOptions, nc.Requirements, nc.Spec.Resources.Requests) {
+			offerings := it.Offerings.Available().Compatible(nc.Requirements)
+			if len(offerings) == 0 {
+				continue
+			}
+			nodeClaim.InstanceTypes = append(nodeClaim.InstanceTypes, SimulatedInstanceType{Name: it.Name, Price: offerings.Cheapest().Price})
+		}
+		sort.SliceStable(nodeClaim.InstanceTypes, func(i, j int) bool { return nodeClaim.InstanceTypes[i].Price < nodeClaim.InstanceTypes[j].Price })
+		if len(nodeClaim.InstanceTypes) > 0 {
+			nodeClaim.Price = nodeClaim.InstanceTypes[0].Price
+		}
+		simulation.NodeClaims = append(simulation.NodeClaims, nodeClaim)
+	}
+	for p, err := range results.PodErrors {
+		simulation.PodErrors[client.ObjectKeyFromObject(p).String()] = err.Error()
+	}
+	return simulation
+}
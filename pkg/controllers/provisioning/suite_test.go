@@ -17,8 +17,13 @@ limitations under the License.
 package provisioning_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -30,23 +35,24 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
-	"k8s.io/client-go/tools/record"
 	clock "k8s.io/utils/clock/testing"
 	. "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"sigs.k8s.io/karpenter/pkg/apis"
 	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
 	"sigs.k8s.io/karpenter/pkg/cloudprovider"
 	"sigs.k8s.io/karpenter/pkg/cloudprovider/fake"
 	"sigs.k8s.io/karpenter/pkg/controllers/provisioning"
+	scheduler "sigs.k8s.io/karpenter/pkg/controllers/provisioning/scheduling"
 	"sigs.k8s.io/karpenter/pkg/controllers/state"
 	"sigs.k8s.io/karpenter/pkg/controllers/state/informer"
-	"sigs.k8s.io/karpenter/pkg/events"
 	"sigs.k8s.io/karpenter/pkg/operator/controller"
 	"sigs.k8s.io/karpenter/pkg/operator/options"
 	"sigs.k8s.io/karpenter/pkg/operator/scheme"
+	"sigs.k8s.io/karpenter/pkg/scheduling"
 	"sigs.k8s.io/karpenter/pkg/test"
 	. "sigs.k8s.io/karpenter/pkg/test/expectations"
 )
@@ -59,6 +65,7 @@ var (
 	daemonsetController controller.Controller
 	cloudProvider       *fake.CloudProvider
 	prov                *provisioning.Provisioner
+	recorder            *test.EventRecorder
 	env                 *test.Environment
 	instanceTypeMap     map[string]*cloudprovider.InstanceType
 )
@@ -76,7 +83,8 @@ var _ = BeforeSuite(func() {
 	fakeClock = clock.NewFakeClock(time.Now())
 	cluster = state.NewCluster(fakeClock, env.Client, cloudProvider)
 	nodeController = informer.NewNodeController(env.Client, cluster)
-	prov = provisioning.NewProvisioner(env.Client, events.NewRecorder(&record.FakeRecorder{}), cloudProvider, cluster)
+	recorder = test.NewEventRecorder()
+	prov = provisioning.NewProvisioner(env.Client, recorder, cloudProvider, cluster)
 	daemonsetController = informer.NewDaemonSetController(env.Client, cluster)
 	instanceTypes, _ := cloudProvider.GetInstanceTypes(ctx, nil)
 	instanceTypeMap = map[string]*cloudprovider.InstanceType{}
@@ -88,6 +96,7 @@ var _ = BeforeSuite(func() {
 var _ = BeforeEach(func() {
 	ctx = options.ToContext(ctx, test.Options())
 	cloudProvider.Reset()
+	recorder.Reset()
 })
 
 var _ = AfterSuite(func() {
@@ -224,12 +233,12 @@ var _ = Describe("Provisioning", func() {
 				Template: v1beta1.NodeClaimTemplate{
 					Spec: v1beta1.NodeClaimSpec{
 						Kubelet: &v1beta1.KubeletConfiguration{MaxPods: ptr.Int32(1)},
-						Requirements: []v1.NodeSelectorRequirement{
-							{
+						Requirements: []v1beta1.NodeSelectorRequirementWithMinValues{
+							{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 								Key:      v1.LabelInstanceTypeStable,
 								Operator: v1.NodeSelectorOpIn,
 								Values:   []string{"single-pod-instance-type"},
-							},
+							}},
 						},
 					},
 				},
@@ -428,7 +437,792 @@ var _ = Describe("Provisioning", func() {
 			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
 			ExpectNotScheduled(ctx, env.Client, pod)
 		})
+		It("should not schedule to a nodepool with provisioning paused", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool(v1beta1.NodePool{
+				Spec: v1beta1.NodePoolSpec{
+					Paused: []v1beta1.PausedOperation{v1beta1.PausedOperationProvisioning},
+				},
+			}))
+			pod := test.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectNotScheduled(ctx, env.Client, pod)
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(0))
+		})
+		It("should schedule to a nodepool with only disruption paused", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool(v1beta1.NodePool{
+				Spec: v1beta1.NodePoolSpec{
+					Paused: []v1beta1.PausedOperation{v1beta1.PausedOperationDisruption},
+				},
+			}))
+			pod := test.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+		})
+		It("should schedule to other nodepools when one nodepool has provisioning paused", func() {
+			paused := test.NodePool(v1beta1.NodePool{
+				Spec: v1beta1.NodePoolSpec{
+					Weight: lo.ToPtr[int32](100),
+					Paused: []v1beta1.PausedOperation{v1beta1.PausedOperationProvisioning},
+				},
+			})
+			nodePool := test.NodePool()
+			ExpectApplied(ctx, env.Client, paused, nodePool)
+			pod := test.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels[v1beta1.NodePoolLabelKey]).To(Equal(nodePool.Name))
+		})
+		It("should count in-flight nodeclaims against the node limit", func() {
+			nodePool := test.NodePool(v1beta1.NodePool{
+				Spec: v1beta1.NodePoolSpec{
+					Limits: v1beta1.Limits(v1.ResourceList{v1beta1.ResourceNodes: resource.MustParse("2")}),
+				},
+			})
+			ExpectApplied(ctx, env.Client, nodePool)
+			// The nodeclaims have launched but haven't registered nodes, so the nodepool status doesn't count them yet
+			for i := 0; i < 2; i++ {
+				nodeClaim := test.NodeClaim(v1beta1.NodeClaim{ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{v1beta1.NodePoolLabelKey: nodePool.Name},
+				}})
+				ExpectApplied(ctx, env.Client, nodeClaim)
+				cluster.UpdateNodeClaim(nodeClaim)
+			}
+			pod := test.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectNotScheduled(ctx, env.Client, pod)
+		})
+		It("should not create a nodeclaim past the node limit when called directly", func() {
+			nodePool := test.NodePool(v1beta1.NodePool{
+				Spec: v1beta1.NodePoolSpec{
+					Limits: v1beta1.Limits(v1.ResourceList{v1beta1.ResourceNodes: resource.MustParse("2")}),
+				},
+			})
+			ExpectApplied(ctx, env.Client, nodePool)
+			for i := 0; i < 2; i++ {
+				nodeClaim := test.NodeClaim(v1beta1.NodeClaim{ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{v1beta1.NodePoolLabelKey: nodePool.Name},
+				}})
+				ExpectApplied(ctx, env.Client, nodeClaim)
+				cluster.UpdateNodeClaim(nodeClaim)
+			}
+			// Callers like the minimum controller create nodeclaims without going through the scheduler
+			_, err := prov.Create(ctx, &scheduler.NodeClaim{NodeClaimTemplate: *scheduler.NewNodeClaimTemplate(nodePool)})
+			Expect(err).To(HaveOccurred())
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(2))
+		})
+		It("should only launch as many nodes as the node limit allows", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool(v1beta1.NodePool{
+				Spec: v1beta1.NodePoolSpec{
+					Limits: v1beta1.Limits(v1.ResourceList{v1beta1.ResourceNodes: resource.MustParse("2")}),
+				},
+			}))
+			// prevent these pods from scheduling on the same node
+			opts := test.PodOptions{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "foo"},
+				},
+				PodAntiRequirements: []v1.PodAffinityTerm{
+					{
+						TopologyKey: v1.LabelHostname,
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app": "foo",
+							},
+						},
+					},
+				},
+			}
+			pods := []*v1.Pod{
+				test.UnschedulablePod(opts),
+				test.UnschedulablePod(opts),
+				test.UnschedulablePod(opts),
+			}
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pods...)
+			scheduled := lo.Filter(pods, func(p *v1.Pod, _ int) bool {
+				return ExpectPodExists(ctx, env.Client, p.Name, p.Namespace).Spec.NodeName != ""
+			})
+			Expect(scheduled).To(HaveLen(2))
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(2))
+		})
+		It("should count existing nodes against the node limit", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool(v1beta1.NodePool{
+				Spec: v1beta1.NodePoolSpec{
+					Limits: v1beta1.Limits(v1.ResourceList{v1beta1.ResourceNodes: resource.MustParse("1")}),
+				},
+			}))
+			// prevent these pods from scheduling on the same node
+			opts := test.PodOptions{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "foo"},
+				},
+				PodAntiRequirements: []v1.PodAffinityTerm{
+					{
+						TopologyKey: v1.LabelHostname,
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app": "foo",
+							},
+						},
+					},
+				},
+			}
+			pod := test.UnschedulablePod(opts)
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+
+			// The pod can't schedule to the existing node and a second node would exceed the node limit
+			pod = test.UnschedulablePod(opts)
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectNotScheduled(ctx, env.Client, pod)
+		})
 	})
+	Context("Headroom", func() {
+		var headroom []v1beta1.Headroom
+		BeforeEach(func() {
+			headroom = []v1beta1.Headroom{{
+				Count:    2,
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			}}
+		})
+		It("should launch a nodeclaim for headroom without any pending pods", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{Headroom: headroom}}))
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov)
+			Expect(cloudProvider.CreateCalls).To(HaveLen(1))
+			ExpectNodeClaimRequests(cloudProvider.CreateCalls[0], v1.ResourceList{
+				v1.ResourceCPU:  resource.MustParse("2"),
+				v1.ResourcePods: resource.MustParse("2"),
+			})
+			// Headroom pods are virtual and are never created
+			pods := &v1.PodList{}
+			Expect(env.Client.List(ctx, pods)).To(Succeed())
+			Expect(pods.Items).To(HaveLen(0))
+		})
+		It("should not launch a nodeclaim when existing capacity satisfies the headroom", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{Headroom: headroom}}))
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov)
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov)
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+		})
+		It("should launch headroom alongside pending pods", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{Headroom: headroom}}))
+			pod := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(cloudProvider.CreateCalls).To(HaveLen(1))
+			ExpectNodeClaimRequests(cloudProvider.CreateCalls[0], v1.ResourceList{
+				v1.ResourceCPU:  resource.MustParse("3"),
+				v1.ResourcePods: resource.MustParse("3"),
+			})
+		})
+		It("should respect the headroom node selector", func() {
+			headroom[0].NodeSelector = map[string]string{v1.LabelTopologyZone: "test-zone-2"}
+			ExpectApplied(ctx, env.Client, test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{Headroom: headroom}}))
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov)
+			nodes := ExpectNodes(ctx, env.Client)
+			Expect(nodes).To(HaveLen(1))
+			Expect(nodes[0].Labels).To(HaveKeyWithValue(v1.LabelTopologyZone, "test-zone-2"))
+		})
+		It("should tolerate the taints of the nodepool", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{
+				Headroom: headroom,
+				Template: v1beta1.NodeClaimTemplate{
+					Spec: v1beta1.NodeClaimSpec{
+						Taints: []v1.Taint{{Key: "test-key", Value: "test-value", Effect: v1.TaintEffectNoSchedule}},
+					},
+				},
+			}}))
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov)
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(1))
+		})
+		It("should only launch headroom capacity for its own nodepool", func() {
+			nodePool := test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{Headroom: headroom}})
+			other := test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{Weight: lo.ToPtr[int32](100)}})
+			ExpectApplied(ctx, env.Client, nodePool, other)
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov)
+			nodeClaims := ExpectNodeClaims(ctx, env.Client)
+			Expect(nodeClaims).To(HaveLen(1))
+			Expect(nodeClaims[0].Labels).To(HaveKeyWithValue(v1beta1.NodePoolLabelKey, nodePool.Name))
+		})
+		It("should not launch headroom capacity for a nodepool with provisioning paused", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{
+				Headroom: headroom,
+				Paused:   []v1beta1.PausedOperation{v1beta1.PausedOperationProvisioning},
+			}}))
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov)
+			Expect(ExpectNodeClaims(ctx, env.Client)).To(HaveLen(0))
+		})
+	})
+	Context("Gated Pods", func() {
+		var gatedPod *v1.Pod
+		BeforeEach(func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{
+				PreProvisionSchedulingGates: []string{"example.com/queue"},
+				FeatureGates:                test.FeatureGates{PreProvisionGatedPods: lo.ToPtr(true)},
+			}))
+			gatedPod = test.Pod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			}})
+			gatedPod.Spec.SchedulingGates = []v1.PodSchedulingGate{{Name: "example.com/queue"}}
+		})
+		It("should provision capacity for pods held by a pre-provisioned scheduling gate", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool(), gatedPod)
+			bindings := ExpectProvisionedNoBinding(ctx, env.Client, cluster, cloudProvider, prov)
+			Expect(cloudProvider.CreateCalls).To(HaveLen(1))
+			Expect(bindings).To(HaveKey(HaveField("Name", gatedPod.Name)))
+			// The pod isn't bound since it can't schedule until its gates are removed
+			ExpectNotScheduled(ctx, env.Client, gatedPod)
+		})
+		It("should provision capacity for gated pods with the pre-provision annotation", func() {
+			gatedPod.Spec.SchedulingGates = []v1.PodSchedulingGate{{Name: "example.com/other"}}
+			gatedPod.Annotations = map[string]string{v1beta1.PreProvisionAnnotationKey: "true"}
+			ExpectApplied(ctx, env.Client, test.NodePool(), gatedPod)
+			ExpectProvisionedNoBinding(ctx, env.Client, cluster, cloudProvider, prov)
+			Expect(cloudProvider.CreateCalls).To(HaveLen(1))
+		})
+		It("should not provision capacity for pods held by other scheduling gates", func() {
+			gatedPod.Spec.SchedulingGates = []v1.PodSchedulingGate{{Name: "example.com/other"}}
+			ExpectApplied(ctx, env.Client, test.NodePool(), gatedPod)
+			ExpectProvisionedNoBinding(ctx, env.Client, cluster, cloudProvider, prov)
+			Expect(cloudProvider.CreateCalls).To(HaveLen(0))
+		})
+		It("should not provision capacity for gated pods when the feature gate is disabled", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{
+				PreProvisionSchedulingGates: []string{"example.com/queue"},
+			}))
+			ExpectApplied(ctx, env.Client, test.NodePool(), gatedPod)
+			ExpectProvisionedNoBinding(ctx, env.Client, cluster, cloudProvider, prov)
+			Expect(cloudProvider.CreateCalls).To(HaveLen(0))
+		})
+		It("should provision for gated pods alongside pending pods", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool(), gatedPod)
+			pod := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			ExpectNotScheduled(ctx, env.Client, gatedPod)
+			Expect(cloudProvider.CreateCalls).To(HaveLen(1))
+			ExpectNodeClaimRequests(cloudProvider.CreateCalls[0], v1.ResourceList{
+				v1.ResourceCPU:  resource.MustParse("2"),
+				v1.ResourcePods: resource.MustParse("2"),
+			})
+		})
+		It("should let gated pods know once they fit on ready capacity", func() {
+			node := test.Node(test.NodeOptions{Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("10"),
+				v1.ResourceMemory: resource.MustParse("10Gi"),
+				v1.ResourcePods:   resource.MustParse("110"),
+			}})
+			ExpectApplied(ctx, env.Client, test.NodePool(), gatedPod, node)
+			ExpectMakeNodesInitialized(ctx, env.Client, node)
+			ExpectReconcileSucceeded(ctx, nodeController, client.ObjectKeyFromObject(node))
+
+			ExpectProvisionedNoBinding(ctx, env.Client, cluster, cloudProvider, prov)
+			Expect(cloudProvider.CreateCalls).To(HaveLen(0))
+			Expect(recorder.Calls(scheduler.PodCapacityReadyEvent(gatedPod, node, nil).Reason)).To(Equal(1))
+			Expect(recorder.DetectedEvent(scheduler.PodCapacityReadyEvent(gatedPod, node, nil).Message)).To(BeTrue())
+			Expect(recorder.Calls(scheduler.NominatePodEvent(gatedPod, node, nil).Reason)).To(Equal(0))
+		})
+		It("should nominate gated pods to capacity that isn't ready yet", func() {
+			nodePool := test.NodePool()
+			resources := v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("10"),
+				v1.ResourceMemory: resource.MustParse("10Gi"),
+				v1.ResourcePods:   resource.MustParse("110"),
+			}
+			nodeClaim := test.NodeClaim(v1beta1.NodeClaim{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1beta1.NodePoolLabelKey: nodePool.Name}},
+				Status:     v1beta1.NodeClaimStatus{Capacity: resources, Allocatable: resources},
+			})
+			ExpectApplied(ctx, env.Client, nodePool, gatedPod, nodeClaim)
+			cluster.UpdateNodeClaim(nodeClaim)
+
+			ExpectProvisionedNoBinding(ctx, env.Client, cluster, cloudProvider, prov)
+			Expect(cloudProvider.CreateCalls).To(HaveLen(0))
+			Expect(recorder.Calls(scheduler.PodCapacityReadyEvent(gatedPod, nil, nodeClaim).Reason)).To(Equal(0))
+			Expect(recorder.Calls(scheduler.NominatePodEvent(gatedPod, nil, nodeClaim).Reason)).To(Equal(1))
+		})
+	})
+	Context("Capacity Reservations", func() {
+		var nodePool *v1beta1.NodePool
+		BeforeEach(func() {
+			nodePool = test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{Template: v1beta1.NodeClaimTemplate{Spec: v1beta1.NodeClaimSpec{
+				Requirements: []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{
+					Key:      v1beta1.CapacityTypeLabelKey,
+					Operator: v1.NodeSelectorOpIn,
+					Values:   []string{v1beta1.CapacityTypeReserved, v1beta1.CapacityTypeOnDemand},
+				}}},
+			}}}})
+			cloudProvider.InstanceTypes = []*cloudprovider.InstanceType{
+				fake.NewInstanceType(fake.InstanceTypeOptions{
+					Name: "reserved-instance-type",
+					Offerings: []cloudprovider.Offering{
+						{CapacityType: v1beta1.CapacityTypeReserved, Zone: "test-zone-1", Price: 1, Available: true, ReservationID: "r-1", ReservationCapacity: 2},
+						{CapacityType: v1beta1.CapacityTypeOnDemand, Zone: "test-zone-1", Price: 1, Available: true},
+						{CapacityType: v1beta1.CapacityTypeOnDemand, Zone: "test-zone-2", Price: 1, Available: true},
+					},
+				}),
+			}
+		})
+		// each of the pods needs a node of its own
+		largePods := func(count int) []*v1.Pod {
+			return test.UnschedulablePods(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("3")},
+			}}, count)
+		}
+		It("should launch into a reservation before launching capacity that isn't reserved", func() {
+			ExpectApplied(ctx, env.Client, nodePool)
+			pod := largePods(1)[0]
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels).To(HaveKeyWithValue(v1beta1.CapacityTypeLabelKey, v1beta1.CapacityTypeReserved))
+			Expect(node.Labels).To(HaveKeyWithValue(v1.LabelTopologyZone, "test-zone-1"))
+			Expect(cloudProvider.CreateCalls).To(HaveLen(1))
+			Expect(cloudProvider.CreateCalls[0].Annotations).To(HaveKeyWithValue(v1beta1.ReservationIDAnnotationKey, "r-1"))
+		})
+		It("should fall back to capacity that isn't reserved once the reservation is used up", func() {
+			ExpectApplied(ctx, env.Client, nodePool)
+			pods := largePods(3)
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pods...)
+			capacityTypes := lo.CountValuesBy(pods, func(p *v1.Pod) string {
+				return ExpectScheduled(ctx, env.Client, p).Labels[v1beta1.CapacityTypeLabelKey]
+			})
+			Expect(capacityTypes).To(Equal(map[string]int{v1beta1.CapacityTypeReserved: 2, v1beta1.CapacityTypeOnDemand: 1}))
+			Expect(lo.CountBy(cloudProvider.CreateCalls, func(nc *v1beta1.NodeClaim) bool {
+				return nc.Annotations[v1beta1.ReservationIDAnnotationKey] == "r-1"
+			})).To(Equal(2))
+		})
+		It("should count in-flight nodeclaims that launch into a reservation against its capacity", func() {
+			ExpectApplied(ctx, env.Client, nodePool)
+			// the nodeclaim hasn't launched yet, so the reservation's capacity doesn't account for it
+			nodeClaim := test.NodeClaim(v1beta1.NodeClaim{ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{v1beta1.NodePoolLabelKey: nodePool.Name},
+				Annotations: map[string]string{v1beta1.ReservationIDAnnotationKey: "r-1"},
+			}})
+			ExpectApplied(ctx, env.Client, nodeClaim)
+			cluster.UpdateNodeClaim(nodeClaim)
+			pods := largePods(2)
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pods...)
+			capacityTypes := lo.CountValuesBy(pods, func(p *v1.Pod) string {
+				return ExpectScheduled(ctx, env.Client, p).Labels[v1beta1.CapacityTypeLabelKey]
+			})
+			Expect(capacityTypes).To(Equal(map[string]int{v1beta1.CapacityTypeReserved: 1, v1beta1.CapacityTypeOnDemand: 1}))
+		})
+		It("should not launch into reservations that the pod can't schedule to", func() {
+			ExpectApplied(ctx, env.Client, nodePool)
+			pod := largePods(1)[0]
+			pod.Spec.NodeSelector = map[string]string{v1.LabelTopologyZone: "test-zone-2"}
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels).To(HaveKeyWithValue(v1beta1.CapacityTypeLabelKey, v1beta1.CapacityTypeOnDemand))
+			Expect(cloudProvider.CreateCalls[0].Annotations).ToNot(HaveKey(v1beta1.ReservationIDAnnotationKey))
+		})
+		It("should not launch into reservations that the NodePool doesn't allow", func() {
+			test.ReplaceRequirements(nodePool, v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{
+				Key:      v1beta1.CapacityTypeLabelKey,
+				Operator: v1.NodeSelectorOpIn,
+				Values:   []string{v1beta1.CapacityTypeOnDemand},
+			}})
+			ExpectApplied(ctx, env.Client, nodePool)
+			pod := largePods(1)[0]
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels).To(HaveKeyWithValue(v1beta1.CapacityTypeLabelKey, v1beta1.CapacityTypeOnDemand))
+		})
+		It("should not launch more instances into a reservation than it has room for when it's the only capacity allowed", func() {
+			test.ReplaceRequirements(nodePool, v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{
+				Key:      v1beta1.CapacityTypeLabelKey,
+				Operator: v1.NodeSelectorOpIn,
+				Values:   []string{v1beta1.CapacityTypeReserved},
+			}})
+			ExpectApplied(ctx, env.Client, nodePool)
+			pods := largePods(3)
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pods...)
+			Expect(lo.CountBy(pods, func(p *v1.Pod) bool {
+				stored := ExpectExists(ctx, env.Client, p)
+				return stored.Spec.NodeName != ""
+			})).To(Equal(2))
+			Expect(cloudProvider.CreateCalls).To(HaveLen(2))
+		})
+	})
+	Context("Explanations", func() {
+		explanationFor := func(results scheduler.Results, pod *v1.Pod) *scheduler.Explanation {
+			GinkgoHelper()
+			for p, explanation := range results.PodExplanations {
+				if p.UID == pod.UID {
+					return explanation
+				}
+			}
+			Fail(fmt.Sprintf("expected an explanation for pod %s", client.ObjectKeyFromObject(pod)))
+			return nil
+		}
+		It("should explain that a nodepool's taints aren't tolerated", func() {
+			nodePool := test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{Template: v1beta1.NodeClaimTemplate{Spec: v1beta1.NodeClaimSpec{
+				Taints: []v1.Taint{{Key: "test-key", Value: "test-value", Effect: v1.TaintEffectNoSchedule}},
+			}}}})
+			pod := test.UnschedulablePod()
+			ExpectApplied(ctx, env.Client, nodePool, pod)
+			results, err := prov.Schedule(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			explanation := explanationFor(results, pod)
+			Expect(explanation.NodePools).To(HaveLen(1))
+			Expect(explanation.NodePools[0].NodePool).To(Equal(nodePool.Name))
+			Expect(explanation.NodePools[0].Reason).To(Equal(scheduler.RejectionReasonTaints))
+		})
+		It("should explain that no instance type meets a pod's requirements", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool())
+			pod := test.UnschedulablePod(test.PodOptions{NodeSelector: map[string]string{v1.LabelInstanceTypeStable: "unknown-instance-type"}})
+			ExpectApplied(ctx, env.Client, pod)
+			results, err := prov.Schedule(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(explanationFor(results, pod).NodePools[0].Reason).To(Equal(scheduler.RejectionReasonRequirements))
+		})
+		It("should explain that no instance type is large enough along with the closest instance types", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool())
+			pod := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1000")},
+			}})
+			ExpectApplied(ctx, env.Client, pod)
+			results, err := prov.Schedule(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			rejection := explanationFor(results, pod).NodePools[0]
+			Expect(rejection.Reason).To(Equal(scheduler.RejectionReasonInsufficientResources))
+			Expect(rejection.ClosestInstanceTypes).To(ContainElement("arm-instance-type"))
+		})
+		It("should explain that daemonset overhead keeps a pod from fitting", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool(), test.DaemonSet(test.DaemonSetOptions{PodOptions: test.PodOptions{
+				ResourceRequirements: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")}},
+			}}))
+			pod := test.UnschedulablePod(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("12")},
+			}})
+			ExpectApplied(ctx, env.Client, pod)
+			results, err := prov.Schedule(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(explanationFor(results, pod).NodePools[0].Reason).To(Equal(scheduler.RejectionReasonDaemonOverhead))
+		})
+		It("should explain that a nodepool's limits are exceeded", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{
+				Limits: v1beta1.Limits(v1.ResourceList{v1.ResourceCPU: resource.MustParse("0")}),
+			}}))
+			pod := test.UnschedulablePod()
+			ExpectApplied(ctx, env.Client, pod)
+			results, err := prov.Schedule(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(explanationFor(results, pod).NodePools[0].Reason).To(Equal(scheduler.RejectionReasonLimits))
+		})
+		It("should explain the preferences that were relaxed", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool())
+			pod := test.UnschedulablePod(test.PodOptions{
+				NodePreferences: []v1.NodeSelectorRequirement{{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"unknown-zone"}}},
+				ResourceRequirements: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1000")},
+				},
+			})
+			ExpectApplied(ctx, env.Client, pod)
+			results, err := prov.Schedule(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(explanationFor(results, pod).Relaxations).To(ContainElement(ContainSubstring("preferredDuringSchedulingIgnoredDuringExecution")))
+		})
+		It("should annotate pods with their explanation and summarize rejections on the nodepool", func() {
+			nodePool := test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{Template: v1beta1.NodeClaimTemplate{Spec: v1beta1.NodeClaimSpec{
+				Taints: []v1.Taint{{Key: "test-key", Value: "test-value", Effect: v1.TaintEffectNoSchedule}},
+			}}}})
+			pods := test.UnschedulablePods(test.PodOptions{}, 2)
+			ExpectApplied(ctx, env.Client, nodePool, pods[0], pods[1])
+			results, err := prov.Schedule(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(prov.RecordExplanations(ctx, results)).To(Succeed())
+
+			for _, pod := range pods {
+				pod = ExpectExists(ctx, env.Client, pod)
+				Expect(pod.Annotations).To(HaveKey(v1beta1.ProvisioningExplanationAnnotationKey))
+				explanation := &scheduler.Explanation{}
+				Expect(json.Unmarshal([]byte(pod.Annotations[v1beta1.ProvisioningExplanationAnnotationKey]), explanation)).To(Succeed())
+				Expect(explanation.NodePools).To(HaveLen(1))
+				Expect(explanation.NodePools[0].Reason).To(Equal(scheduler.RejectionReasonTaints))
+			}
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+			Expect(nodePool.Status.PodRejections).To(HaveLen(1))
+			Expect(nodePool.Status.PodRejections[0].Reason).To(Equal(string(scheduler.RejectionReasonTaints)))
+			Expect(nodePool.Status.PodRejections[0].Count).To(BeNumerically("==", 2))
+			Expect(nodePool.Status.PodRejections[0].Pods).To(ConsistOf(
+				client.ObjectKeyFromObject(pods[0]).String(),
+				client.ObjectKeyFromObject(pods[1]).String(),
+			))
+		})
+		It("should bound the size of the explanation annotation", func() {
+			// enough taints that the message of each rejection is longer than the annotation keeps
+			taints := lo.Times(50, func(i int) v1.Taint {
+				return v1.Taint{Key: fmt.Sprintf("test-key-%d", i), Value: "test-value", Effect: v1.TaintEffectNoSchedule}
+			})
+			nodePools := test.NodePools(12, v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{Template: v1beta1.NodeClaimTemplate{Spec: v1beta1.NodeClaimSpec{
+				Taints: taints,
+			}}}})
+			for _, nodePool := range nodePools {
+				ExpectApplied(ctx, env.Client, nodePool)
+			}
+			pod := test.UnschedulablePod()
+			ExpectApplied(ctx, env.Client, pod)
+			results, err := prov.Schedule(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(explanationFor(results, pod).NodePools).To(HaveLen(12))
+			Expect(prov.RecordExplanations(ctx, results)).To(Succeed())
+
+			pod = ExpectExists(ctx, env.Client, pod)
+			explanation := &scheduler.Explanation{}
+			Expect(json.Unmarshal([]byte(pod.Annotations[v1beta1.ProvisioningExplanationAnnotationKey]), explanation)).To(Succeed())
+			Expect(explanation.NodePools).To(HaveLen(10))
+			Expect(explanation.OmittedNodePools).To(Equal(2))
+			for _, rejection := range explanation.NodePools {
+				Expect(rejection.Message).To(HaveLen(1024 + len("...")))
+			}
+		})
+		It("should only update the pod rejections on the nodepool when they change", func() {
+			nodePool := test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{Template: v1beta1.NodeClaimTemplate{Spec: v1beta1.NodeClaimSpec{
+				Taints: []v1.Taint{{Key: "test-key", Value: "test-value", Effect: v1.TaintEffectNoSchedule}},
+			}}}})
+			pod := test.UnschedulablePod()
+			ExpectApplied(ctx, env.Client, nodePool, pod)
+			results, err := prov.Schedule(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(prov.RecordExplanations(ctx, results)).To(Succeed())
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+			Expect(nodePool.Status.PodRejections).To(HaveLen(1))
+
+			results, err = prov.Schedule(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(prov.RecordExplanations(ctx, results)).To(Succeed())
+			Expect(ExpectExists(ctx, env.Client, nodePool).ResourceVersion).To(Equal(nodePool.ResourceVersion))
+		})
+		It("should remove the explanation once a pod can be scheduled", func() {
+			nodePool := test.NodePool()
+			nodePool.Status.PodRejections = []v1beta1.PodRejection{{Reason: string(scheduler.RejectionReasonTaints), Count: 1}}
+			pod := test.UnschedulablePod(test.PodOptions{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{v1beta1.ProvisioningExplanationAnnotationKey: "{}"},
+			}})
+			ExpectApplied(ctx, env.Client, nodePool, pod)
+			results, err := prov.Schedule(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results.PodErrors).To(BeEmpty())
+			Expect(prov.RecordExplanations(ctx, results)).To(Succeed())
+
+			pod = ExpectExists(ctx, env.Client, pod)
+			Expect(pod.Annotations).ToNot(HaveKey(v1beta1.ProvisioningExplanationAnnotationKey))
+			nodePool = ExpectExists(ctx, env.Client, nodePool)
+			Expect(nodePool.Status.PodRejections).To(BeEmpty())
+		})
+	})
+	Context("Batching", func() {
+		var pod *v1.Pod
+		BeforeEach(func() {
+			pod = test.UnschedulablePod()
+		})
+		It("should request the configured batching window by default", func() {
+			Expect(provisioning.BatchWindowFor(ctx, pod, &v1beta1.NodePoolList{Items: []v1beta1.NodePool{*test.NodePool()}})).To(Equal(&provisioning.BatchWindow{IdleDuration: time.Second, MaxDuration: 10 * time.Second}))
+		})
+		It("should use the batching window of the NodePool that the pod would schedule to", func() {
+			nodePool := test.NodePool()
+			nodePool.Spec.Batching = &v1beta1.Batching{IdleDuration: &metav1.Duration{Duration: 100 * time.Millisecond}}
+			window := provisioning.BatchWindowFor(ctx, pod, &v1beta1.NodePoolList{Items: []v1beta1.NodePool{*nodePool}})
+			Expect(window).To(Equal(&provisioning.BatchWindow{IdleDuration: 100 * time.Millisecond, MaxDuration: 10 * time.Second}))
+		})
+		It("should use the batching window of the highest weighted NodePool that the pod would schedule to", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{BatchMaxDuration: lo.ToPtr(time.Minute)}))
+			tainted := test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{
+				Weight:   lo.ToPtr[int32](100),
+				Batching: &v1beta1.Batching{MaxDuration: &metav1.Duration{Duration: 30 * time.Second}},
+				Template: v1beta1.NodeClaimTemplate{Spec: v1beta1.NodeClaimSpec{
+					Taints: []v1.Taint{{Key: "ci", Effect: v1.TaintEffectNoSchedule}},
+				}},
+			}})
+			low := test.NodePool(v1beta1.NodePool{Spec: v1beta1.NodePoolSpec{
+				Weight:   lo.ToPtr[int32](1),
+				Batching: &v1beta1.Batching{MaxDuration: &metav1.Duration{Duration: 5 * time.Second}},
+			}})
+			nodePools := &v1beta1.NodePoolList{Items: []v1beta1.NodePool{*low, *tainted}}
+			Expect(provisioning.BatchWindowFor(ctx, pod, nodePools)).To(Equal(&provisioning.BatchWindow{IdleDuration: time.Second, MaxDuration: 5 * time.Second}))
+			pod.Spec.Tolerations = []v1.Toleration{{Key: "ci", Operator: v1.TolerationOpExists}}
+			Expect(provisioning.BatchWindowFor(ctx, pod, nodePools)).To(Equal(&provisioning.BatchWindow{IdleDuration: time.Second, MaxDuration: 30 * time.Second}))
+		})
+		It("should prefer the batching window of the pod's annotations", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{BatchMaxDuration: lo.ToPtr(time.Minute)}))
+			nodePool := test.NodePool()
+			nodePool.Spec.Batching = &v1beta1.Batching{
+				IdleDuration: &metav1.Duration{Duration: 5 * time.Second},
+				MaxDuration:  &metav1.Duration{Duration: 30 * time.Second},
+			}
+			pod.Annotations = map[string]string{v1beta1.BatchIdleDurationAnnotationKey: "200ms"}
+			window := provisioning.BatchWindowFor(ctx, pod, &v1beta1.NodePoolList{Items: []v1beta1.NodePool{*nodePool}})
+			Expect(window).To(Equal(&provisioning.BatchWindow{IdleDuration: 200 * time.Millisecond, MaxDuration: 30 * time.Second}))
+		})
+		It("should ignore batching annotations that aren't valid durations", func() {
+			pod.Annotations = map[string]string{v1beta1.BatchIdleDurationAnnotationKey: "soon", v1beta1.BatchMaxDurationAnnotationKey: "-1s"}
+			Expect(provisioning.BatchWindowFor(ctx, pod, &v1beta1.NodePoolList{})).To(Equal(&provisioning.BatchWindow{IdleDuration: time.Second, MaxDuration: 10 * time.Second}))
+		})
+		It("should not request a batching window longer than the configured maximum duration", func() {
+			nodePool := test.NodePool()
+			nodePool.Spec.Batching = &v1beta1.Batching{MaxDuration: &metav1.Duration{Duration: time.Hour}}
+			Expect(provisioning.BatchWindowFor(ctx, pod, &v1beta1.NodePoolList{Items: []v1beta1.NodePool{*nodePool}})).To(Equal(&provisioning.BatchWindow{IdleDuration: time.Second, MaxDuration: 10 * time.Second}))
+			pod.Annotations = map[string]string{v1beta1.BatchIdleDurationAnnotationKey: "1h", v1beta1.BatchMaxDurationAnnotationKey: "1h"}
+			Expect(provisioning.BatchWindowFor(ctx, pod, &v1beta1.NodePoolList{})).To(Equal(&provisioning.BatchWindow{IdleDuration: 10 * time.Second, MaxDuration: 10 * time.Second}))
+		})
+		It("should end the batch once the shortest requested idle duration passes", func() {
+			batcher := provisioning.NewBatcher()
+			batcher.TriggerPod(pod.UID, &provisioning.BatchWindow{IdleDuration: 50 * time.Millisecond, MaxDuration: 10 * time.Second})
+			batcher.TriggerPod(test.UnschedulablePod().UID, nil)
+			start := time.Now()
+			Expect(batcher.Wait(ctx)).To(BeTrue())
+			Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+		})
+		It("should end the batch once the configured idle duration passes if a pod doesn't request a window", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{BatchIdleDuration: lo.ToPtr(50 * time.Millisecond)}))
+			batcher := provisioning.NewBatcher()
+			batcher.TriggerPod(pod.UID, &provisioning.BatchWindow{IdleDuration: time.Hour, MaxDuration: time.Hour})
+			batcher.TriggerPod(test.UnschedulablePod().UID, nil)
+			start := time.Now()
+			Expect(batcher.Wait(ctx)).To(BeTrue())
+			Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+		})
+		It("should not extend the batch past the configured maximum duration", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{BatchMaxDuration: lo.ToPtr(100 * time.Millisecond)}))
+			batcher := provisioning.NewBatcher()
+			batcher.TriggerPod(pod.UID, &provisioning.BatchWindow{IdleDuration: time.Hour, MaxDuration: time.Hour})
+			start := time.Now()
+			Expect(batcher.Wait(ctx)).To(BeTrue())
+			Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+		})
+		It("should shorten the idle duration of small batches when batching is adaptive", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{BatchIdleDuration: lo.ToPtr(2 * time.Second), BatchAdaptive: lo.ToPtr(true)}))
+			batcher := provisioning.NewBatcher()
+			batcher.TriggerPod(pod.UID, nil)
+			start := time.Now()
+			Expect(batcher.Wait(ctx)).To(BeTrue())
+			Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		})
+		It("should not shorten the idle duration of small batches when the pending backlog is large", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{BatchIdleDuration: lo.ToPtr(time.Second), BatchAdaptive: lo.ToPtr(true)}))
+			batcher := provisioning.NewBatcher()
+			batcher.SetBacklog(50)
+			batcher.TriggerPod(pod.UID, nil)
+			start := time.Now()
+			Expect(batcher.Wait(ctx)).To(BeTrue())
+			Expect(time.Since(start)).To(BeNumerically(">=", time.Second))
+		})
+		It("should keep the batch of a pod that requested a longer window open when a shorter window ends", func() {
+			long := test.UnschedulablePod()
+			batcher := provisioning.NewBatcher()
+			batcher.TriggerPod(pod.UID, &provisioning.BatchWindow{IdleDuration: 50 * time.Millisecond, MaxDuration: 10 * time.Second})
+			batcher.TriggerPod(long.UID, &provisioning.BatchWindow{IdleDuration: time.Hour, MaxDuration: time.Hour})
+			start := time.Now()
+			Expect(batcher.Wait(ctx)).To(BeTrue())
+			Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+			Expect(batcher.Waiting(pod.UID)).To(BeFalse())
+			Expect(batcher.Waiting(long.UID)).To(BeTrue())
+		})
+		It("should only provision for the pods whose batching window has ended", func() {
+			pod.Annotations = map[string]string{v1beta1.BatchIdleDurationAnnotationKey: "50ms"}
+			pod.Spec.NodeSelector = map[string]string{v1.LabelTopologyZone: "test-zone-1"}
+			long := test.UnschedulablePod(test.PodOptions{
+				ObjectMeta:   metav1.ObjectMeta{Annotations: map[string]string{v1beta1.BatchIdleDurationAnnotationKey: "1h"}},
+				NodeSelector: map[string]string{v1.LabelTopologyZone: "test-zone-2"},
+			})
+			ExpectApplied(ctx, env.Client, test.NodePool(), pod, long)
+			prov.TriggerPod(ctx, pod)
+			prov.TriggerPod(ctx, long)
+			_, err := prov.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ToNot(HaveOccurred())
+
+			nodeClaims := ExpectNodeClaims(ctx, env.Client)
+			Expect(nodeClaims).To(HaveLen(1))
+			Expect(scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaims[0].Spec.Requirements...).Get(v1.LabelTopologyZone).Values()).To(ConsistOf("test-zone-1"))
+		})
+		It("should not start a batch without a trigger", func() {
+			Expect(provisioning.NewBatcher().Wait(ctx)).To(BeFalse())
+		})
+	})
+	Context("Simulation", func() {
+		var server *provisioning.SimulationServer
+		BeforeEach(func() {
+			server = provisioning.NewSimulationServer(prov, cluster)
+		})
+		It("should return the nodeclaims that would be launched without launching them", func() {
+			nodePool := test.NodePool()
+			ExpectApplied(ctx, env.Client, nodePool)
+			pods := test.UnschedulablePods(test.PodOptions{ResourceRequirements: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			}}, 2)
+			results, err := server.Simulate(ctx, pods)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results.PodErrors).To(BeEmpty())
+			Expect(results.NodeClaims).To(HaveLen(1))
+			Expect(results.NodeClaims[0].NodePool).To(Equal(nodePool.Name))
+			Expect(results.NodeClaims[0].Pods).To(ConsistOf(client.ObjectKeyFromObject(pods[0]).String(), client.ObjectKeyFromObject(pods[1]).String()))
+			Expect(results.NodeClaims[0].InstanceTypes).ToNot(BeEmpty())
+			Expect(results.NodeClaims[0].Price).To(BeNumerically(">", 0))
+			Expect(results.NodeClaims[0].Price).To(Equal(results.NodeClaims[0].InstanceTypes[0].Price))
+			for _, it := range results.NodeClaims[0].InstanceTypes {
+				Expect(it.Price).To(BeNumerically(">=", results.NodeClaims[0].Price))
+			}
+			Expect(cloudProvider.CreateCalls).To(BeEmpty())
+			ExpectNotScheduled(ctx, env.Client, pods[0])
+		})
+		It("should return the existing nodes that pods would schedule to", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool())
+			node := test.Node(test.NodeOptions{Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("10"),
+				v1.ResourceMemory: resource.MustParse("10Gi"),
+				v1.ResourcePods:   resource.MustParse("110"),
+			}})
+			ExpectApplied(ctx, env.Client, node)
+			ExpectMakeNodesInitialized(ctx, env.Client, node)
+			ExpectReconcileSucceeded(ctx, nodeController, client.ObjectKeyFromObject(node))
+
+			pod := test.UnschedulablePod()
+			results, err := server.Simulate(ctx, []*v1.Pod{pod})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results.NodeClaims).To(BeEmpty())
+			Expect(results.ExistingNodes).To(HaveLen(1))
+			Expect(results.ExistingNodes[0].Name).To(Equal(node.Name))
+			Expect(results.ExistingNodes[0].Pods).To(ConsistOf(client.ObjectKeyFromObject(pod).String()))
+		})
+		It("should return the pods that couldn't schedule", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool())
+			pod := test.UnschedulablePod(test.PodOptions{NodeSelector: map[string]string{v1.LabelTopologyZone: "unknown"}})
+			results, err := server.Simulate(ctx, []*v1.Pod{pod})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results.NodeClaims).To(BeEmpty())
+			Expect(results.PodErrors).To(HaveKey(client.ObjectKeyFromObject(pod).String()))
+		})
+		It("should fail when there are no nodepools", func() {
+			_, err := server.Simulate(ctx, []*v1.Pod{test.UnschedulablePod()})
+			Expect(errors.Is(err, provisioning.ErrNodePoolsNotFound)).To(BeTrue())
+		})
+		It("should serve simulations for pods that are submitted without metadata", func() {
+			ExpectApplied(ctx, env.Client, test.NodePool())
+			body, err := json.Marshal(provisioning.SimulationRequest{Pods: []v1.Pod{{
+				Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "app"}}},
+			}}})
+			Expect(err).ToNot(HaveOccurred())
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)).WithContext(ctx))
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			results := &provisioning.SimulationResults{}
+			Expect(json.NewDecoder(recorder.Body).Decode(results)).To(Succeed())
+			Expect(results.NodeClaims).To(HaveLen(1))
+			Expect(results.NodeClaims[0].Pods).To(ConsistOf("default/simulated-pod-0"))
+		})
+		It("should reject requests that aren't posted", func() {
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+			Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
 	Context("Daemonsets and Node Overhead", func() {
 		It("should account for overhead", func() {
 			ExpectApplied(ctx, env.Client, test.NodePool(), test.DaemonSet(
@@ -932,13 +1726,13 @@ var _ = Describe("Provisioning", func() {
 							Labels: map[string]string{"test-key-1": "test-value-1"},
 						},
 						Spec: v1beta1.NodeClaimSpec{
-							Requirements: []v1.NodeSelectorRequirement{
-								{Key: "test-key-2", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value-2"}},
-								{Key: "test-key-3", Operator: v1.NodeSelectorOpNotIn, Values: []string{"test-value-3"}},
-								{Key: "test-key-4", Operator: v1.NodeSelectorOpLt, Values: []string{"4"}},
-								{Key: "test-key-5", Operator: v1.NodeSelectorOpGt, Values: []string{"5"}},
-								{Key: "test-key-6", Operator: v1.NodeSelectorOpExists},
-								{Key: "test-key-7", Operator: v1.NodeSelectorOpDoesNotExist},
+							Requirements: []v1beta1.NodeSelectorRequirementWithMinValues{
+								{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key-2", Operator: v1.NodeSelectorOpIn, Values: []string{"test-value-2"}}},
+								{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key-3", Operator: v1.NodeSelectorOpNotIn, Values: []string{"test-value-3"}}},
+								{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key-4", Operator: v1.NodeSelectorOpLt, Values: []string{"4"}}},
+								{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key-5", Operator: v1.NodeSelectorOpGt, Values: []string{"5"}}},
+								{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key-6", Operator: v1.NodeSelectorOpExists}},
+								{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "test-key-7", Operator: v1.NodeSelectorOpDoesNotExist}},
 							},
 						},
 					},
@@ -1079,17 +1873,17 @@ var _ = Describe("Provisioning", func() {
 				Spec: v1beta1.NodePoolSpec{
 					Template: v1beta1.NodeClaimTemplate{
 						Spec: v1beta1.NodeClaimSpec{
-							Requirements: []v1.NodeSelectorRequirement{
-								{
+							Requirements: []v1beta1.NodeSelectorRequirementWithMinValues{
+								{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 									Key:      "custom-requirement-key",
 									Operator: v1.NodeSelectorOpIn,
 									Values:   []string{"value"},
-								},
-								{
+								}},
+								{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 									Key:      "custom-requirement-key2",
 									Operator: v1.NodeSelectorOpIn,
 									Values:   []string{"value"},
-								},
+								}},
 							},
 						},
 					},
@@ -1129,12 +1923,12 @@ var _ = Describe("Provisioning", func() {
 				Spec: v1beta1.NodePoolSpec{
 					Template: v1beta1.NodeClaimTemplate{
 						Spec: v1beta1.NodeClaimSpec{
-							Requirements: []v1.NodeSelectorRequirement{
-								{
+							Requirements: []v1beta1.NodeSelectorRequirementWithMinValues{
+								{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 									Key:      v1.LabelArchStable,
 									Operator: v1.NodeSelectorOpIn,
 									Values:   []string{"arm64"},
-								},
+								}},
 							},
 						},
 					},
@@ -1166,12 +1960,12 @@ var _ = Describe("Provisioning", func() {
 				Spec: v1beta1.NodePoolSpec{
 					Template: v1beta1.NodeClaimTemplate{
 						Spec: v1beta1.NodeClaimSpec{
-							Requirements: []v1.NodeSelectorRequirement{
-								{
+							Requirements: []v1beta1.NodeSelectorRequirementWithMinValues{
+								{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 									Key:      v1.LabelOSStable,
 									Operator: v1.NodeSelectorOpIn,
 									Values:   []string{"ios"},
-								},
+								}},
 							},
 						},
 					},
@@ -1614,7 +2408,7 @@ var _ = Describe("Provisioning", func() {
 					Spec: v1beta1.NodePoolSpec{
 						Template: v1beta1.NodeClaimTemplate{
 							Spec: v1beta1.NodeClaimSpec{
-								Requirements: []v1.NodeSelectorRequirement{{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}},
+								Requirements: []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1"}}}},
 							},
 						},
 					},
@@ -1690,7 +2484,7 @@ var _ = Describe("Provisioning", func() {
 					Spec: v1beta1.NodePoolSpec{
 						Template: v1beta1.NodeClaimTemplate{
 							Spec: v1beta1.NodeClaimSpec{
-								Requirements: []v1.NodeSelectorRequirement{{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1", "test-zone-2"}}},
+								Requirements: []v1beta1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"test-zone-1", "test-zone-2"}}}},
 							},
 						},
 					},
@@ -1809,7 +2603,7 @@ var _ = Describe("Provisioning", func() {
 func ExpectNodeClaimRequirements(nodeClaim *v1beta1.NodeClaim, requirements ...v1.NodeSelectorRequirement) {
 	GinkgoHelper()
 	for _, requirement := range requirements {
-		req, ok := lo.Find(nodeClaim.Spec.Requirements, func(r v1.NodeSelectorRequirement) bool {
+		req, ok := lo.Find(nodeClaim.Spec.Requirements, func(r v1beta1.NodeSelectorRequirementWithMinValues) bool {
 			return r.Key == requirement.Key && r.Operator == requirement.Operator
 		})
 		Expect(ok).To(BeTrue())
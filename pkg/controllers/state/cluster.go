@@ -404,6 +404,7 @@ func (c *Cluster) newStateFromNodeClaim(nodeClaim *v1beta1.NodeClaim, oldNode *S
 		volumeUsage:       oldNode.volumeUsage,
 		markedForDeletion: oldNode.markedForDeletion,
 		nominatedUntil:    oldNode.nominatedUntil,
+		lastPodEventTime:  oldNode.lastPodEventTime,
 	}
 	// Cleanup the old nodeClaim with its old providerID if its providerID changes
 	// This can happen since nodes don't get created with providerIDs. Rather, CCM picks up the
@@ -445,6 +446,7 @@ func (c *Cluster) newStateFromNode(ctx context.Context, node *v1.Node, oldNode *
 		volumeUsage:       scheduling.NewVolumeUsage(),
 		markedForDeletion: oldNode.markedForDeletion,
 		nominatedUntil:    oldNode.nominatedUntil,
+		lastPodEventTime:  oldNode.lastPodEventTime,
 	}
 	if err := multierr.Combine(
 		c.populateResourceRequests(ctx, n),
@@ -523,6 +525,9 @@ func (c *Cluster) updateNodeUsageFromPod(ctx context.Context, pod *v1.Pod) error
 	if err := n.updateForPod(ctx, c.kubeClient, pod); err != nil {
 		return err
 	}
+	if nodeName, bindingKnown := c.bindings[client.ObjectKeyFromObject(pod)]; !bindingKnown || nodeName != pod.Spec.NodeName {
+		n.lastPodEventTime = metav1.Time{Time: c.clock.Now()}
+	}
 	c.cleanupOldBindings(pod)
 	c.bindings[client.ObjectKeyFromObject(pod)] = pod.Spec.NodeName
 	return nil
@@ -542,6 +547,7 @@ func (c *Cluster) updateNodeUsageFromPodCompletion(podKey types.NamespacedName)
 		return
 	}
 	n.cleanupForPod(podKey)
+	n.lastPodEventTime = metav1.Time{Time: c.clock.Now()}
 }
 
 func (c *Cluster) cleanupOldBindings(pod *v1.Pod) {
@@ -555,6 +561,7 @@ func (c *Cluster) cleanupOldBindings(pod *v1.Pod) {
 		if oldNode, ok := c.nodes[c.nodeNameToProviderID[oldNodeName]]; ok {
 			// we were tracking the old node, so we need to reduce its capacity by the amount of the pod that left
 			oldNode.cleanupForPod(client.ObjectKeyFromObject(pod))
+			oldNode.lastPodEventTime = metav1.Time{Time: c.clock.Now()}
 			delete(c.bindings, client.ObjectKeyFromObject(pod))
 		}
 	}
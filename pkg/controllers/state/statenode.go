@@ -106,6 +106,8 @@ type StateNode struct {
 	// of the karpenter.sh/disruption taint to know when a node is marked for deletion.
 	markedForDeletion bool
 	nominatedUntil    metav1.Time
+	// lastPodEventTime is the last time that a pod was bound to or removed from the node
+	lastPodEventTime metav1.Time
 }
 
 func NewNode() *StateNode {
@@ -330,6 +332,18 @@ func (in *StateNode) Nominated() bool {
 	return in.nominatedUntil.After(time.Now())
 }
 
+// LastPodEventTime returns the last time that a pod was bound to or removed from the node. Cluster state only observes
+// these changes while Karpenter is running, so this falls back to the creation time of the node.
+func (in *StateNode) LastPodEventTime() time.Time {
+	if !in.lastPodEventTime.IsZero() {
+		return in.lastPodEventTime.Time
+	}
+	if in.Node != nil {
+		return in.Node.CreationTimestamp.Time
+	}
+	return in.NodeClaim.CreationTimestamp.Time
+}
+
 func (in *StateNode) Managed() bool {
 	return in.NodeClaim != nil
 }
@@ -832,17 +832,17 @@ var _ = Describe("Node Resource Level", func() {
 				Finalizers: []string{v1beta1.TerminationFinalizer},
 			},
 			Spec: v1beta1.NodeClaimSpec{
-				Requirements: []v1.NodeSelectorRequirement{
-					{
+				Requirements: []v1beta1.NodeSelectorRequirementWithMinValues{
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 						Key:      v1.LabelInstanceTypeStable,
 						Operator: v1.NodeSelectorOpIn,
 						Values:   []string{cloudProvider.InstanceTypes[0].Name},
-					},
-					{
+					}},
+					{NodeSelectorRequirement: v1.NodeSelectorRequirement{
 						Key:      v1.LabelTopologyZone,
 						Operator: v1.NodeSelectorOpIn,
 						Values:   []string{"test-zone-1"},
-					},
+					}},
 				},
 				NodeClassRef: &v1beta1.NodeClassReference{
 					Name: "default",
@@ -910,6 +910,40 @@ var _ = Describe("Node Resource Level", func() {
 		time.Sleep(time.Second * 11) // past 20s, node should no longer be nominated
 		Expect(ExpectStateNodeExists(cluster, node).Nominated()).To(BeFalse())
 	})
+	It("should track the last time that a pod was bound to or removed from the node", func() {
+		pod := test.UnschedulablePod()
+		node := test.Node(test.NodeOptions{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+				v1beta1.NodePoolLabelKey:   nodePool.Name,
+				v1.LabelInstanceTypeStable: cloudProvider.InstanceTypes[0].Name,
+			}},
+			Allocatable: map[v1.ResourceName]resource.Quantity{
+				v1.ResourceCPU: resource.MustParse("4"),
+			},
+			ProviderID: test.RandomProviderID(),
+		})
+		ExpectApplied(ctx, env.Client, pod, node)
+		ExpectReconcileSucceeded(ctx, nodeController, client.ObjectKeyFromObject(node))
+
+		// Without any pod events, we fall back to the creation time of the node
+		node = ExpectExists(ctx, env.Client, node)
+		Expect(ExpectStateNodeExists(cluster, node).LastPodEventTime()).To(BeTemporally("==", node.CreationTimestamp.Time))
+
+		fakeClock.Step(time.Minute)
+		ExpectManualBinding(ctx, env.Client, pod, node)
+		ExpectReconcileSucceeded(ctx, podController, client.ObjectKeyFromObject(pod))
+		Expect(ExpectStateNodeExists(cluster, node).LastPodEventTime()).To(BeTemporally("==", fakeClock.Now()))
+
+		// Updates for a pod that is already bound don't change the time
+		bound := fakeClock.Now()
+		fakeClock.Step(time.Minute)
+		ExpectReconcileSucceeded(ctx, podController, client.ObjectKeyFromObject(pod))
+		Expect(ExpectStateNodeExists(cluster, node).LastPodEventTime()).To(BeTemporally("==", bound))
+
+		ExpectDeleted(ctx, env.Client, pod)
+		ExpectReconcileSucceeded(ctx, podController, client.ObjectKeyFromObject(pod))
+		Expect(ExpectStateNodeExists(cluster, node).LastPodEventTime()).To(BeTemporally("==", fakeClock.Now()))
+	})
 	It("should handle a node changing from no providerID to registering a providerID", func() {
 		node := test.Node()
 		ExpectApplied(ctx, env.Client, node)
@@ -124,6 +124,7 @@ func (in *StateNode) DeepCopyInto(out *StateNode) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.nominatedUntil.DeepCopyInto(&out.nominatedUntil)
+	in.lastPodEventTime.DeepCopyInto(&out.lastPodEventTime)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateNode.
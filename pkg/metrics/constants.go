@@ -36,6 +36,7 @@ const (
 	ExpirationReason    = "expiration"
 	EmptinessReason     = "emptiness"
 	DriftReason         = "drift"
+	MinimumReason       = "minimum"
 )
 
 // DurationBuckets returns a []float64 of default threshold values for duration histograms.
@@ -25,6 +25,9 @@ import (
 	"runtime/debug"
 	"sync"
 	"time"
+	// Embed the IANA time zone database so that the time zones of disruption budgets can be resolved without relying on
+	// the image having one
+	_ "time/tzdata"
 
 	"github.com/prometheus/client_golang/prometheus"
 	coordinationv1 "k8s.io/api/coordination/v1"
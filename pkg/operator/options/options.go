@@ -22,6 +22,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/samber/lo"
@@ -30,8 +31,17 @@ import (
 	"sigs.k8s.io/karpenter/pkg/utils/env"
 )
 
+const (
+	// ConsolidationPlannerBinarySearch finds multi-node consolidations by binary searching the candidates in order of
+	// disruption cost
+	ConsolidationPlannerBinarySearch = "binary-search"
+	// ConsolidationPlannerGlobal finds multi-node consolidations by searching subsets of the candidates
+	ConsolidationPlannerGlobal = "global"
+)
+
 var (
-	validLogLevels = []string{"", "debug", "info", "error"}
+	validLogLevels             = []string{"", "debug", "info", "error"}
+	validConsolidationPlanners = []string{ConsolidationPlannerBinarySearch, ConsolidationPlannerGlobal}
 
 	Injectables = []Injectable{&Options{}}
 )
@@ -43,6 +53,7 @@ type FeatureGates struct {
 
 	Drift                   bool
 	SpotToSpotConsolidation bool
+	PreProvisionGatedPods   bool
 }
 
 // Options contains all CLI flags / env vars for karpenter-core. It adheres to the options.Injectable interface.
@@ -53,6 +64,8 @@ type Options struct {
 	MetricsPort          int
 	WebhookMetricsPort   int
 	HealthProbePort      int
+	SimulationPort       int
+	SimulationAddress    string
 	KubeClientQPS        int
 	KubeClientBurst      int
 	EnableProfiling      bool
@@ -61,7 +74,19 @@ type Options struct {
 	LogLevel             string
 	BatchMaxDuration     time.Duration
 	BatchIdleDuration    time.Duration
-	FeatureGates         FeatureGates
+	// BatchAdaptive scales the batching window with the number of pods in the batch, shortening it for a few pods and
+	// lengthening it for bursts of pods
+	BatchAdaptive bool
+	// DisruptionDryRun makes Karpenter report the disruptions that it would perform without performing them
+	DisruptionDryRun bool
+	// DisruptionRecordTTL is how long the records of executed disruption commands are kept for
+	DisruptionRecordTTL time.Duration
+	// ConsolidationPlanner is how multi-node consolidations are found
+	ConsolidationPlanner string
+	// PreProvisionSchedulingGates are the scheduling gates that pods can be held by while we provision capacity for
+	// them, when the PreProvisionGatedPods feature gate is enabled
+	PreProvisionSchedulingGates []string
+	FeatureGates                FeatureGates
 }
 
 type FlagSet struct {
@@ -88,6 +113,8 @@ func (o *Options) AddFlags(fs *FlagSet) {
 	fs.IntVar(&o.MetricsPort, "metrics-port", env.WithDefaultInt("METRICS_PORT", 8000), "The port the metric endpoint binds to for operating metrics about the controller itself")
 	fs.IntVar(&o.WebhookMetricsPort, "webhook-metrics-port", env.WithDefaultInt("WEBHOOK_METRICS_PORT", 8001), "The port the webhook metric endpoing binds to for operating metrics about the webhook")
 	fs.IntVar(&o.HealthProbePort, "health-probe-port", env.WithDefaultInt("HEALTH_PROBE_PORT", 8081), "The port the health probe endpoint binds to for reporting controller health")
+	fs.IntVar(&o.SimulationPort, "simulation-port", env.WithDefaultInt("SIMULATION_PORT", 0), "The port the scheduling simulation endpoint binds to for answering what-if questions about where pods would schedule. The endpoint is disabled when this is 0.")
+	fs.StringVar(&o.SimulationAddress, "simulation-address", env.WithDefaultString("SIMULATION_ADDRESS", "127.0.0.1"), "The address the scheduling simulation endpoint binds to. The endpoint doesn't authenticate its callers, so it only accepts connections from within the pod by default.")
 	fs.IntVar(&o.KubeClientQPS, "kube-client-qps", env.WithDefaultInt("KUBE_CLIENT_QPS", 200), "The smoothed rate of qps to kube-apiserver")
 	fs.IntVar(&o.KubeClientBurst, "kube-client-burst", env.WithDefaultInt("KUBE_CLIENT_BURST", 300), "The maximum allowed burst of queries to the kube-apiserver")
 	fs.BoolVarWithEnv(&o.EnableProfiling, "enable-profiling", "ENABLE_PROFILING", false, "Enable the profiling on the metric endpoint")
@@ -96,7 +123,16 @@ func (o *Options) AddFlags(fs *FlagSet) {
 	fs.StringVar(&o.LogLevel, "log-level", env.WithDefaultString("LOG_LEVEL", "info"), "Log verbosity level. Can be one of 'debug', 'info', or 'error'")
 	fs.DurationVar(&o.BatchMaxDuration, "batch-max-duration", env.WithDefaultDuration("BATCH_MAX_DURATION", 10*time.Second), "The maximum length of a batch window. The longer this is, the more pods we can consider for provisioning at one time which usually results in fewer but larger nodes.")
 	fs.DurationVar(&o.BatchIdleDuration, "batch-idle-duration", env.WithDefaultDuration("BATCH_IDLE_DURATION", time.Second), "The maximum amount of time with no new pending pods that if exceeded ends the current batching window. If pods arrive faster than this time, the batching window will be extended up to the maxDuration. If they arrive slower, the pods will be batched separately.")
-	fs.StringVar(&o.FeatureGates.inputStr, "feature-gates", env.WithDefaultString("FEATURE_GATES", "Drift=true,SpotToSpotConsolidation=false"), "Optional features can be enabled / disabled using feature gates. Current options are: Drift,SpotToSpotConsolidation")
+	fs.BoolVarWithEnv(&o.BatchAdaptive, "batch-adaptive", "BATCH_ADAPTIVE", false, "Scale the batching window with the number of pending pods. Batches of a few pods have their idle duration shortened so that they're provisioned for quickly, while large bursts of pods have their idle and max durations lengthened so that they're packed onto fewer, larger nodes.")
+	fs.BoolVarWithEnv(&o.DisruptionDryRun, "disruption-dry-run", "DISRUPTION_DRY_RUN", false, "Report the disruptions that Karpenter would perform through events and metrics without performing them. Nodes aren't tainted and no replacement nodes are launched.")
+	fs.DurationVar(&o.DisruptionRecordTTL, "disruption-record-ttl", env.WithDefaultDuration("DISRUPTION_RECORD_TTL", 7*24*time.Hour), "How long the DisruptionRecord of each disruption command that Karpenter executes is kept for before it's deleted.")
+	fs.StringVar(&o.ConsolidationPlanner, "consolidation-planner", env.WithDefaultString("CONSOLIDATION_PLANNER", ConsolidationPlannerBinarySearch), "How consolidations of multiple nodes are found. Can be one of 'binary-search' or 'global'. 'binary-search' considers the nodes that are cheapest to disrupt first. 'global' searches subsets of the nodes for the one that saves the most per unit of disruption cost, which finds better consolidations in fragmented clusters at the cost of more scheduling simulations.")
+	o.PreProvisionSchedulingGates = parseList(env.WithDefaultString("PRE_PROVISION_SCHEDULING_GATES", ""))
+	fs.Func("pre-provision-scheduling-gates", "Comma separated list of scheduling gates that Karpenter provisions capacity for gated pods ahead of time for. Pods with the karpenter.sh/pre-provision=true annotation are provisioned for regardless of their gates. Requires the PreProvisionGatedPods feature gate.", func(val string) error {
+		o.PreProvisionSchedulingGates = parseList(val)
+		return nil
+	})
+	fs.StringVar(&o.FeatureGates.inputStr, "feature-gates", env.WithDefaultString("FEATURE_GATES", "Drift=true,SpotToSpotConsolidation=false,PreProvisionGatedPods=false"), "Optional features can be enabled / disabled using feature gates. Current options are: Drift,SpotToSpotConsolidation,PreProvisionGatedPods")
 }
 
 func (o *Options) Parse(fs *FlagSet, args ...string) error {
@@ -110,6 +146,9 @@ func (o *Options) Parse(fs *FlagSet, args ...string) error {
 	if !lo.Contains(validLogLevels, o.LogLevel) {
 		return fmt.Errorf("validating cli flags / env vars, invalid log level %q", o.LogLevel)
 	}
+	if !lo.Contains(validConsolidationPlanners, o.ConsolidationPlanner) {
+		return fmt.Errorf("validating cli flags / env vars, invalid consolidation planner %q", o.ConsolidationPlanner)
+	}
 	gates, err := ParseFeatureGates(o.FeatureGates.inputStr)
 	if err != nil {
 		return fmt.Errorf("parsing feature gates, %w", err)
@@ -137,10 +176,24 @@ func ParseFeatureGates(gateStr string) (FeatureGates, error) {
 	if val, ok := gateMap["SpotToSpotConsolidation"]; ok {
 		gates.SpotToSpotConsolidation = val
 	}
+	if val, ok := gateMap["PreProvisionGatedPods"]; ok {
+		gates.PreProvisionGatedPods = val
+	}
 
 	return gates, nil
 }
 
+// parseList splits a comma separated list, dropping empty values
+func parseList(str string) []string {
+	var values []string
+	for _, value := range strings.Split(str, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func ToContext(ctx context.Context, opts *Options) context.Context {
 	return context.WithValue(ctx, optionsKey{}, opts)
 }
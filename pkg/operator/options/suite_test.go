@@ -50,6 +50,8 @@ var _ = Describe("Options", func() {
 		"METRICS_PORT",
 		"WEBHOOK_METRICS_PORT",
 		"HEALTH_PROBE_PORT",
+		"SIMULATION_PORT",
+		"SIMULATION_ADDRESS",
 		"KUBE_CLIENT_QPS",
 		"KUBE_CLIENT_BURST",
 		"ENABLE_PROFILING",
@@ -58,6 +60,11 @@ var _ = Describe("Options", func() {
 		"LOG_LEVEL",
 		"BATCH_MAX_DURATION",
 		"BATCH_IDLE_DURATION",
+		"BATCH_ADAPTIVE",
+		"DISRUPTION_DRY_RUN",
+		"DISRUPTION_RECORD_TTL",
+		"CONSOLIDATION_PLANNER",
+		"PRE_PROVISION_SCHEDULING_GATES",
 		"FEATURE_GATES",
 	}
 
@@ -101,6 +108,8 @@ var _ = Describe("Options", func() {
 				MetricsPort:          lo.ToPtr(8000),
 				WebhookMetricsPort:   lo.ToPtr(8001),
 				HealthProbePort:      lo.ToPtr(8081),
+				SimulationPort:       lo.ToPtr(0),
+				SimulationAddress:    lo.ToPtr("127.0.0.1"),
 				KubeClientQPS:        lo.ToPtr(200),
 				KubeClientBurst:      lo.ToPtr(300),
 				EnableProfiling:      lo.ToPtr(false),
@@ -109,6 +118,10 @@ var _ = Describe("Options", func() {
 				LogLevel:             lo.ToPtr("info"),
 				BatchMaxDuration:     lo.ToPtr(10 * time.Second),
 				BatchIdleDuration:    lo.ToPtr(time.Second),
+				BatchAdaptive:        lo.ToPtr(false),
+				DisruptionDryRun:     lo.ToPtr(false),
+				DisruptionRecordTTL:  lo.ToPtr(7 * 24 * time.Hour),
+				ConsolidationPlanner: lo.ToPtr("binary-search"),
 				FeatureGates: test.FeatureGates{
 					Drift: lo.ToPtr(true),
 				},
@@ -124,6 +137,8 @@ var _ = Describe("Options", func() {
 				"--metrics-port", "0",
 				"--webhook-metrics-port", "0",
 				"--health-probe-port", "0",
+				"--simulation-port", "8002",
+				"--simulation-address", "0.0.0.0",
 				"--kube-client-qps", "0",
 				"--kube-client-burst", "0",
 				"--enable-profiling",
@@ -132,26 +147,39 @@ var _ = Describe("Options", func() {
 				"--log-level", "debug",
 				"--batch-max-duration", "5s",
 				"--batch-idle-duration", "5s",
-				"--feature-gates", "Drift=true",
+				"--batch-adaptive",
+				"--disruption-dry-run",
+				"--disruption-record-ttl", "24h",
+				"--consolidation-planner", "global",
+				"--pre-provision-scheduling-gates", "example.com/queue, example.com/quota",
+				"--feature-gates", "Drift=true,PreProvisionGatedPods=true",
 			)
 			Expect(err).To(BeNil())
 			expectOptionsMatch(opts, test.Options(test.OptionsFields{
-				ServiceName:          lo.ToPtr("cli"),
-				DisableWebhook:       lo.ToPtr(true),
-				WebhookPort:          lo.ToPtr(0),
-				MetricsPort:          lo.ToPtr(0),
-				WebhookMetricsPort:   lo.ToPtr(0),
-				HealthProbePort:      lo.ToPtr(0),
-				KubeClientQPS:        lo.ToPtr(0),
-				KubeClientBurst:      lo.ToPtr(0),
-				EnableProfiling:      lo.ToPtr(true),
-				EnableLeaderElection: lo.ToPtr(false),
-				MemoryLimit:          lo.ToPtr[int64](0),
-				LogLevel:             lo.ToPtr("debug"),
-				BatchMaxDuration:     lo.ToPtr(5 * time.Second),
-				BatchIdleDuration:    lo.ToPtr(5 * time.Second),
+				ServiceName:                 lo.ToPtr("cli"),
+				DisableWebhook:              lo.ToPtr(true),
+				WebhookPort:                 lo.ToPtr(0),
+				MetricsPort:                 lo.ToPtr(0),
+				WebhookMetricsPort:          lo.ToPtr(0),
+				HealthProbePort:             lo.ToPtr(0),
+				SimulationPort:              lo.ToPtr(8002),
+				SimulationAddress:           lo.ToPtr("0.0.0.0"),
+				KubeClientQPS:               lo.ToPtr(0),
+				KubeClientBurst:             lo.ToPtr(0),
+				EnableProfiling:             lo.ToPtr(true),
+				EnableLeaderElection:        lo.ToPtr(false),
+				MemoryLimit:                 lo.ToPtr[int64](0),
+				LogLevel:                    lo.ToPtr("debug"),
+				BatchMaxDuration:            lo.ToPtr(5 * time.Second),
+				BatchIdleDuration:           lo.ToPtr(5 * time.Second),
+				BatchAdaptive:               lo.ToPtr(true),
+				DisruptionDryRun:            lo.ToPtr(true),
+				DisruptionRecordTTL:         lo.ToPtr(24 * time.Hour),
+				ConsolidationPlanner:        lo.ToPtr("global"),
+				PreProvisionSchedulingGates: []string{"example.com/queue", "example.com/quota"},
 				FeatureGates: test.FeatureGates{
-					Drift: lo.ToPtr(true),
+					Drift:                 lo.ToPtr(true),
+					PreProvisionGatedPods: lo.ToPtr(true),
 				},
 			}))
 		})
@@ -163,6 +191,8 @@ var _ = Describe("Options", func() {
 			os.Setenv("METRICS_PORT", "0")
 			os.Setenv("WEBHOOK_METRICS_PORT", "0")
 			os.Setenv("HEALTH_PROBE_PORT", "0")
+			os.Setenv("SIMULATION_PORT", "8002")
+			os.Setenv("SIMULATION_ADDRESS", "0.0.0.0")
 			os.Setenv("KUBE_CLIENT_QPS", "0")
 			os.Setenv("KUBE_CLIENT_BURST", "0")
 			os.Setenv("ENABLE_PROFILING", "true")
@@ -171,7 +201,12 @@ var _ = Describe("Options", func() {
 			os.Setenv("LOG_LEVEL", "debug")
 			os.Setenv("BATCH_MAX_DURATION", "5s")
 			os.Setenv("BATCH_IDLE_DURATION", "5s")
-			os.Setenv("FEATURE_GATES", "Drift=true")
+			os.Setenv("BATCH_ADAPTIVE", "true")
+			os.Setenv("DISRUPTION_DRY_RUN", "true")
+			os.Setenv("DISRUPTION_RECORD_TTL", "24h")
+			os.Setenv("CONSOLIDATION_PLANNER", "global")
+			os.Setenv("PRE_PROVISION_SCHEDULING_GATES", "example.com/queue,example.com/quota")
+			os.Setenv("FEATURE_GATES", "Drift=true,PreProvisionGatedPods=true")
 			fs = &options.FlagSet{
 				FlagSet: flag.NewFlagSet("karpenter", flag.ContinueOnError),
 			}
@@ -179,22 +214,30 @@ var _ = Describe("Options", func() {
 			err := opts.Parse(fs)
 			Expect(err).To(BeNil())
 			expectOptionsMatch(opts, test.Options(test.OptionsFields{
-				ServiceName:          lo.ToPtr("env"),
-				DisableWebhook:       lo.ToPtr(true),
-				WebhookPort:          lo.ToPtr(0),
-				MetricsPort:          lo.ToPtr(0),
-				WebhookMetricsPort:   lo.ToPtr(0),
-				HealthProbePort:      lo.ToPtr(0),
-				KubeClientQPS:        lo.ToPtr(0),
-				KubeClientBurst:      lo.ToPtr(0),
-				EnableProfiling:      lo.ToPtr(true),
-				EnableLeaderElection: lo.ToPtr(false),
-				MemoryLimit:          lo.ToPtr[int64](0),
-				LogLevel:             lo.ToPtr("debug"),
-				BatchMaxDuration:     lo.ToPtr(5 * time.Second),
-				BatchIdleDuration:    lo.ToPtr(5 * time.Second),
+				ServiceName:                 lo.ToPtr("env"),
+				DisableWebhook:              lo.ToPtr(true),
+				WebhookPort:                 lo.ToPtr(0),
+				MetricsPort:                 lo.ToPtr(0),
+				WebhookMetricsPort:          lo.ToPtr(0),
+				HealthProbePort:             lo.ToPtr(0),
+				SimulationPort:              lo.ToPtr(8002),
+				SimulationAddress:           lo.ToPtr("0.0.0.0"),
+				KubeClientQPS:               lo.ToPtr(0),
+				KubeClientBurst:             lo.ToPtr(0),
+				EnableProfiling:             lo.ToPtr(true),
+				EnableLeaderElection:        lo.ToPtr(false),
+				MemoryLimit:                 lo.ToPtr[int64](0),
+				LogLevel:                    lo.ToPtr("debug"),
+				BatchMaxDuration:            lo.ToPtr(5 * time.Second),
+				BatchIdleDuration:           lo.ToPtr(5 * time.Second),
+				BatchAdaptive:               lo.ToPtr(true),
+				DisruptionDryRun:            lo.ToPtr(true),
+				DisruptionRecordTTL:         lo.ToPtr(24 * time.Hour),
+				ConsolidationPlanner:        lo.ToPtr("global"),
+				PreProvisionSchedulingGates: []string{"example.com/queue", "example.com/quota"},
 				FeatureGates: test.FeatureGates{
-					Drift: lo.ToPtr(true),
+					Drift:                 lo.ToPtr(true),
+					PreProvisionGatedPods: lo.ToPtr(true),
 				},
 			}))
 		})
@@ -204,6 +247,8 @@ var _ = Describe("Options", func() {
 			os.Setenv("METRICS_PORT", "0")
 			os.Setenv("WEBHOOK_METRICS_PORT", "0")
 			os.Setenv("HEALTH_PROBE_PORT", "0")
+			os.Setenv("SIMULATION_PORT", "8002")
+			os.Setenv("SIMULATION_ADDRESS", "0.0.0.0")
 			os.Setenv("KUBE_CLIENT_QPS", "0")
 			os.Setenv("KUBE_CLIENT_BURST", "0")
 			os.Setenv("ENABLE_PROFILING", "true")
@@ -212,7 +257,12 @@ var _ = Describe("Options", func() {
 			os.Setenv("LOG_LEVEL", "debug")
 			os.Setenv("BATCH_MAX_DURATION", "5s")
 			os.Setenv("BATCH_IDLE_DURATION", "5s")
-			os.Setenv("FEATURE_GATES", "Drift=true")
+			os.Setenv("BATCH_ADAPTIVE", "true")
+			os.Setenv("DISRUPTION_DRY_RUN", "true")
+			os.Setenv("DISRUPTION_RECORD_TTL", "24h")
+			os.Setenv("CONSOLIDATION_PLANNER", "global")
+			os.Setenv("PRE_PROVISION_SCHEDULING_GATES", "example.com/queue,example.com/quota")
+			os.Setenv("FEATURE_GATES", "Drift=true,PreProvisionGatedPods=true")
 			fs = &options.FlagSet{
 				FlagSet: flag.NewFlagSet("karpenter", flag.ContinueOnError),
 			}
@@ -224,22 +274,30 @@ var _ = Describe("Options", func() {
 			)
 			Expect(err).To(BeNil())
 			expectOptionsMatch(opts, test.Options(test.OptionsFields{
-				ServiceName:          lo.ToPtr("cli"),
-				DisableWebhook:       lo.ToPtr(true),
-				WebhookPort:          lo.ToPtr(0),
-				MetricsPort:          lo.ToPtr(0),
-				WebhookMetricsPort:   lo.ToPtr(0),
-				HealthProbePort:      lo.ToPtr(0),
-				KubeClientQPS:        lo.ToPtr(0),
-				KubeClientBurst:      lo.ToPtr(0),
-				EnableProfiling:      lo.ToPtr(true),
-				EnableLeaderElection: lo.ToPtr(false),
-				MemoryLimit:          lo.ToPtr[int64](0),
-				LogLevel:             lo.ToPtr("debug"),
-				BatchMaxDuration:     lo.ToPtr(5 * time.Second),
-				BatchIdleDuration:    lo.ToPtr(5 * time.Second),
+				ServiceName:                 lo.ToPtr("cli"),
+				DisableWebhook:              lo.ToPtr(true),
+				WebhookPort:                 lo.ToPtr(0),
+				MetricsPort:                 lo.ToPtr(0),
+				WebhookMetricsPort:          lo.ToPtr(0),
+				HealthProbePort:             lo.ToPtr(0),
+				SimulationPort:              lo.ToPtr(8002),
+				SimulationAddress:           lo.ToPtr("0.0.0.0"),
+				KubeClientQPS:               lo.ToPtr(0),
+				KubeClientBurst:             lo.ToPtr(0),
+				EnableProfiling:             lo.ToPtr(true),
+				EnableLeaderElection:        lo.ToPtr(false),
+				MemoryLimit:                 lo.ToPtr[int64](0),
+				LogLevel:                    lo.ToPtr("debug"),
+				BatchMaxDuration:            lo.ToPtr(5 * time.Second),
+				BatchIdleDuration:           lo.ToPtr(5 * time.Second),
+				BatchAdaptive:               lo.ToPtr(true),
+				DisruptionDryRun:            lo.ToPtr(true),
+				DisruptionRecordTTL:         lo.ToPtr(24 * time.Hour),
+				ConsolidationPlanner:        lo.ToPtr("global"),
+				PreProvisionSchedulingGates: []string{"example.com/queue", "example.com/quota"},
 				FeatureGates: test.FeatureGates{
-					Drift: lo.ToPtr(true),
+					Drift:                 lo.ToPtr(true),
+					PreProvisionGatedPods: lo.ToPtr(true),
 				},
 			}))
 		})
@@ -274,6 +332,10 @@ var _ = Describe("Options", func() {
 			err := opts.Parse(fs, "--log-level", "hello")
 			Expect(err).ToNot(BeNil())
 		})
+		It("should error with an invalid consolidation planner", func() {
+			err := opts.Parse(fs, "--consolidation-planner", "hello")
+			Expect(err).ToNot(BeNil())
+		})
 	})
 })
 
@@ -290,6 +352,8 @@ func expectOptionsMatch(optsA, optsB *options.Options) {
 	Expect(optsA.MetricsPort).To(Equal(optsB.MetricsPort))
 	Expect(optsA.WebhookMetricsPort).To(Equal(optsB.WebhookMetricsPort))
 	Expect(optsA.HealthProbePort).To(Equal(optsB.HealthProbePort))
+	Expect(optsA.SimulationPort).To(Equal(optsB.SimulationPort))
+	Expect(optsA.SimulationAddress).To(Equal(optsB.SimulationAddress))
 	Expect(optsA.KubeClientQPS).To(Equal(optsB.KubeClientQPS))
 	Expect(optsA.KubeClientBurst).To(Equal(optsB.KubeClientBurst))
 	Expect(optsA.EnableProfiling).To(Equal(optsB.EnableProfiling))
@@ -298,5 +362,11 @@ func expectOptionsMatch(optsA, optsB *options.Options) {
 	Expect(optsA.LogLevel).To(Equal(optsB.LogLevel))
 	Expect(optsA.BatchMaxDuration).To(Equal(optsB.BatchMaxDuration))
 	Expect(optsA.BatchIdleDuration).To(Equal(optsB.BatchIdleDuration))
+	Expect(optsA.BatchAdaptive).To(Equal(optsB.BatchAdaptive))
+	Expect(optsA.DisruptionDryRun).To(Equal(optsB.DisruptionDryRun))
+	Expect(optsA.DisruptionRecordTTL).To(Equal(optsB.DisruptionRecordTTL))
+	Expect(optsA.ConsolidationPlanner).To(Equal(optsB.ConsolidationPlanner))
+	Expect(optsA.PreProvisionSchedulingGates).To(Equal(optsB.PreProvisionSchedulingGates))
 	Expect(optsA.FeatureGates.Drift).To(Equal(optsB.FeatureGates.Drift))
+	Expect(optsA.FeatureGates.PreProvisionGatedPods).To(Equal(optsB.FeatureGates.PreProvisionGatedPods))
 }
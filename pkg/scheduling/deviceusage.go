@@ -0,0 +1,192 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	v1 "k8s.io/api/core/v1"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeviceClaims are the ResourceClaims that need a device allocated, keyed by the name of the driver that allocates them
+type DeviceClaims map[string]sets.Set[string]
+
+func (d DeviceClaims) Add(driver string, claimID string) {
+	existing, ok := d[driver]
+	if !ok {
+		existing = sets.New[string]()
+		d[driver] = existing
+	}
+	existing.Insert(claimID)
+}
+
+func (d DeviceClaims) Union(claims DeviceClaims) DeviceClaims {
+	cp := DeviceClaims{}
+	for k, v := range d {
+		cp[k] = sets.New(sets.List(v)...)
+	}
+	for k, v := range claims {
+		existing, ok := cp[k]
+		if !ok {
+			existing = sets.New[string]()
+			cp[k] = existing
+		}
+		existing.Insert(sets.List(v)...)
+	}
+	return cp
+}
+
+// ExceedsCapacity returns an error if there are more claims for a driver than there are devices that it can allocate
+func (d DeviceClaims) ExceedsCapacity(capacity map[string]int) error {
+	for driver, claims := range d {
+		if len(claims) > capacity[driver] {
+			return fmt.Errorf("would exceed device capacity for %s, %d > %d", driver, len(claims), capacity[driver])
+		}
+	}
+	return nil
+}
+
+// DeviceRequests are the devices that a pod's ResourceClaims need allocated, along with the requirements for the
+// nodes that they can be allocated on
+type DeviceRequests struct {
+	Claims       DeviceClaims
+	Requirements Requirements
+}
+
+// GetDeviceRequests resolves the ResourceClaims that are referenced by the pod. Claims that haven't been created from
+// their ResourceClaimTemplate yet are modeled from the template so that we can provision for them ahead of time.
+//
+//nolint:gocyclo
+func GetDeviceRequests(ctx context.Context, kubeClient client.Client, pod *v1.Pod) (*DeviceRequests, error) {
+	devices := &DeviceRequests{Claims: DeviceClaims{}, Requirements: NewRequirements()}
+	for _, podClaim := range pod.Spec.ResourceClaims {
+		var claimID string
+		var spec resourcev1alpha2.ResourceClaimSpec
+		var status resourcev1alpha2.ResourceClaimStatus
+		claimName := podClaim.Source.ResourceClaimName
+		if podClaim.Source.ResourceClaimTemplateName != nil {
+			claimStatus, ok := lo.Find(pod.Status.ResourceClaimStatuses, func(s v1.PodResourceClaimStatus) bool { return s.Name == podClaim.Name })
+			// a nil name in the status means that the claim isn't needed
+			if ok && claimStatus.ResourceClaimName == nil {
+				continue
+			}
+			claimName = claimStatus.ResourceClaimName
+			if !ok {
+				template := &resourcev1alpha2.ResourceClaimTemplate{}
+				if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: *podClaim.Source.ResourceClaimTemplateName}, template); err != nil {
+					return nil, fmt.Errorf("getting resource claim template %q, %w", *podClaim.Source.ResourceClaimTemplateName, err)
+				}
+				claimID = fmt.Sprintf("%s/%s", client.ObjectKeyFromObject(pod), podClaim.Name)
+				spec = template.Spec.Spec
+			}
+		}
+		if claimName != nil {
+			claim := &resourcev1alpha2.ResourceClaim{}
+			if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: *claimName}, claim); err != nil {
+				// If the claim was deleted, the pod can't start until it's recreated, so there's nothing to track
+				if errors.IsNotFound(err) {
+					logging.FromContext(ctx).With("pod", client.ObjectKeyFromObject(pod), "resourceclaim", *claimName).Errorf("failed resolving resource claim, %s", err)
+					continue
+				}
+				return nil, fmt.Errorf("getting resource claim %q, %w", *claimName, err)
+			}
+			claimID = client.ObjectKeyFromObject(claim).String()
+			spec, status = claim.Spec, claim.Status
+		}
+		if claimID == "" {
+			continue
+		}
+		// Claims that are already allocated can only be used on the nodes that the driver allocated them for
+		if status.Allocation != nil {
+			devices.Claims.Add(status.DriverName, claimID)
+			if selector := status.Allocation.AvailableOnNodes; selector != nil && len(selector.NodeSelectorTerms) > 0 {
+				// Terms are ORed, only use the first term
+				devices.Requirements.Add(NewNodeSelectorRequirements(selector.NodeSelectorTerms[0].MatchExpressions...).Values()...)
+			}
+			continue
+		}
+		resourceClass := &resourcev1alpha2.ResourceClass{}
+		if err := kubeClient.Get(ctx, types.NamespacedName{Name: spec.ResourceClassName}, resourceClass); err != nil {
+			return nil, fmt.Errorf("getting resource class %q, %w", spec.ResourceClassName, err)
+		}
+		devices.Claims.Add(resourceClass.DriverName, claimID)
+		if selector := resourceClass.SuitableNodes; selector != nil && len(selector.NodeSelectorTerms) > 0 {
+			// Terms are ORed, only use the first term
+			devices.Requirements.Add(NewNodeSelectorRequirements(selector.NodeSelectorTerms[0].MatchExpressions...).Values()...)
+		}
+	}
+	return devices, nil
+}
+
+// DeviceUsage tracks the devices that are allocated on a node. Claims can be shared between pods, so we track them by
+// claim rather than by pod to avoid counting the same device twice.
+type DeviceUsage struct {
+	claims DeviceClaims
+	// users counts the pods that use each claim, so that a shared claim is only released once none of them use it
+	users    map[string]int
+	capacity map[string]int
+}
+
+func NewDeviceUsage(capacity map[string]int) *DeviceUsage {
+	return &DeviceUsage{
+		claims:   DeviceClaims{},
+		users:    map[string]int{},
+		capacity: capacity,
+	}
+}
+
+// ExceedsCapacity returns an error if the claims need more devices than the node has left. Claims that are already
+// allocated on the node don't need another device.
+func (d *DeviceUsage) ExceedsCapacity(claims DeviceClaims) error {
+	for driver, c := range d.claims.Union(claims) {
+		if c.Len() > d.claims[driver].Len() && c.Len() > d.capacity[driver] {
+			return fmt.Errorf("would exceed device capacity for %s, %d > %d", driver, c.Len(), d.capacity[driver])
+		}
+	}
+	return nil
+}
+
+// Add records that a pod uses the claims
+func (d *DeviceUsage) Add(claims DeviceClaims) {
+	for driver, c := range claims {
+		for claimID := range c {
+			d.claims.Add(driver, claimID)
+			d.users[claimID]++
+		}
+	}
+}
+
+// Remove records that a pod no longer uses the claims, releasing the devices of claims that aren't used by other pods
+func (d *DeviceUsage) Remove(claims DeviceClaims) {
+	for driver, c := range claims {
+		for claimID := range c {
+			if d.users[claimID]--; d.users[claimID] > 0 {
+				continue
+			}
+			delete(d.users, claimID)
+			d.claims[driver].Delete(claimID)
+		}
+	}
+}
@@ -31,8 +31,10 @@ import (
 
 // Requirement is an efficient represenatation of v1.NodeSelectorRequirement
 type Requirement struct {
-	Key         string
-	complement  bool
+	Key        string
+	complement bool
+	// MinValues is the minimum number of distinct values that instance types must offer for this key
+	MinValues   *int
 	values      sets.Set[string]
 	greaterThan *int
 	lessThan    *int
@@ -78,6 +80,14 @@ func NewRequirement(key string, operator v1.NodeSelectorOperator, values ...stri
 	return r
 }
 
+// NewRequirementWithFlexibility constructs a requirement that additionally tracks the minimum number of values
+// that instance types must span for the key
+func NewRequirementWithFlexibility(key string, operator v1.NodeSelectorOperator, minValues *int, values ...string) *Requirement {
+	r := NewRequirement(key, operator, values...)
+	r.MinValues = minValues
+	return r
+}
+
 func (r *Requirement) NodeSelectorRequirement() v1.NodeSelectorRequirement {
 	switch {
 	case r.greaterThan != nil:
@@ -129,11 +139,14 @@ func (r *Requirement) Intersection(requirement *Requirement) *Requirement {
 	// Complement
 	complement := r.complement && requirement.complement
 
+	// MinValues
+	minValues := maxIntPtr(r.MinValues, requirement.MinValues)
+
 	// Boundaries
 	greaterThan := maxIntPtr(r.greaterThan, requirement.greaterThan)
 	lessThan := minIntPtr(r.lessThan, requirement.lessThan)
 	if greaterThan != nil && lessThan != nil && *greaterThan >= *lessThan {
-		return NewRequirement(r.Key, v1.NodeSelectorOpDoesNotExist)
+		return NewRequirementWithFlexibility(r.Key, v1.NodeSelectorOpDoesNotExist, minValues)
 	}
 
 	// Values
@@ -157,7 +170,7 @@ func (r *Requirement) Intersection(requirement *Requirement) *Requirement {
 		greaterThan, lessThan = nil, nil
 	}
 
-	return &Requirement{Key: r.Key, values: values, complement: complement, greaterThan: greaterThan, lessThan: lessThan}
+	return &Requirement{Key: r.Key, values: values, complement: complement, greaterThan: greaterThan, lessThan: lessThan, MinValues: minValues}
 }
 
 func (r *Requirement) Any() string {
@@ -232,6 +245,9 @@ func (r *Requirement) String() string {
 	if r.lessThan != nil {
 		s += fmt.Sprintf(" <%d", *r.lessThan)
 	}
+	if r.MinValues != nil {
+		s += fmt.Sprintf(" minValues %d", *r.MinValues)
+	}
 	return s
 }
 
@@ -293,6 +293,23 @@ var _ = Describe("Requirement", func() {
 			Expect(lessThan9.Intersection(lessThan9)).To(Equal(lessThan9))
 		})
 	})
+	Context("MinValues", func() {
+		It("should keep the larger minValues when intersecting requirements", func() {
+			withMinValues := NewRequirementWithFlexibility("key", v1.NodeSelectorOpExists, lo.ToPtr(2))
+			Expect(withMinValues.Intersection(exists).MinValues).To(Equal(lo.ToPtr(2)))
+			Expect(exists.Intersection(withMinValues).MinValues).To(Equal(lo.ToPtr(2)))
+			Expect(withMinValues.Intersection(inAB).MinValues).To(Equal(lo.ToPtr(2)))
+			Expect(withMinValues.Intersection(NewRequirementWithFlexibility("key", v1.NodeSelectorOpIn, lo.ToPtr(5), "A")).MinValues).To(Equal(lo.ToPtr(5)))
+			Expect(exists.Intersection(inA).MinValues).To(BeNil())
+		})
+		It("should keep minValues when the intersection is empty", func() {
+			withMinValues := NewRequirementWithFlexibility("key", v1.NodeSelectorOpGt, lo.ToPtr(2), "9")
+			Expect(withMinValues.Intersection(lessThan1).MinValues).To(Equal(lo.ToPtr(2)))
+		})
+		It("should print minValues", func() {
+			Expect(NewRequirementWithFlexibility("key", v1.NodeSelectorOpIn, lo.ToPtr(2), "A", "B").String()).To(Equal("key In [A B] minValues 2"))
+		})
+	})
 	Context("Has", func() {
 		It("should have the right values", func() {
 			Expect(exists.Has("A")).To(BeTrue())
@@ -18,6 +18,7 @@ package scheduling
 
 import (
 	"fmt"
+	"hash/fnv"
 	"slices"
 	"sort"
 	"strings"
@@ -52,6 +53,15 @@ func NewNodeSelectorRequirements(requirements ...v1.NodeSelectorRequirement) Req
 	return r
 }
 
+// NewNodeSelectorRequirementsWithMinValues constructs requirements from NodeSelectorRequirementWithMinValues
+func NewNodeSelectorRequirementsWithMinValues(requirements ...v1beta1.NodeSelectorRequirementWithMinValues) Requirements {
+	r := NewRequirements()
+	for _, requirement := range requirements {
+		r.Add(NewRequirementWithFlexibility(requirement.Key, requirement.Operator, requirement.MinValues, requirement.Values...))
+	}
+	return r
+}
+
 // NewLabelRequirements constructs requirements from labels
 func NewLabelRequirements(labels map[string]string) Requirements {
 	requirements := NewRequirements()
@@ -108,12 +118,25 @@ func HasPreferredNodeAffinity(p *v1.Pod) bool {
 	return p.Spec.Affinity != nil && p.Spec.Affinity.NodeAffinity != nil && len(p.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution) > 0
 }
 
-func (r Requirements) NodeSelectorRequirements() []v1.NodeSelectorRequirement {
-	return lo.Map(lo.Values(r), func(req *Requirement, _ int) v1.NodeSelectorRequirement {
-		return req.NodeSelectorRequirement()
+func (r Requirements) NodeSelectorRequirements() []v1beta1.NodeSelectorRequirementWithMinValues {
+	return lo.Map(lo.Values(r), func(req *Requirement, _ int) v1beta1.NodeSelectorRequirementWithMinValues {
+		return v1beta1.NodeSelectorRequirementWithMinValues{
+			NodeSelectorRequirement: req.NodeSelectorRequirement(),
+			MinValues:               req.MinValues,
+		}
 	})
 }
 
+// HasMinValues returns true if any of the requirements define a minimum number of values
+func (r Requirements) HasMinValues() bool {
+	for _, requirement := range r {
+		if requirement.MinValues != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // Add requirements to provided requirements. Mutates existing requirements
 func (r Requirements) Add(requirements ...*Requirement) {
 	for _, requirement := range requirements {
@@ -310,3 +333,24 @@ func (r Requirements) String() string {
 	slices.Sort(stringRequirements)
 	return strings.Join(stringRequirements, ", ")
 }
+
+// Hash returns a hash of the requirements, which is equal for requirements that allow the same values. The
+// requirements for the ignored keys aren't included in the hash.
+func (r Requirements) Hash(ignoredKeys ...string) uint64 {
+	keys := lo.Without(lo.Keys(r), ignoredKeys...)
+	slices.Sort(keys)
+	h := fnv.New64a()
+	for _, key := range keys {
+		requirement := r[key]
+		fmt.Fprintf(h, "%s %t %q %s %s %s;", key, requirement.complement, sets.List(requirement.values),
+			intPtrString(requirement.greaterThan), intPtrString(requirement.lessThan), intPtrString(requirement.MinValues))
+	}
+	return h.Sum64()
+}
+
+func intPtrString(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return fmt.Sprint(*i)
+}
@@ -25,6 +25,8 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/samber/lo"
 	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/karpenter/pkg/apis/v1beta1"
 )
 
 var _ = Describe("Requirements", func() {
@@ -605,23 +607,36 @@ var _ = Describe("Requirements", func() {
 				lessThan9,
 			)
 			Expect(reqs.NodeSelectorRequirements()).To(ContainElements(
-				v1.NodeSelectorRequirement{Key: "exists", Operator: v1.NodeSelectorOpExists},
-				v1.NodeSelectorRequirement{Key: "doesNotExist", Operator: v1.NodeSelectorOpDoesNotExist},
-				v1.NodeSelectorRequirement{Key: "inA", Operator: v1.NodeSelectorOpIn, Values: []string{"A"}},
-				v1.NodeSelectorRequirement{Key: "inB", Operator: v1.NodeSelectorOpIn, Values: []string{"B"}},
-				v1.NodeSelectorRequirement{Key: "inAB", Operator: v1.NodeSelectorOpIn, Values: []string{"A", "B"}},
-				v1.NodeSelectorRequirement{Key: "notInA", Operator: v1.NodeSelectorOpNotIn, Values: []string{"A"}},
-				v1.NodeSelectorRequirement{Key: "in1", Operator: v1.NodeSelectorOpIn, Values: []string{"1"}},
-				v1.NodeSelectorRequirement{Key: "in9", Operator: v1.NodeSelectorOpIn, Values: []string{"9"}},
-				v1.NodeSelectorRequirement{Key: "in19", Operator: v1.NodeSelectorOpIn, Values: []string{"1", "9"}},
-				v1.NodeSelectorRequirement{Key: "notIn12", Operator: v1.NodeSelectorOpNotIn, Values: []string{"1", "2"}},
-				v1.NodeSelectorRequirement{Key: "greaterThan1", Operator: v1.NodeSelectorOpGt, Values: []string{"1"}},
-				v1.NodeSelectorRequirement{Key: "greaterThan9", Operator: v1.NodeSelectorOpGt, Values: []string{"9"}},
-				v1.NodeSelectorRequirement{Key: "lessThan1", Operator: v1.NodeSelectorOpLt, Values: []string{"1"}},
-				v1.NodeSelectorRequirement{Key: "lessThan9", Operator: v1.NodeSelectorOpLt, Values: []string{"9"}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "exists", Operator: v1.NodeSelectorOpExists}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "doesNotExist", Operator: v1.NodeSelectorOpDoesNotExist}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "inA", Operator: v1.NodeSelectorOpIn, Values: []string{"A"}}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "inB", Operator: v1.NodeSelectorOpIn, Values: []string{"B"}}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "inAB", Operator: v1.NodeSelectorOpIn, Values: []string{"A", "B"}}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "notInA", Operator: v1.NodeSelectorOpNotIn, Values: []string{"A"}}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "in1", Operator: v1.NodeSelectorOpIn, Values: []string{"1"}}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "in9", Operator: v1.NodeSelectorOpIn, Values: []string{"9"}}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "in19", Operator: v1.NodeSelectorOpIn, Values: []string{"1", "9"}}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "notIn12", Operator: v1.NodeSelectorOpNotIn, Values: []string{"1", "2"}}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "greaterThan1", Operator: v1.NodeSelectorOpGt, Values: []string{"1"}}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "greaterThan9", Operator: v1.NodeSelectorOpGt, Values: []string{"9"}}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "lessThan1", Operator: v1.NodeSelectorOpLt, Values: []string{"1"}}},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "lessThan9", Operator: v1.NodeSelectorOpLt, Values: []string{"9"}}},
 			))
 			Expect(reqs.NodeSelectorRequirements()).To(HaveLen(14))
 		})
+		It("should round trip minValues through NodeSelectorRequirements", func() {
+			reqs := NewNodeSelectorRequirementsWithMinValues(
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "exists", Operator: v1.NodeSelectorOpExists}, MinValues: lo.ToPtr(3)},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "inAB", Operator: v1.NodeSelectorOpIn, Values: []string{"A", "B"}}},
+			)
+			Expect(reqs.HasMinValues()).To(BeTrue())
+			Expect(reqs.Get("exists").MinValues).To(Equal(lo.ToPtr(3)))
+			Expect(reqs.NodeSelectorRequirements()).To(ConsistOf(
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "exists", Operator: v1.NodeSelectorOpExists}, MinValues: lo.ToPtr(3)},
+				v1beta1.NodeSelectorRequirementWithMinValues{NodeSelectorRequirement: v1.NodeSelectorRequirement{Key: "inAB", Operator: v1.NodeSelectorOpIn, Values: []string{"A", "B"}}},
+			))
+			Expect(NewNodeSelectorRequirements(v1.NodeSelectorRequirement{Key: "exists", Operator: v1.NodeSelectorOpExists}).HasMinValues()).To(BeFalse())
+		})
 	})
 	Context("Stringify Requirements", func() {
 		It("should print Requirements in the same order", func() {
@@ -645,6 +660,42 @@ var _ = Describe("Requirements", func() {
 			Expect(reqs.String()).To(Equal("doesNotExist DoesNotExist, exists Exists, greaterThan1 Exists >1, greaterThan9 Exists >9, in1 In [1], in19 In [1 9], in9 In [9], inA In [A], inAB In [A B], inB In [B], lessThan1 Exists <1, lessThan9 Exists <9, notIn12 NotIn [1 2], notInA NotIn [A]"))
 		})
 	})
+	Context("Hash", func() {
+		It("should hash requirements that allow the same values equally", func() {
+			lhs := NewRequirements(
+				NewRequirement("inAB", v1.NodeSelectorOpIn, "A", "B"),
+				NewRequirement("notInA", v1.NodeSelectorOpNotIn, "A"),
+				NewRequirement("greaterThan1", v1.NodeSelectorOpGt, "1"),
+			)
+			rhs := NewRequirements(
+				NewRequirement("greaterThan1", v1.NodeSelectorOpGt, "1"),
+				NewRequirement("notInA", v1.NodeSelectorOpNotIn, "A"),
+				NewRequirement("inAB", v1.NodeSelectorOpIn, "B", "A"),
+			)
+			Expect(lhs.Hash()).To(Equal(rhs.Hash()))
+		})
+		It("should hash requirements that allow different values differently", func() {
+			base := NewRequirements(NewRequirement("key", v1.NodeSelectorOpIn, "A"))
+			for _, reqs := range []Requirements{
+				NewRequirements(NewRequirement("key", v1.NodeSelectorOpIn, "B")),
+				NewRequirements(NewRequirement("key", v1.NodeSelectorOpIn, "A", "B")),
+				NewRequirements(NewRequirement("key", v1.NodeSelectorOpNotIn, "A")),
+				NewRequirements(NewRequirement("other", v1.NodeSelectorOpIn, "A")),
+				NewRequirements(NewRequirement("key", v1.NodeSelectorOpIn, "A"), NewRequirement("other", v1.NodeSelectorOpExists)),
+				NewRequirements(NewRequirementWithFlexibility("key", v1.NodeSelectorOpIn, lo.ToPtr(1), "A")),
+			} {
+				Expect(reqs.Hash()).ToNot(Equal(base.Hash()), reqs.String())
+			}
+			Expect(NewRequirements(NewRequirement("key", v1.NodeSelectorOpGt, "1")).Hash()).ToNot(Equal(NewRequirements(NewRequirement("key", v1.NodeSelectorOpGt, "2")).Hash()))
+			Expect(NewRequirements(NewRequirement("key", v1.NodeSelectorOpGt, "1")).Hash()).ToNot(Equal(NewRequirements(NewRequirement("key", v1.NodeSelectorOpLt, "1")).Hash()))
+		})
+		It("should ignore the requirements for ignored keys", func() {
+			lhs := NewRequirements(NewRequirement("key", v1.NodeSelectorOpIn, "A"), NewRequirement(v1.LabelHostname, v1.NodeSelectorOpIn, "foo"))
+			rhs := NewRequirements(NewRequirement("key", v1.NodeSelectorOpIn, "A"), NewRequirement(v1.LabelHostname, v1.NodeSelectorOpIn, "bar"))
+			Expect(lhs.Hash()).ToNot(Equal(rhs.Hash()))
+			Expect(lhs.Hash(v1.LabelHostname)).To(Equal(rhs.Hash(v1.LabelHostname)))
+		})
+	})
 })
 
 // Keeping this in case we need it, I ran for 1m+ samples and had no issues
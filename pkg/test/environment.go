@@ -97,6 +97,12 @@ func NewEnvironment(scheme *runtime.Scheme, options ...functional.Option[Environ
 		// Ref: https://github.com/aws/karpenter-core/pull/330
 		environment.ControlPlane.GetAPIServer().Configure().Set("feature-gates", "MinDomainsInPodTopologySpread=true")
 	}
+	if version.Minor() >= 27 {
+		// DynamicResourceAllocation serves the alpha resource.k8s.io API that pods use to request devices with ResourceClaims
+		// See https://kubernetes.io/docs/concepts/scheduling-eviction/dynamic-resource-allocation/
+		environment.ControlPlane.GetAPIServer().Configure().Append("feature-gates", "DynamicResourceAllocation=true")
+		environment.ControlPlane.GetAPIServer().Configure().Set("runtime-config", "resource.k8s.io/v1alpha2=true")
+	}
 
 	_ = lo.Must(environment.Start())
 
@@ -200,6 +200,7 @@ func ExpectCleanedUp(ctx context.Context, c client.Client) {
 		&storagev1.StorageClass{},
 		&v1beta1.NodePool{},
 		&v1beta1.NodeClaim{},
+		&v1beta1.DisruptionRecord{},
 	} {
 		for _, namespace := range namespaces.Items {
 			wg.Add(1)
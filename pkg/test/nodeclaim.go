@@ -46,7 +46,7 @@ func NodeClaim(overrides ...v1beta1.NodeClaim) *v1beta1.NodeClaim {
 		}
 	}
 	if override.Spec.Requirements == nil {
-		override.Spec.Requirements = []v1.NodeSelectorRequirement{}
+		override.Spec.Requirements = []v1beta1.NodeSelectorRequirementWithMinValues{}
 	}
 	return &v1beta1.NodeClaim{
 		ObjectMeta: ObjectMeta(override.ObjectMeta),
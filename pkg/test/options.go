@@ -28,26 +28,34 @@ import (
 
 type OptionsFields struct {
 	// Vendor Neutral
-	ServiceName          *string
-	DisableWebhook       *bool
-	WebhookPort          *int
-	MetricsPort          *int
-	WebhookMetricsPort   *int
-	HealthProbePort      *int
-	KubeClientQPS        *int
-	KubeClientBurst      *int
-	EnableProfiling      *bool
-	EnableLeaderElection *bool
-	MemoryLimit          *int64
-	LogLevel             *string
-	BatchMaxDuration     *time.Duration
-	BatchIdleDuration    *time.Duration
-	FeatureGates         FeatureGates
+	ServiceName                 *string
+	DisableWebhook              *bool
+	WebhookPort                 *int
+	MetricsPort                 *int
+	WebhookMetricsPort          *int
+	HealthProbePort             *int
+	SimulationPort              *int
+	SimulationAddress           *string
+	KubeClientQPS               *int
+	KubeClientBurst             *int
+	EnableProfiling             *bool
+	EnableLeaderElection        *bool
+	MemoryLimit                 *int64
+	LogLevel                    *string
+	BatchMaxDuration            *time.Duration
+	BatchIdleDuration           *time.Duration
+	BatchAdaptive               *bool
+	DisruptionDryRun            *bool
+	DisruptionRecordTTL         *time.Duration
+	ConsolidationPlanner        *string
+	PreProvisionSchedulingGates []string
+	FeatureGates                FeatureGates
 }
 
 type FeatureGates struct {
 	Drift                   *bool
 	SpotToSpotConsolidation *bool
+	PreProvisionGatedPods   *bool
 }
 
 func Options(overrides ...OptionsFields) *options.Options {
@@ -59,23 +67,31 @@ func Options(overrides ...OptionsFields) *options.Options {
 	}
 
 	return &options.Options{
-		ServiceName:          lo.FromPtrOr(opts.ServiceName, ""),
-		DisableWebhook:       lo.FromPtrOr(opts.DisableWebhook, false),
-		WebhookPort:          lo.FromPtrOr(opts.WebhookPort, 8443),
-		MetricsPort:          lo.FromPtrOr(opts.MetricsPort, 8000),
-		WebhookMetricsPort:   lo.FromPtrOr(opts.WebhookMetricsPort, 8001),
-		HealthProbePort:      lo.FromPtrOr(opts.HealthProbePort, 8081),
-		KubeClientQPS:        lo.FromPtrOr(opts.KubeClientQPS, 200),
-		KubeClientBurst:      lo.FromPtrOr(opts.KubeClientBurst, 300),
-		EnableProfiling:      lo.FromPtrOr(opts.EnableProfiling, false),
-		EnableLeaderElection: lo.FromPtrOr(opts.EnableLeaderElection, true),
-		MemoryLimit:          lo.FromPtrOr(opts.MemoryLimit, -1),
-		LogLevel:             lo.FromPtrOr(opts.LogLevel, ""),
-		BatchMaxDuration:     lo.FromPtrOr(opts.BatchMaxDuration, 10*time.Second),
-		BatchIdleDuration:    lo.FromPtrOr(opts.BatchIdleDuration, time.Second),
+		ServiceName:                 lo.FromPtrOr(opts.ServiceName, ""),
+		DisableWebhook:              lo.FromPtrOr(opts.DisableWebhook, false),
+		WebhookPort:                 lo.FromPtrOr(opts.WebhookPort, 8443),
+		MetricsPort:                 lo.FromPtrOr(opts.MetricsPort, 8000),
+		WebhookMetricsPort:          lo.FromPtrOr(opts.WebhookMetricsPort, 8001),
+		HealthProbePort:             lo.FromPtrOr(opts.HealthProbePort, 8081),
+		SimulationPort:              lo.FromPtrOr(opts.SimulationPort, 0),
+		SimulationAddress:           lo.FromPtrOr(opts.SimulationAddress, "127.0.0.1"),
+		KubeClientQPS:               lo.FromPtrOr(opts.KubeClientQPS, 200),
+		KubeClientBurst:             lo.FromPtrOr(opts.KubeClientBurst, 300),
+		EnableProfiling:             lo.FromPtrOr(opts.EnableProfiling, false),
+		EnableLeaderElection:        lo.FromPtrOr(opts.EnableLeaderElection, true),
+		MemoryLimit:                 lo.FromPtrOr(opts.MemoryLimit, -1),
+		LogLevel:                    lo.FromPtrOr(opts.LogLevel, ""),
+		BatchMaxDuration:            lo.FromPtrOr(opts.BatchMaxDuration, 10*time.Second),
+		BatchIdleDuration:           lo.FromPtrOr(opts.BatchIdleDuration, time.Second),
+		BatchAdaptive:               lo.FromPtrOr(opts.BatchAdaptive, false),
+		DisruptionDryRun:            lo.FromPtrOr(opts.DisruptionDryRun, false),
+		DisruptionRecordTTL:         lo.FromPtrOr(opts.DisruptionRecordTTL, 7*24*time.Hour),
+		ConsolidationPlanner:        lo.FromPtrOr(opts.ConsolidationPlanner, options.ConsolidationPlannerBinarySearch),
+		PreProvisionSchedulingGates: opts.PreProvisionSchedulingGates,
 		FeatureGates: options.FeatureGates{
 			Drift:                   lo.FromPtrOr(opts.FeatureGates.Drift, false),
 			SpotToSpotConsolidation: lo.FromPtrOr(opts.FeatureGates.SpotToSpotConsolidation, false),
+			PreProvisionGatedPods:   lo.FromPtrOr(opts.FeatureGates.PreProvisionGatedPods, false),
 		},
 	}
 }
@@ -19,6 +19,7 @@ package pod
 import (
 	"time"
 
+	"github.com/samber/lo"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/clock"
@@ -88,6 +89,24 @@ func IsProvisionable(pod *v1.Pod) bool {
 		!IsOwnedByNode(pod)
 }
 
+// IsPreProvisionable checks if we should provision capacity ahead of time for a pod that's held by scheduling gates by
+// ensuring that the pod:
+// - Is held by scheduling gates, which keep the kube-scheduler from ever marking it as "Unschedulable"
+// - Has the `karpenter.sh/pre-provision` annotation or is held by one of the gates that we pre-provision for
+// - Is an active pod (isn't terminal or actively terminating)
+// - Has not been bound to a node
+// - Isn't owned by a DaemonSet
+// - Isn't a mirror pod (https://kubernetes.io/docs/tasks/configure-pod-container/static-pod/)
+func IsPreProvisionable(pod *v1.Pod, gates []string) bool {
+	return IsGated(pod) &&
+		(pod.Annotations[v1beta1.PreProvisionAnnotationKey] == "true" ||
+			lo.ContainsBy(pod.Spec.SchedulingGates, func(g v1.PodSchedulingGate) bool { return lo.Contains(gates, g.Name) })) &&
+		IsActive(pod) &&
+		!IsScheduled(pod) &&
+		!IsOwnedByDaemonSet(pod) &&
+		!IsOwnedByNode(pod)
+}
+
 // IsDisruptable checks if a pod can be disrupted based on validating the `karpenter.sh/do-not-disrupt` annotation on the pod.
 // It checks whether the following is true for the pod:
 // - Has the `karpenter.sh/do-not-disrupt` annotation
@@ -115,6 +134,11 @@ func IsScheduled(pod *v1.Pod) bool {
 	return pod.Spec.NodeName != ""
 }
 
+// IsGated returns true if the pod is held by scheduling gates, which keep it from being scheduled until they're removed
+func IsGated(pod *v1.Pod) bool {
+	return len(pod.Spec.SchedulingGates) > 0
+}
+
 func IsPreempting(pod *v1.Pod) bool {
 	return pod.Status.NominatedNodeName != ""
 }
@@ -135,6 +159,16 @@ func IsStuckTerminating(pod *v1.Pod, clk clock.Clock) bool {
 	return IsTerminating(pod) && clk.Since(pod.DeletionTimestamp.Time) > time.Minute
 }
 
+// Priority returns the pod's priority, which defaults to zero when the pod doesn't have a priority class
+func Priority(pod *v1.Pod) int32 {
+	return lo.FromPtr(pod.Spec.Priority)
+}
+
+// CanPreempt returns true if the pod can preempt lower priority pods to schedule
+func CanPreempt(pod *v1.Pod) bool {
+	return lo.FromPtrOr(pod.Spec.PreemptionPolicy, v1.PreemptLowerPriority) != v1.PreemptNever
+}
+
 func IsOwnedByStatefulSet(pod *v1.Pod) bool {
 	return IsOwnedBy(pod, []schema.GroupVersionKind{
 		{Group: "apps", Version: "v1", Kind: "StatefulSet"},
@@ -154,6 +188,13 @@ func IsOwnedByNode(pod *v1.Pod) bool {
 	})
 }
 
+// IsOwnedByNodePool returns true if the pod is a virtual pod that Karpenter simulates for a NodePool's headroom
+func IsOwnedByNodePool(pod *v1.Pod) bool {
+	return IsOwnedBy(pod, []schema.GroupVersionKind{
+		v1beta1.SchemeGroupVersion.WithKind("NodePool"),
+	})
+}
+
 func IsOwnedBy(pod *v1.Pod, gvks []schema.GroupVersionKind) bool {
 	for _, ignoredOwner := range gvks {
 		for _, owner := range pod.ObjectMeta.OwnerReferences {